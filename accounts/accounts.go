@@ -48,6 +48,13 @@ type Wallet interface {
 	// SignTextWithPassphrase is identical to Signtext, but also takes a password
 	SignTextWithPassphrase(account Account, passphrase string, hash []byte) ([]byte, error)
 
+	// SignTypedData requests the wallet to sign the EIP-712 hash of the given typed data.
+	// This method should return the signature in 'canonical' format, with v 0 or 1.
+	SignTypedData(account Account, typedData *TypedData) ([]byte, error)
+
+	// SignTypedDataWithPassphrase is identical to SignTypedData, but also takes a password
+	SignTypedDataWithPassphrase(account Account, passphrase string, typedData *TypedData) ([]byte, error)
+
 	// SignTx requests the wallet to sign the given transaction.
 	SignTx(account Account, tx *types.Transaction) (*types.Transaction, error)
 
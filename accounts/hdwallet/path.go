@@ -0,0 +1,130 @@
+package hdwallet
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+)
+
+var (
+	// DefaultRootDerivationPath is the root path of the Ethereum accounts (BIP-44, coin type 60)
+	DefaultRootDerivationPath = DerivationPath{
+		hdkeychain.HardenedKeyStart + 44, hdkeychain.HardenedKeyStart + 60, hdkeychain.HardenedKeyStart,
+	}
+
+	// DefaultBaseDerivationPath is the base path of the sequentially derived accounts, m/44'/60'/0'/0
+	DefaultBaseDerivationPath = DerivationPath{
+		hdkeychain.HardenedKeyStart + 44, hdkeychain.HardenedKeyStart + 60, hdkeychain.HardenedKeyStart, 0,
+	}
+
+	errInvalidDerivationPath = errors.New("invalid derivation path")
+)
+
+// DerivationPath is the BIP-32 path of the child key, the hardened components are offset by 2^31
+type DerivationPath []uint32
+
+// ParseDerivationPath parses the path given either as absolute (m/44'/60'/0'/0/1)
+// or as relative to the default base path (1 or 0/1)
+func ParseDerivationPath(path string) (DerivationPath, error) {
+	return ParseDerivationPathFrom(path, DefaultBaseDerivationPath)
+}
+
+// ParseDerivationPathFrom parses the path given either as absolute or as relative to the given base path
+func ParseDerivationPathFrom(path string, base DerivationPath) (DerivationPath, error) {
+	components := strings.Split(strings.TrimSpace(path), "/")
+
+	var result DerivationPath
+
+	switch {
+	case len(components) == 0 || components[0] == "":
+		return nil, fmt.Errorf("%w: empty path", errInvalidDerivationPath)
+	case strings.TrimSpace(components[0]) == "m":
+		components = components[1:]
+	default:
+		result = append(result, base...)
+	}
+
+	if len(components) == 0 {
+		return nil, fmt.Errorf("%w: no components after the root", errInvalidDerivationPath)
+	}
+
+	for _, component := range components {
+		component = strings.TrimSpace(component)
+
+		var offset uint32
+
+		if hardened := strings.TrimSuffix(component, "'"); hardened != component {
+			component, offset = strings.TrimSpace(hardened), hdkeychain.HardenedKeyStart
+		}
+
+		index, err := strconv.ParseUint(component, 0, 32)
+		if err != nil || index >= hdkeychain.HardenedKeyStart {
+			return nil, fmt.Errorf("%w: invalid component %q", errInvalidDerivationPath, component)
+		}
+
+		result = append(result, uint32(index)+offset)
+	}
+
+	return result, nil
+}
+
+// String returns the path in the canonical form, e.g. m/44'/60'/0'/0/1
+func (p DerivationPath) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("m")
+
+	for _, component := range p {
+		sb.WriteString("/")
+
+		if component >= hdkeychain.HardenedKeyStart {
+			sb.WriteString(strconv.FormatUint(uint64(component-hdkeychain.HardenedKeyStart), 10))
+			sb.WriteString("'")
+		} else {
+			sb.WriteString(strconv.FormatUint(uint64(component), 10))
+		}
+	}
+
+	return sb.String()
+}
+
+// child returns the path of the index-th child of the path
+func (p DerivationPath) child(index uint32) DerivationPath {
+	child := make(DerivationPath, len(p), len(p)+1)
+	copy(child, p)
+
+	return append(child, index)
+}
+
+// derive derives the private key at the path from the master key
+func (p DerivationPath) derive(master *hdkeychain.ExtendedKey) (*ecdsa.PrivateKey, error) {
+	key := master
+
+	for _, component := range p {
+		child, err := key.Derive(component)
+		if err != nil {
+			return nil, err
+		}
+
+		if key != master {
+			key.Zero()
+		}
+
+		key = child
+	}
+
+	privKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if key != master {
+		key.Zero()
+	}
+
+	return privKey.ToECDSA(), nil
+}
@@ -0,0 +1,328 @@
+package hdwallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+	"github.com/tyler-smith/go-bip39"
+
+	"github.com/0xPolygon/polygon-edge/accounts"
+	"github.com/0xPolygon/polygon-edge/accounts/event"
+	"github.com/0xPolygon/polygon-edge/accounts/keystore"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// version is the version of the wallet file format
+	version = 1
+
+	// mnemonicEntropyBits is the entropy of the generated mnemonics, resulting in 24 words
+	mnemonicEntropyBits = 256
+
+	walletFileExt = ".json"
+)
+
+var (
+	ErrLocked = accounts.NewAuthNeededError("password or unlock")
+
+	// ErrWalletAlreadyExists is returned if the imported mnemonic is already present in the store
+	ErrWalletAlreadyExists = errors.New("wallet already exists")
+
+	errInvalidMnemonic = errors.New("invalid mnemonic")
+)
+
+var HDStoreType = reflect.TypeOf(&HDStore{})
+
+// encryptedWallet is the wallet file, holding the encrypted mnemonic and the tracked accounts
+type encryptedWallet struct {
+	ID       string          `json:"id"`
+	Version  int             `json:"version"`
+	BasePath string          `json:"basePath"`
+	Crypto   keystore.Crypto `json:"crypto"`
+	Accounts []walletAccount `json:"accounts"`
+}
+
+type walletAccount struct {
+	Address types.Address `json:"address"`
+	Path    string        `json:"path"`
+}
+
+// HDStore manages the HD wallets stored in a directory on disk, one file per wallet
+type HDStore struct {
+	dir     string
+	scryptN int
+	scryptP int
+	logger  hclog.Logger
+
+	wallets      []accounts.Wallet
+	eventHandler *event.EventHandler
+
+	manager accounts.AccountManager
+
+	mu sync.RWMutex
+}
+
+func NewHDStore(dir string, scryptN, scryptP int, logger hclog.Logger) (*HDStore, error) {
+	hs := &HDStore{
+		dir:     dir,
+		scryptN: scryptN,
+		scryptP: scryptP,
+		logger:  logger,
+	}
+
+	if err := hs.init(); err != nil {
+		return nil, fmt.Errorf("could not initialize hd wallet store: %w", err)
+	}
+
+	return hs, nil
+}
+
+func (hs *HDStore) init() error {
+	if err := common.CreateDirSafe(hs.dir, 0700); err != nil {
+		return fmt.Errorf("could not create hd wallet directory: %w", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(hs.dir, "*"+walletFileExt))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		w, err := hs.load(file)
+		if err != nil {
+			return fmt.Errorf("could not load hd wallet %s: %w", filepath.Base(file), err)
+		}
+
+		hs.wallets = append(hs.wallets, w)
+	}
+
+	hs.logger.Debug("hd wallets loaded", "count", len(hs.wallets))
+
+	return nil
+}
+
+func (hs *HDStore) load(file string) (*Wallet, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var encrypted encryptedWallet
+	if err := json.Unmarshal(raw, &encrypted); err != nil {
+		return nil, err
+	}
+
+	if encrypted.Version != version {
+		return nil, fmt.Errorf("version not supported: %v", encrypted.Version)
+	}
+
+	basePath, err := ParseDerivationPath(encrypted.BasePath)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Wallet{
+		id:       encrypted.ID,
+		basePath: basePath,
+		crypto:   encrypted.Crypto,
+		store:    hs,
+		accounts: make([]accounts.Account, 0, len(encrypted.Accounts)),
+		paths:    make(map[types.Address]DerivationPath, len(encrypted.Accounts)),
+	}
+
+	for _, acc := range encrypted.Accounts {
+		path, err := ParseDerivationPath(acc.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		w.accounts = append(w.accounts, accounts.Account{Address: acc.Address})
+		w.paths[acc.Address] = path
+	}
+
+	if len(w.accounts) == 0 {
+		return nil, errors.New("wallet has no accounts")
+	}
+
+	return w, nil
+}
+
+// save writes the wallet file, the caller must hold the lock of the wallet
+func (hs *HDStore) save(w *Wallet) error {
+	encrypted := encryptedWallet{
+		ID:       w.id,
+		Version:  version,
+		BasePath: w.basePath.String(),
+		Crypto:   w.crypto,
+		Accounts: make([]walletAccount, len(w.accounts)),
+	}
+
+	for i, acc := range w.accounts {
+		encrypted.Accounts[i] = walletAccount{Address: acc.Address, Path: w.paths[acc.Address].String()}
+	}
+
+	raw, err := json.Marshal(encrypted)
+	if err != nil {
+		return err
+	}
+
+	return common.SaveFileSafe(filepath.Join(hs.dir, w.id+walletFileExt), raw, 0600)
+}
+
+func (hs *HDStore) Wallets() []accounts.Wallet {
+	hs.mu.RLock()
+	defer hs.mu.RUnlock()
+
+	cpy := make([]accounts.Wallet, len(hs.wallets))
+
+	copy(cpy, hs.wallets)
+
+	return cpy
+}
+
+// Wallet returns the HD wallet tracking the given account
+func (hs *HDStore) Wallet(addr types.Address) (*Wallet, error) {
+	hs.mu.RLock()
+	defer hs.mu.RUnlock()
+
+	for _, w := range hs.wallets {
+		if w.Contains(accounts.Account{Address: addr}) {
+			return w.(*Wallet), nil //nolint:forcetypeassert
+		}
+	}
+
+	return nil, accounts.ErrUnknownAccount
+}
+
+func (hs *HDStore) SetEventHandler(eventHandler *event.EventHandler) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	hs.eventHandler = eventHandler
+}
+
+func (hs *HDStore) SetManager(manager accounts.AccountManager) {
+	hs.manager = manager
+}
+
+// NewMnemonic generates the new BIP-39 mnemonic of 24 words. The mnemonics are generated only by the
+// command line, so that they are never sent back by the node and are shown only to the user creating them
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(mnemonicEntropyBits)
+	if err != nil {
+		return "", err
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
+
+// ImportMnemonic stores the wallet of the existing mnemonic with the first account of the default base path
+func (hs *HDStore) ImportMnemonic(mnemonic, passphrase string) (*Wallet, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidMnemonic, err)
+	}
+
+	master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, err
+	}
+
+	defer master.Zero()
+
+	firstPath := DefaultBaseDerivationPath.child(0)
+
+	first, err := deriveAccount(master, firstPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cryptoStruct, err := keystore.EncryptData([]byte(mnemonic), []byte(passphrase), hs.scryptN, hs.scryptP)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Wallet{
+		id:       id.String(),
+		basePath: DefaultBaseDerivationPath,
+		crypto:   cryptoStruct,
+		store:    hs,
+		accounts: []accounts.Account{first},
+		paths:    map[types.Address]DerivationPath{first.Address: firstPath},
+	}
+
+	hs.mu.Lock()
+
+	for _, existing := range hs.wallets {
+		if existing.Contains(first) {
+			hs.mu.Unlock()
+
+			return nil, ErrWalletAlreadyExists
+		}
+	}
+
+	if err := hs.save(w); err != nil {
+		hs.mu.Unlock()
+
+		return nil, err
+	}
+
+	hs.wallets = append(hs.wallets, w)
+
+	hs.mu.Unlock()
+
+	hs.publish(accounts.WalletEvent{Wallet: w, Kind: accounts.WalletArrived})
+
+	return w, nil
+}
+
+// decryptMaster decrypts the mnemonic and derives the master key from it
+func (hs *HDStore) decryptMaster(cryptoStruct keystore.Crypto, passphrase string) (*hdkeychain.ExtendedKey, error) {
+	mnemonic, err := keystore.DecryptData(cryptoStruct, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	defer clear(mnemonic)
+
+	seed := bip39.NewSeed(string(mnemonic), "")
+	defer clear(seed)
+
+	return hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+}
+
+func (hs *HDStore) publish(walletEvent accounts.WalletEvent) {
+	hs.mu.RLock()
+	eventHandler := hs.eventHandler
+	hs.mu.RUnlock()
+
+	// the store is not attached to the manager yet
+	if eventHandler == nil {
+		return
+	}
+
+	eventHandler.Publish(accounts.WalletEventKey, walletEvent)
+}
+
+func (hs *HDStore) signer() crypto.TxSigner {
+	return hs.manager.GetSigner()
+}
@@ -0,0 +1,406 @@
+package hdwallet
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+
+	"github.com/0xPolygon/polygon-edge/accounts"
+	"github.com/0xPolygon/polygon-edge/accounts/keystore"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// ChainStateReader reads the state of the accounts at the head of the chain, used by the account discovery
+type ChainStateReader interface {
+	// AccountState returns the nonce and the balance of the account
+	AccountState(addr types.Address) (uint64, *big.Int, error)
+}
+
+// Wallet is the hierarchical deterministic wallet, deriving its accounts from the encrypted mnemonic.
+// The derived accounts are tracked with their paths, so that they are listed while the wallet is locked
+type Wallet struct {
+	id       string
+	basePath DerivationPath
+	crypto   keystore.Crypto
+	store    *HDStore
+
+	mu       sync.RWMutex
+	accounts []accounts.Account
+	paths    map[types.Address]DerivationPath
+	master   *hdkeychain.ExtendedKey // master key, set while the wallet is open
+	abort    chan struct{}           // closed when the timed open is aborted
+}
+
+// ID returns the identifier of the wallet
+func (w *Wallet) ID() string {
+	return w.id
+}
+
+// BasePath returns the base path of the sequentially derived accounts
+func (w *Wallet) BasePath() DerivationPath {
+	return w.basePath
+}
+
+func (w *Wallet) Status() (string, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.master != nil {
+		return "Unlocked", nil
+	}
+
+	return "Locked", nil
+}
+
+// Open decrypts the mnemonic and keeps the master key in memory until the wallet is closed
+func (w *Wallet) Open(passphrase string) error {
+	return w.TimedOpen(passphrase, 0)
+}
+
+// TimedOpen opens the wallet, closing it after the timeout. The wallet stays open indefinitely
+// if the timeout is zero. Opening the already open wallet replaces its timeout
+func (w *Wallet) TimedOpen(passphrase string, timeout time.Duration) error {
+	master, err := w.store.decryptMaster(w.crypto, passphrase)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+
+	w.closeNoLock()
+	w.master = master
+
+	if timeout > 0 {
+		abort := make(chan struct{})
+		w.abort = abort
+
+		go w.expire(abort, timeout)
+	}
+
+	w.mu.Unlock()
+
+	w.store.publish(accounts.WalletEvent{Wallet: w, Kind: accounts.WalletOpened})
+
+	return nil
+}
+
+func (w *Wallet) expire(abort chan struct{}, timeout time.Duration) {
+	t := time.NewTimer(timeout)
+	defer t.Stop()
+
+	select {
+	case <-abort:
+		// the wallet was closed or reopened
+	case <-t.C:
+		w.mu.Lock()
+		// only close if the wallet was not reopened in the meantime
+		if w.abort == abort {
+			w.closeNoLock()
+		}
+
+		w.mu.Unlock()
+	}
+}
+
+// Close removes the master key from memory
+func (w *Wallet) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.closeNoLock()
+
+	return nil
+}
+
+func (w *Wallet) closeNoLock() {
+	if w.abort != nil {
+		close(w.abort)
+		w.abort = nil
+	}
+
+	if w.master != nil {
+		w.master.Zero()
+		w.master = nil
+	}
+}
+
+func (w *Wallet) Accounts() []accounts.Account {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	cpy := make([]accounts.Account, len(w.accounts))
+	copy(cpy, w.accounts)
+
+	return cpy
+}
+
+func (w *Wallet) Contains(account accounts.Account) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	_, ok := w.paths[account.Address]
+
+	return ok
+}
+
+// Path returns the derivation path of the tracked account
+func (w *Wallet) Path(account accounts.Account) (DerivationPath, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	path, ok := w.paths[account.Address]
+
+	return path, ok
+}
+
+// Derive derives the account at the given path from the open wallet.
+// If pin is set, the account is tracked by the wallet from then on
+func (w *Wallet) Derive(path DerivationPath, pin bool) (accounts.Account, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.master == nil {
+		return accounts.Account{}, ErrLocked
+	}
+
+	account, err := deriveAccount(w.master, path)
+	if err != nil {
+		return accounts.Account{}, err
+	}
+
+	if !pin {
+		return account, nil
+	}
+
+	if _, ok := w.paths[account.Address]; ok {
+		return account, nil
+	}
+
+	if err := w.trackAndSave([]accounts.Account{account}, []DerivationPath{path}); err != nil {
+		return accounts.Account{}, err
+	}
+
+	return account, nil
+}
+
+// SelfDerive discovers the used accounts under each of the base paths, deriving the sequential accounts
+// until the first one with neither nonce nor balance. The used accounts and the first unused account
+// of each base path are tracked, the newly tracked accounts are returned
+func (w *Wallet) SelfDerive(bases []DerivationPath, chain ChainStateReader) ([]accounts.Account, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.master == nil {
+		return nil, ErrLocked
+	}
+
+	var (
+		discovered []accounts.Account
+		paths      []DerivationPath
+	)
+
+	for _, base := range bases {
+		for index := uint32(0); index < hdkeychain.HardenedKeyStart; index++ {
+			path := base.child(index)
+
+			account, err := deriveAccount(w.master, path)
+			if err != nil {
+				return nil, err
+			}
+
+			nonce, balance, err := chain.AccountState(account.Address)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, ok := w.paths[account.Address]; !ok && !containsAccount(discovered, account) {
+				discovered = append(discovered, account)
+				paths = append(paths, path)
+			}
+
+			if nonce == 0 && (balance == nil || balance.Sign() == 0) {
+				break
+			}
+		}
+	}
+
+	if len(discovered) == 0 {
+		return nil, nil
+	}
+
+	if err := w.trackAndSave(discovered, paths); err != nil {
+		return nil, err
+	}
+
+	return discovered, nil
+}
+
+// trackAndSave adds the accounts to the wallet and persists it, the caller must hold the write lock
+func (w *Wallet) trackAndSave(newAccounts []accounts.Account, paths []DerivationPath) error {
+	prevLen := len(w.accounts)
+
+	for i, account := range newAccounts {
+		w.accounts = append(w.accounts, account)
+		w.paths[account.Address] = paths[i]
+	}
+
+	if err := w.store.save(w); err != nil {
+		// the accounts are not persisted, so they are not tracked either
+		for _, account := range newAccounts {
+			delete(w.paths, account.Address)
+		}
+
+		w.accounts = w.accounts[:prevLen]
+
+		return err
+	}
+
+	return nil
+}
+
+// privateKey derives the private key of the tracked account from the open wallet
+func (w *Wallet) privateKey(account accounts.Account) (*ecdsa.PrivateKey, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	path, ok := w.paths[account.Address]
+	if !ok {
+		return nil, accounts.ErrUnknownAccount
+	}
+
+	if w.master == nil {
+		return nil, ErrLocked
+	}
+
+	return path.derive(w.master)
+}
+
+// privateKeyWithPassphrase derives the private key of the tracked account from the mnemonic decrypted
+// with the passphrase, without opening the wallet
+func (w *Wallet) privateKeyWithPassphrase(account accounts.Account, passphrase string) (*ecdsa.PrivateKey, error) {
+	path, ok := w.Path(account)
+	if !ok {
+		return nil, accounts.ErrUnknownAccount
+	}
+
+	master, err := w.store.decryptMaster(w.crypto, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	defer master.Zero()
+
+	return path.derive(master)
+}
+
+func (w *Wallet) signHash(account accounts.Account, hash []byte) ([]byte, error) {
+	key, err := w.privateKey(account)
+	if err != nil {
+		return nil, err
+	}
+
+	defer zeroKey(key)
+
+	return crypto.Sign(key, hash)
+}
+
+func (w *Wallet) signHashWithPassphrase(account accounts.Account, passphrase string, hash []byte) ([]byte, error) {
+	key, err := w.privateKeyWithPassphrase(account, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	defer zeroKey(key)
+
+	return crypto.Sign(key, hash)
+}
+
+func (w *Wallet) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	return w.signHash(account, crypto.Keccak256(data))
+}
+
+func (w *Wallet) SignDataWithPassphrase(account accounts.Account,
+	passphrase, mimeType string, data []byte) ([]byte, error) {
+	return w.signHashWithPassphrase(account, passphrase, crypto.Keccak256(data))
+}
+
+func (w *Wallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
+	return w.signHash(account, accounts.TextHash(text))
+}
+
+func (w *Wallet) SignTextWithPassphrase(account accounts.Account,
+	passphrase string, text []byte) ([]byte, error) {
+	return w.signHashWithPassphrase(account, passphrase, accounts.TextHash(text))
+}
+
+func (w *Wallet) SignTypedData(account accounts.Account, typedData *accounts.TypedData) ([]byte, error) {
+	hash, err := accounts.TypedDataHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.signHash(account, hash)
+}
+
+func (w *Wallet) SignTypedDataWithPassphrase(account accounts.Account,
+	passphrase string, typedData *accounts.TypedData) ([]byte, error) {
+	hash, err := accounts.TypedDataHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.signHashWithPassphrase(account, passphrase, hash)
+}
+
+func (w *Wallet) SignTx(account accounts.Account, tx *types.Transaction) (*types.Transaction, error) {
+	key, err := w.privateKey(account)
+	if err != nil {
+		return nil, err
+	}
+
+	defer zeroKey(key)
+
+	return w.store.signer().SignTx(tx, key)
+}
+
+func (w *Wallet) SignTxWithPassphrase(account accounts.Account,
+	passphrase string, tx *types.Transaction) (*types.Transaction, error) {
+	key, err := w.privateKeyWithPassphrase(account, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	defer zeroKey(key)
+
+	return w.store.signer().SignTx(tx, key)
+}
+
+func deriveAccount(master *hdkeychain.ExtendedKey, path DerivationPath) (accounts.Account, error) {
+	key, err := path.derive(master)
+	if err != nil {
+		return accounts.Account{}, err
+	}
+
+	defer zeroKey(key)
+
+	return accounts.Account{Address: crypto.PubKeyToAddress(&key.PublicKey)}, nil
+}
+
+func containsAccount(accs []accounts.Account, account accounts.Account) bool {
+	for _, acc := range accs {
+		if acc.Address == account.Address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// zeroKey zeroes the private key in memory
+func zeroKey(k *ecdsa.PrivateKey) {
+	clear(k.D.Bits())
+}
@@ -0,0 +1,257 @@
+package hdwallet
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/accounts"
+	"github.com/0xPolygon/polygon-edge/accounts/event"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	pass = "foo"
+
+	// testMnemonic is the well known development mnemonic, its accounts are funded by the local dev chains
+	testMnemonic = "test test test test test test test test test test test junk"
+
+	veryLightScryptN = 2
+	veryLightScryptP = 1
+)
+
+var (
+	testAccount0 = types.StringToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	testAccount1 = types.StringToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	testAccount2 = types.StringToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+)
+
+type mockChainState map[types.Address]uint64
+
+func (m mockChainState) AccountState(addr types.Address) (uint64, *big.Int, error) {
+	return m[addr], big.NewInt(0), nil
+}
+
+func tmpHDStore(t *testing.T) (string, *HDStore) {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	hs, err := NewHDStore(dir, veryLightScryptN, veryLightScryptP, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	eventHandler := event.NewEventHandler()
+	updates := make(chan event.Event, 100)
+	eventHandler.Subscribe(accounts.WalletEventKey, updates)
+	hs.SetEventHandler(eventHandler)
+
+	return dir, hs
+}
+
+func TestDerivationPath(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		input  string
+		output string
+	}{
+		{"m/44'/60'/0'/0/1", "m/44'/60'/0'/0/1"},
+		{" m / 44' / 60' / 0' / 0 / 0x10 ", "m/44'/60'/0'/0/16"},
+		{"5", "m/44'/60'/0'/0/5"},
+		{"1/2", "m/44'/60'/0'/0/1/2"},
+		{"m/2147483647'", "m/2147483647'"},
+	}
+
+	for _, c := range cases {
+		path, err := ParseDerivationPath(c.input)
+		require.NoError(t, err, c.input)
+		require.Equal(t, c.output, path.String())
+	}
+
+	for _, invalid := range []string{"", "m", "m/", "m/-1", "m/2147483648", "m/a'", "m/44''"} {
+		_, err := ParseDerivationPath(invalid)
+		require.ErrorIs(t, err, errInvalidDerivationPath, invalid)
+	}
+
+	path, err := ParseDerivationPathFrom("3", DefaultRootDerivationPath)
+	require.NoError(t, err)
+	require.Equal(t, "m/44'/60'/0'/3", path.String())
+}
+
+func TestHDStore_ImportMnemonic(t *testing.T) {
+	t.Parallel()
+
+	dir, hs := tmpHDStore(t)
+
+	// the BIP-39 test mnemonic with the expected first account of m/44'/60'/0'/0
+	w, err := hs.ImportMnemonic("abandon abandon abandon abandon abandon abandon abandon "+
+		"abandon abandon abandon abandon about", pass)
+	require.NoError(t, err)
+	require.Equal(t,
+		[]accounts.Account{{Address: types.StringToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")}},
+		w.Accounts(),
+	)
+
+	_, err = hs.ImportMnemonic("abandon abandon abandon abandon abandon abandon abandon "+
+		"abandon abandon abandon abandon abandon", pass)
+	require.ErrorIs(t, err, errInvalidMnemonic)
+
+	// the extra whitespace does not change the wallet
+	_, err = hs.ImportMnemonic("  abandon abandon abandon abandon abandon abandon abandon "+
+		"abandon abandon abandon  abandon about ", "other")
+	require.ErrorIs(t, err, ErrWalletAlreadyExists)
+
+	mnemonic, err := NewMnemonic()
+	require.NoError(t, err)
+	require.Len(t, strings.Fields(mnemonic), 24)
+
+	created, err := hs.ImportMnemonic(mnemonic, pass)
+	require.NoError(t, err)
+	require.Len(t, hs.Wallets(), 2)
+
+	// the wallets are loaded from the disk
+	reloaded, err := NewHDStore(dir, veryLightScryptN, veryLightScryptP, hclog.NewNullLogger())
+	require.NoError(t, err)
+	require.Len(t, reloaded.Wallets(), 2)
+
+	reloadedWallet, err := reloaded.Wallet(created.Accounts()[0].Address)
+	require.NoError(t, err)
+	require.Equal(t, created.ID(), reloadedWallet.ID())
+	require.NoError(t, reloadedWallet.Open(pass))
+
+	account, err := reloadedWallet.Derive(DefaultBaseDerivationPath.child(0), false)
+	require.NoError(t, err)
+	require.Equal(t, created.Accounts()[0], account)
+
+	_, err = reloaded.Wallet(testAccount0)
+	require.ErrorIs(t, err, accounts.ErrUnknownAccount)
+}
+
+func TestWallet_Derive(t *testing.T) {
+	t.Parallel()
+
+	dir, hs := tmpHDStore(t)
+
+	w, err := hs.ImportMnemonic(testMnemonic, pass)
+	require.NoError(t, err)
+	require.Equal(t, []accounts.Account{{Address: testAccount0}}, w.Accounts())
+
+	_, err = w.Derive(DefaultBaseDerivationPath.child(1), true)
+	require.ErrorIs(t, err, ErrLocked)
+
+	require.ErrorIs(t, w.Open("wrong"), accounts.ErrDecrypt)
+	require.NoError(t, w.Open(pass))
+
+	status, err := w.Status()
+	require.NoError(t, err)
+	require.Equal(t, "Unlocked", status)
+
+	// not pinned accounts are not tracked
+	account, err := w.Derive(DefaultBaseDerivationPath.child(1), false)
+	require.NoError(t, err)
+	require.Equal(t, testAccount1, account.Address)
+	require.False(t, w.Contains(account))
+
+	account, err = w.Derive(DefaultBaseDerivationPath.child(1), true)
+	require.NoError(t, err)
+	require.True(t, w.Contains(account))
+
+	path, ok := w.Path(account)
+	require.True(t, ok)
+	require.Equal(t, "m/44'/60'/0'/0/1", path.String())
+
+	// the pinned accounts are persisted
+	reloaded, err := NewHDStore(dir, veryLightScryptN, veryLightScryptP, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	reloadedWallet, err := reloaded.Wallet(testAccount1)
+	require.NoError(t, err)
+	require.Equal(t, w.Accounts(), reloadedWallet.Accounts())
+
+	require.NoError(t, w.Close())
+
+	status, err = w.Status()
+	require.NoError(t, err)
+	require.Equal(t, "Locked", status)
+}
+
+func TestWallet_SelfDerive(t *testing.T) {
+	t.Parallel()
+
+	_, hs := tmpHDStore(t)
+
+	w, err := hs.ImportMnemonic(testMnemonic, pass)
+	require.NoError(t, err)
+
+	chain := mockChainState{testAccount0: 3, testAccount1: 1}
+
+	_, err = w.SelfDerive([]DerivationPath{DefaultBaseDerivationPath}, chain)
+	require.ErrorIs(t, err, ErrLocked)
+
+	require.NoError(t, w.Open(pass))
+
+	// the used accounts and the first unused one are tracked
+	discovered, err := w.SelfDerive([]DerivationPath{DefaultBaseDerivationPath}, chain)
+	require.NoError(t, err)
+	require.Equal(t, []accounts.Account{{Address: testAccount1}, {Address: testAccount2}}, discovered)
+	require.Len(t, w.Accounts(), 3)
+
+	discovered, err = w.SelfDerive([]DerivationPath{DefaultBaseDerivationPath}, chain)
+	require.NoError(t, err)
+	require.Empty(t, discovered)
+}
+
+func TestWallet_Sign(t *testing.T) {
+	t.Parallel()
+
+	_, hs := tmpHDStore(t)
+
+	w, err := hs.ImportMnemonic(testMnemonic, pass)
+	require.NoError(t, err)
+
+	account := accounts.Account{Address: testAccount0}
+	hash := crypto.Keccak256([]byte("data"))
+
+	_, err = w.SignData(account, "", []byte("data"))
+	require.ErrorIs(t, err, ErrLocked)
+
+	signature, err := w.SignDataWithPassphrase(account, pass, "", []byte("data"))
+	require.NoError(t, err)
+
+	pub, err := crypto.RecoverPubKey(signature, hash)
+	require.NoError(t, err)
+	require.Equal(t, testAccount0, crypto.PubKeyToAddress(pub))
+
+	_, err = w.SignDataWithPassphrase(account, "wrong", "", []byte("data"))
+	require.ErrorIs(t, err, accounts.ErrDecrypt)
+
+	_, err = w.SignDataWithPassphrase(accounts.Account{Address: testAccount1}, pass, "", []byte("data"))
+	require.ErrorIs(t, err, accounts.ErrUnknownAccount)
+
+	// the timed open closes the wallet after the timeout
+	require.NoError(t, w.TimedOpen(pass, 100*time.Millisecond))
+
+	openSignature, err := w.SignData(account, "", []byte("data"))
+	require.NoError(t, err)
+	require.Equal(t, signature, openSignature)
+
+	require.Eventually(t, func() bool {
+		_, err := w.SignData(account, "", []byte("data"))
+
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// reopening without the timeout cancels the previous timeout
+	require.NoError(t, w.TimedOpen(pass, 50*time.Millisecond))
+	require.NoError(t, w.Open(pass))
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = w.SignText(account, []byte("text"))
+	require.NoError(t, err)
+}
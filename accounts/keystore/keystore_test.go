@@ -1,6 +1,8 @@
 package keystore
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"math/rand"
 	"testing"
@@ -96,6 +98,64 @@ func TestSignWithPassphrase(t *testing.T) {
 	}
 }
 
+func TestSignTypedData(t *testing.T) {
+	t.Parallel()
+
+	_, ks := tmpKeyStore(t)
+
+	// the signer of the EIP-712 specification example
+	key, err := crypto.HexToECDSA(hex.EncodeToString(crypto.Keccak256([]byte("cow"))))
+	require.NoError(t, err)
+
+	acc, err := ks.ImportECDSA(key, pass)
+	require.NoError(t, err)
+	require.Equal(t, types.StringToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"), acc.Address)
+
+	typedData := new(accounts.TypedData)
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"types": {
+			"EIP712Domain": [
+				{"name": "name", "type": "string"},
+				{"name": "version", "type": "string"},
+				{"name": "chainId", "type": "uint256"},
+				{"name": "verifyingContract", "type": "address"}
+			],
+			"Person": [{"name": "name", "type": "string"}, {"name": "wallet", "type": "address"}],
+			"Mail": [{"name": "from", "type": "Person"}, {"name": "to", "type": "Person"}, {"name": "contents", "type": "string"}]
+		},
+		"primaryType": "Mail",
+		"domain": {"name": "Ether Mail", "version": "1", "chainId": 1, "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"},
+		"message": {
+			"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+			"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+			"contents": "Hello, Bob!"
+		}
+	}`), typedData))
+
+	wallet := &keyStoreWallet{account: acc, keyStore: ks}
+
+	_, err = wallet.SignTypedData(acc, typedData)
+	require.Error(t, err)
+
+	signature, err := wallet.SignTypedDataWithPassphrase(acc, pass, typedData)
+	require.NoError(t, err)
+	require.Equal(t,
+		"4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"+
+			"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"+
+			"01",
+		hex.EncodeToString(signature),
+	)
+
+	require.NoError(t, ks.Unlock(acc, pass))
+
+	unlockedSignature, err := wallet.SignTypedData(acc, typedData)
+	require.NoError(t, err)
+	require.Equal(t, signature, unlockedSignature)
+
+	_, err = wallet.SignTypedData(accounts.Account{Address: types.StringToAddress("0x1")}, typedData)
+	require.ErrorIs(t, err, accounts.ErrUnknownAccount)
+}
+
 func TestTimedUnlock(t *testing.T) {
 	t.Parallel()
 	_, ks := tmpKeyStore(t)
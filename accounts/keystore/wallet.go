@@ -70,6 +70,29 @@ func (ksw *keyStoreWallet) SignTextWithPassphrase(account accounts.Account,
 	return ksw.keyStore.SignHashWithPassphrase(account, passphrase, accounts.TextHash(text))
 }
 
+func (ksw *keyStoreWallet) SignTypedData(account accounts.Account, typedData *accounts.TypedData) ([]byte, error) {
+	hash, err := accounts.TypedDataHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	return ksw.signHash(account, hash)
+}
+
+func (ksw *keyStoreWallet) SignTypedDataWithPassphrase(account accounts.Account,
+	passphrase string, typedData *accounts.TypedData) ([]byte, error) {
+	if !ksw.Contains(account) {
+		return nil, accounts.ErrUnknownAccount
+	}
+
+	hash, err := accounts.TypedDataHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	return ksw.keyStore.SignHashWithPassphrase(account, passphrase, hash)
+}
+
 func (ksw *keyStoreWallet) SignTx(account accounts.Account,
 	tx *types.Transaction) (*types.Transaction, error) {
 	if !ksw.Contains(account) {
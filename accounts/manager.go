@@ -87,10 +87,8 @@ func NewManager(blockchain *blockchain.Blockchain, walletManagers ...WalletManag
 
 // Close stop updater in manager
 func (am *Manager) Close() error {
-	am.lock.RLock()
-	defer am.lock.RUnlock()
-
-	for _, w := range am.wallets {
+	// the lock is released before stopping the updater, which might be waiting for it
+	for _, w := range am.Wallets() {
 		w.Close()
 	}
 
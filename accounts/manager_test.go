@@ -90,6 +90,20 @@ func (m *keystoreWalletMock) SignTextWithPassphrase(account Account, passphrase
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (m *keystoreWalletMock) SignTypedData(account Account, typedData *TypedData) ([]byte, error) {
+	args := m.Called(account, typedData)
+
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *keystoreWalletMock) SignTypedDataWithPassphrase(account Account,
+	passphrase string,
+	typedData *TypedData) ([]byte, error) {
+	args := m.Called(account, passphrase, typedData)
+
+	return args.Get(0).([]byte), args.Error(1)
+}
+
 func (m *keystoreWalletMock) SignTx(account Account, tx *types.Transaction) (*types.Transaction, error) {
 	args := m.Called(account, tx)
 
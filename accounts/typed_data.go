@@ -0,0 +1,484 @@
+package accounts
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// EIP712DomainType is the name of the type describing the domain of the typed data
+const EIP712DomainType = "EIP712Domain"
+
+var (
+	errInvalidTypedData = errors.New("invalid typed data")
+
+	// domainFields are the fields of the EIP712Domain type, in the order defined by EIP-712
+	domainFields = []TypedDataField{
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "address"},
+		{Name: "salt", Type: "bytes32"},
+	}
+)
+
+// TypedDataField is a single member of the struct type
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedData is the structured data signed according to EIP-712
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      map[string]interface{}      `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+// UnmarshalJSON decodes the typed data either from the object or from the string holding the object,
+// keeping the numbers as json.Number so that the large integers do not lose precision
+func (t *TypedData) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	if len(data) > 0 && data[0] == '"' {
+		var raw string
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+
+		data = []byte(raw)
+	}
+
+	type typedData TypedData
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var td typedData
+	if err := decoder.Decode(&td); err != nil {
+		return err
+	}
+
+	*t = TypedData(td)
+
+	return nil
+}
+
+// TypedDataHash returns the hash of the typed data to be signed:
+// keccak256("\x19\x01" || domainSeparator || hashStruct(message))
+func TypedDataHash(typedData *TypedData) ([]byte, error) {
+	domainSeparator, err := typedData.DomainSeparator()
+	if err != nil {
+		return nil, err
+	}
+
+	messageHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, messageHash), nil
+}
+
+// DomainSeparator returns the hash of the domain. If the EIP712Domain type is not given,
+// it is composed of the standard fields present in the domain
+func (t *TypedData) DomainSeparator() ([]byte, error) {
+	if _, ok := t.Types[EIP712DomainType]; ok {
+		return t.HashStruct(EIP712DomainType, t.Domain)
+	}
+
+	fields := make([]TypedDataField, 0, len(domainFields))
+
+	for _, field := range domainFields {
+		if _, ok := t.Domain[field.Name]; ok {
+			fields = append(fields, field)
+		}
+	}
+
+	withDomain := &TypedData{
+		Types:  make(map[string][]TypedDataField, len(t.Types)+1),
+		Domain: t.Domain,
+	}
+
+	for name, typeFields := range t.Types {
+		withDomain.Types[name] = typeFields
+	}
+
+	withDomain.Types[EIP712DomainType] = fields
+
+	return withDomain.HashStruct(EIP712DomainType, t.Domain)
+}
+
+// HashStruct returns keccak256(typeHash || encodeData(data)) of the given struct type
+func (t *TypedData) HashStruct(primaryType string, data map[string]interface{}) ([]byte, error) {
+	typeHash, err := t.TypeHash(primaryType)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := t.EncodeData(primaryType, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256(typeHash, encoded), nil
+}
+
+// TypeHash returns the hash of the encoded type
+func (t *TypedData) TypeHash(primaryType string) ([]byte, error) {
+	encodedType, err := t.EncodeType(primaryType)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256([]byte(encodedType)), nil
+}
+
+// EncodeType encodes the struct type followed by the struct types it references, sorted by name,
+// e.g. Mail(Person from,Person to,string contents)Person(string name,address wallet)
+func (t *TypedData) EncodeType(primaryType string) (string, error) {
+	if _, ok := t.Types[primaryType]; !ok {
+		return "", fmt.Errorf("%w: unknown type %s", errInvalidTypedData, primaryType)
+	}
+
+	deps := make(map[string]struct{})
+	t.collectDependencies(primaryType, deps)
+	delete(deps, primaryType)
+
+	sorted := make([]string, 0, len(deps))
+	for dep := range deps {
+		sorted = append(sorted, dep)
+	}
+
+	sort.Strings(sorted)
+
+	var sb strings.Builder
+
+	for _, typeName := range append([]string{primaryType}, sorted...) {
+		sb.WriteString(typeName)
+		sb.WriteString("(")
+
+		for i, field := range t.Types[typeName] {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+
+			sb.WriteString(field.Type)
+			sb.WriteString(" ")
+			sb.WriteString(field.Name)
+		}
+
+		sb.WriteString(")")
+	}
+
+	return sb.String(), nil
+}
+
+func (t *TypedData) collectDependencies(typeName string, deps map[string]struct{}) {
+	if _, ok := deps[typeName]; ok {
+		return
+	}
+
+	if _, ok := t.Types[typeName]; !ok {
+		return
+	}
+
+	deps[typeName] = struct{}{}
+
+	for _, field := range t.Types[typeName] {
+		t.collectDependencies(baseType(field.Type), deps)
+	}
+}
+
+// EncodeData encodes the members of the struct in the order of the type definition,
+// each of them as a 32 byte word
+func (t *TypedData) EncodeData(primaryType string, data map[string]interface{}) ([]byte, error) {
+	fields, ok := t.Types[primaryType]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown type %s", errInvalidTypedData, primaryType)
+	}
+
+	encoded := make([]byte, 0, types.HashLength*len(fields))
+
+	for _, field := range fields {
+		value, ok := data[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: missing field %s of %s", errInvalidTypedData, field.Name, primaryType)
+		}
+
+		word, err := t.encodeValue(field.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: field %s of %s: %w", errInvalidTypedData, field.Name, primaryType, err)
+		}
+
+		encoded = append(encoded, word...)
+	}
+
+	return encoded, nil
+}
+
+func (t *TypedData) encodeValue(typeName string, value interface{}) ([]byte, error) {
+	if strings.HasSuffix(typeName, "]") {
+		return t.encodeArray(typeName, value)
+	}
+
+	if _, ok := t.Types[typeName]; ok {
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected object for %s, got %T", typeName, value)
+		}
+
+		return t.HashStruct(typeName, data)
+	}
+
+	switch {
+	case typeName == "string":
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", value)
+		}
+
+		return crypto.Keccak256([]byte(str)), nil
+
+	case typeName == "bytes":
+		raw, err := parseTypedBytes(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return crypto.Keccak256(raw), nil
+
+	case typeName == "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected bool, got %T", value)
+		}
+
+		word := make([]byte, types.HashLength)
+		if b {
+			word[types.HashLength-1] = 1
+		}
+
+		return word, nil
+
+	case typeName == "address":
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected address, got %T", value)
+		}
+
+		raw, err := hex.DecodeHex(str)
+		if err != nil || len(raw) != types.AddressLength {
+			return nil, fmt.Errorf("invalid address %s", str)
+		}
+
+		return leftPadWord(raw), nil
+
+	case strings.HasPrefix(typeName, "bytes"):
+		size, err := strconv.Atoi(strings.TrimPrefix(typeName, "bytes"))
+		if err != nil || size < 1 || size > types.HashLength {
+			return nil, fmt.Errorf("unknown type %s", typeName)
+		}
+
+		raw, err := parseTypedBytes(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(raw) > size {
+			return nil, fmt.Errorf("value of %d bytes exceeds %s", len(raw), typeName)
+		}
+
+		word := make([]byte, types.HashLength)
+		copy(word, raw)
+
+		return word, nil
+
+	case strings.HasPrefix(typeName, "uint"), strings.HasPrefix(typeName, "int"):
+		return encodeTypedInteger(typeName, value)
+	}
+
+	return nil, fmt.Errorf("unknown type %s", typeName)
+}
+
+// encodeArray encodes both the dynamic (T[]) and the fixed size (T[N]) arrays
+// as the hash of the concatenated encodings of the elements
+func (t *TypedData) encodeArray(typeName string, value interface{}) ([]byte, error) {
+	open := strings.LastIndex(typeName, "[")
+	if open < 1 {
+		return nil, fmt.Errorf("unknown type %s", typeName)
+	}
+
+	elemType, rawSize := typeName[:open], typeName[open+1:len(typeName)-1]
+
+	elems, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected array for %s, got %T", typeName, value)
+	}
+
+	if rawSize != "" {
+		size, err := strconv.Atoi(rawSize)
+		if err != nil {
+			return nil, fmt.Errorf("unknown type %s", typeName)
+		}
+
+		if len(elems) != size {
+			return nil, fmt.Errorf("expected %d elements for %s, got %d", size, typeName, len(elems))
+		}
+	}
+
+	encoded := make([]byte, 0, types.HashLength*len(elems))
+
+	for _, elem := range elems {
+		word, err := t.encodeValue(elemType, elem)
+		if err != nil {
+			return nil, err
+		}
+
+		encoded = append(encoded, word...)
+	}
+
+	return crypto.Keccak256(encoded), nil
+}
+
+// encodeTypedInteger encodes the integer given as the number or as the decimal or hex string,
+// the negative values of the signed types are encoded in two's complement
+func encodeTypedInteger(typeName string, value interface{}) ([]byte, error) {
+	signed := strings.HasPrefix(typeName, "int")
+
+	bits := 256
+
+	if rawBits := strings.TrimPrefix(strings.TrimPrefix(typeName, "u"), "int"); rawBits != "" {
+		var err error
+
+		bits, err = strconv.Atoi(rawBits)
+		if err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+			return nil, fmt.Errorf("unknown type %s", typeName)
+		}
+	}
+
+	n, err := parseTypedInteger(value, bits)
+	if err != nil {
+		return nil, err
+	}
+
+	lower, upper := new(big.Int), new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	if signed {
+		upper.Rsh(upper, 1)
+		lower.Neg(upper)
+	}
+
+	if n.Cmp(lower) < 0 || n.Cmp(upper) >= 0 {
+		return nil, fmt.Errorf("value %s out of range of %s", n, typeName)
+	}
+
+	if n.Sign() < 0 {
+		// two's complement within the 256 bit word
+		n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+
+	return leftPadWord(n.Bytes()), nil
+}
+
+// parseTypedInteger parses the integer of the given bit size, the range is checked by the caller
+func parseTypedInteger(value interface{}, bits int) (*big.Int, error) {
+	var str string
+
+	switch v := value.(type) {
+	case json.Number:
+		str = v.String()
+	case string:
+		str = v
+	case float64:
+		if v != float64(int64(v)) {
+			return nil, fmt.Errorf("invalid integer %v", v)
+		}
+
+		return big.NewInt(int64(v)), nil
+	case *big.Int:
+		return new(big.Int).Set(v), nil
+	default:
+		return nil, fmt.Errorf("expected integer, got %T", value)
+	}
+
+	negative := strings.HasPrefix(str, "-")
+	digits := strings.TrimPrefix(str, "-")
+
+	var (
+		n  *big.Int
+		ok bool
+	)
+
+	if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+		n, ok = new(big.Int).SetString(digits[2:], 16)
+	} else {
+		n, ok = new(big.Int).SetString(digits, 10)
+	}
+
+	if !ok {
+		// numbers in the exponent notation, e.g. 1e18. The exponent is bounded by the decimal
+		// digits of the bit size before the conversion, a huge exponent would allocate the integer
+		// of an arbitrary size before the range is checked
+		if exp := strings.IndexAny(digits, "eE"); exp >= 0 {
+			e, err := strconv.Atoi(digits[exp+1:])
+			if err != nil || e > bits*3/10+1 {
+				return nil, fmt.Errorf("invalid integer %s", str)
+			}
+		}
+
+		f, _, err := big.ParseFloat(digits, 10, 512, big.ToNearestEven)
+		if err != nil || !f.IsInt() {
+			return nil, fmt.Errorf("invalid integer %s", str)
+		}
+
+		n, _ = f.Int(nil)
+	}
+
+	if negative {
+		n.Neg(n)
+	}
+
+	return n, nil
+}
+
+func parseTypedBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case string:
+		raw, err := hex.DecodeHex(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bytes %s: %w", v, err)
+		}
+
+		return raw, nil
+	case []byte:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("expected bytes, got %T", value)
+	}
+}
+
+func leftPadWord(raw []byte) []byte {
+	word := make([]byte, types.HashLength)
+	copy(word[types.HashLength-len(raw):], raw)
+
+	return word
+}
+
+// baseType strips the array dimensions from the type, e.g. Person[][2] -> Person
+func baseType(typeName string) string {
+	if open := strings.Index(typeName, "["); open >= 0 {
+		return typeName[:open]
+	}
+
+	return typeName
+}
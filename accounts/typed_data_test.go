@@ -0,0 +1,193 @@
+package accounts
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+)
+
+// mailTypedData is the example from the EIP-712 specification
+const mailTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func decodeTypedData(t *testing.T, raw string) *TypedData {
+	t.Helper()
+
+	typedData := new(TypedData)
+	require.NoError(t, json.Unmarshal([]byte(raw), typedData))
+
+	return typedData
+}
+
+func TestTypedData_Mail(t *testing.T) {
+	t.Parallel()
+
+	typedData := decodeTypedData(t, mailTypedData)
+
+	encodedType, err := typedData.EncodeType("Mail")
+	require.NoError(t, err)
+	require.Equal(t, "Mail(Person from,Person to,string contents)Person(string name,address wallet)", encodedType)
+
+	typeHash, err := typedData.TypeHash("Mail")
+	require.NoError(t, err)
+	require.Equal(t, "0xa0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2", hex.EncodeToHex(typeHash))
+
+	domainSeparator, err := typedData.DomainSeparator()
+	require.NoError(t, err)
+	require.Equal(t, "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", hex.EncodeToHex(domainSeparator))
+
+	messageHash, err := typedData.HashStruct("Mail", typedData.Message)
+	require.NoError(t, err)
+	require.Equal(t, "0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e", hex.EncodeToHex(messageHash))
+
+	hash, err := TypedDataHash(typedData)
+	require.NoError(t, err)
+	require.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex.EncodeToHex(hash))
+
+	// the domain type is derived from the domain fields if not given
+	delete(typedData.Types, EIP712DomainType)
+
+	derived, err := typedData.DomainSeparator()
+	require.NoError(t, err)
+	require.Equal(t, domainSeparator, derived)
+
+	// the typed data is also accepted as the JSON string
+	encoded, err := json.Marshal(mailTypedData)
+	require.NoError(t, err)
+
+	hash, err = TypedDataHash(decodeTypedData(t, string(encoded)))
+	require.NoError(t, err)
+	require.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex.EncodeToHex(hash))
+}
+
+func TestTypedData_ArraysAndAtomicTypes(t *testing.T) {
+	t.Parallel()
+
+	typedData := decodeTypedData(t, `{
+		"types": {
+			"Person": [
+				{"name": "name", "type": "string"},
+				{"name": "wallets", "type": "address[]"}
+			],
+			"Mail": [
+				{"name": "from", "type": "Person"},
+				{"name": "to", "type": "Person[]"},
+				{"name": "contents", "type": "string"},
+				{"name": "amounts", "type": "uint256[2]"},
+				{"name": "flag", "type": "bool"},
+				{"name": "tag", "type": "bytes4"},
+				{"name": "delta", "type": "int8"}
+			]
+		},
+		"primaryType": "Mail",
+		"domain": {
+			"name": "Ether Mail",
+			"version": "1",
+			"chainId": "0x1",
+			"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+		},
+		"message": {
+			"from": {
+				"name": "Cow",
+				"wallets": ["0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", "0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF"]
+			},
+			"to": [{"name": "Bob", "wallets": ["0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"]}],
+			"contents": "Hello, Bob!",
+			"amounts": [1, "1e18"],
+			"flag": true,
+			"tag": "0xdeadbeef",
+			"delta": -5
+		}
+	}`)
+
+	encodedType, err := typedData.EncodeType("Mail")
+	require.NoError(t, err)
+	require.Equal(t,
+		"Mail(Person from,Person[] to,string contents,uint256[2] amounts,bool flag,bytes4 tag,int8 delta)"+
+			"Person(string name,address[] wallets)",
+		encodedType,
+	)
+
+	hash, err := TypedDataHash(typedData)
+	require.NoError(t, err)
+	require.Equal(t, "0x2d5a073460d34415326eaf019b0cf290d0e0447598aeb6a538ed707d317dc08a", hex.EncodeToHex(hash))
+}
+
+func TestTypedData_InvalidValues(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		fieldType string
+		value     string
+	}{
+		{"unsigned out of range", "uint8", `256`},
+		{"signed out of range", "int8", `-129`},
+		{"fraction", "uint256", `1.5`},
+		{"exponent out of range", "uint256", `1e78`},
+		{"huge exponent", "uint256", `1e600000000`},
+		{"huge exponent string", "int64", `"1e600000000"`},
+		{"short address", "address", `"0x1234"`},
+		{"bytes too long", "bytes2", `"0x123456"`},
+		{"fixed array size", "uint8[2]", `[1]`},
+		{"bool as string", "bool", `"true"`},
+		{"unknown type", "Unknown", `"0x"`},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			typedData := decodeTypedData(t, `{
+				"types": {"Data": [{"name": "value", "type": "`+c.fieldType+`"}]},
+				"primaryType": "Data",
+				"domain": {"name": "test"},
+				"message": {"value": `+c.value+`}
+			}`)
+
+			_, err := TypedDataHash(typedData)
+			require.ErrorIs(t, err, errInvalidTypedData)
+		})
+	}
+
+	// the missing fields are not encoded as zero values
+	typedData := decodeTypedData(t, mailTypedData)
+	delete(typedData.Message, "contents")
+
+	_, err := TypedDataHash(typedData)
+	require.ErrorIs(t, err, errInvalidTypedData)
+}
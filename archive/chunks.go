@@ -0,0 +1,447 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+)
+
+const (
+	// ManifestFile is the name of the file describing the chunks of the backup directory
+	ManifestFile = "manifest.json"
+
+	// DefaultChunkSize is the default number of blocks in a single chunk
+	DefaultChunkSize = 10000
+
+	// manifestVersion is the version of the chunked backup format
+	manifestVersion = 1
+
+	headersKind  = "headers"
+	bodiesKind   = "bodies"
+	receiptsKind = "receipts"
+)
+
+var (
+	errManifestMismatch = errors.New("the existing backup was created with the different parameters")
+	errInvalidChunk     = errors.New("invalid chunk")
+	errChecksumMismatch = errors.New("checksum mismatch")
+)
+
+// Manifest describes the chunks of the backup directory. The chunks are contiguous,
+// each of them holding the headers, the bodies and the receipts of its blocks in separate RLP files
+type Manifest struct {
+	Version   int        `json:"version"`
+	Genesis   types.Hash `json:"genesis"`
+	From      uint64     `json:"from"`
+	ChunkSize uint64     `json:"chunkSize"`
+	Chunks    []*Chunk   `json:"chunks"`
+}
+
+// Chunk is the range of the blocks stored in the backup
+type Chunk struct {
+	From     uint64     `json:"from"`
+	To       uint64     `json:"to"`
+	Hash     types.Hash `json:"hash"` // hash of the last block of the chunk
+	Headers  ChunkFile  `json:"headers"`
+	Bodies   ChunkFile  `json:"bodies"`
+	Receipts ChunkFile  `json:"receipts"`
+}
+
+// ChunkFile is the file of the chunk with its size and SHA-256 checksum.
+// The checksums only prove the integrity of the files: the manifest is not authenticated,
+// so the forged backup with its own consistent checksums passes the verification
+type ChunkFile struct {
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"sha256"`
+}
+
+// Latest returns the number and the hash of the last block in the backup
+func (m *Manifest) Latest() (uint64, types.Hash, bool) {
+	if len(m.Chunks) == 0 {
+		return 0, types.ZeroHash, false
+	}
+
+	last := m.Chunks[len(m.Chunks)-1]
+
+	return last.To, last.Hash, true
+}
+
+// Metadata returns the metadata of the backup, the same as written in the beginning of the archive file
+func (m *Manifest) Metadata() *Metadata {
+	latest, latestHash, ok := m.Latest()
+	if !ok {
+		return nil
+	}
+
+	return &Metadata{Latest: latest, LatestHash: latestHash}
+}
+
+func (c *Chunk) files() []ChunkFile {
+	return []ChunkFile{c.Headers, c.Bodies, c.Receipts}
+}
+
+func chunkFileName(kind string, from, to uint64) string {
+	return fmt.Sprintf("%s-%010d-%010d.rlp", kind, from, to)
+}
+
+// LoadManifest reads the manifest of the backup directory
+func LoadManifest(dir string) (*Manifest, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode the manifest: %w", err)
+	}
+
+	if manifest.Version != manifestVersion {
+		return nil, fmt.Errorf("backup version not supported: %d", manifest.Version)
+	}
+
+	return &manifest, nil
+}
+
+func saveManifest(dir string, manifest *Manifest) error {
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// the manifest is replaced at once, so the interrupted export leaves the previous one in place
+	tmpPath := filepath.Join(dir, ManifestFile+".tmp")
+	if err := common.SaveFileSafe(tmpPath, raw, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, filepath.Join(dir, ManifestFile))
+}
+
+// VerifyChunks verifies the backup directory: the checksums of the files, the sequence of the blocks,
+// and the transactions, uncles and receipts of each block against its header.
+// It checks the integrity of the backup, not its origin, the seals of the blocks are not verified
+func VerifyChunks(dir string) (*Manifest, error) {
+	manifest, err := LoadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var parent *types.Header
+
+	next := manifest.From
+
+	for _, chunk := range manifest.Chunks {
+		if chunk.From != next || chunk.To < chunk.From {
+			return nil, fmt.Errorf("%w: range %d-%d, expected to start at %d", errInvalidChunk, chunk.From, chunk.To, next)
+		}
+
+		if err := verifyChunkFiles(dir, chunk); err != nil {
+			return nil, err
+		}
+
+		if parent, err = verifyChunkBlocks(dir, chunk, parent); err != nil {
+			return nil, err
+		}
+
+		next = chunk.To + 1
+	}
+
+	return manifest, nil
+}
+
+// verifyChunkFiles checks the sizes and the checksums of the chunk files
+func verifyChunkFiles(dir string, chunk *Chunk) error {
+	for _, file := range chunk.files() {
+		size, checksum, err := checksumFile(filepath.Join(dir, file.Name))
+		if err != nil {
+			return err
+		}
+
+		if size != file.Size || checksum != file.Checksum {
+			return fmt.Errorf("%w: %s", errChecksumMismatch, file.Name)
+		}
+	}
+
+	return nil
+}
+
+// verifyChunkBlocks checks the blocks of the chunk and returns the header of the last one
+func verifyChunkBlocks(dir string, chunk *Chunk, parent *types.Header) (*types.Header, error) {
+	reader, err := openChunk(dir, chunk)
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	for number := chunk.From; number <= chunk.To; number++ {
+		header, body, receipts, err := reader.next()
+		if err != nil {
+			return nil, err
+		}
+
+		if header == nil {
+			return nil, fmt.Errorf("%w: block #%d is missing", errInvalidChunk, number)
+		}
+
+		if err := verifyBlock(header, body, receipts, parent, number); err != nil {
+			return nil, fmt.Errorf("%w: block #%d: %w", errInvalidChunk, number, err)
+		}
+
+		parent = header
+	}
+
+	if header, _, _, err := reader.next(); err != nil || header != nil {
+		return nil, fmt.Errorf("%w: unexpected data after block #%d", errInvalidChunk, chunk.To)
+	}
+
+	if parent.Hash != chunk.Hash {
+		return nil, fmt.Errorf("%w: hash of block #%d is %s, expected %s", errInvalidChunk, chunk.To, parent.Hash, chunk.Hash)
+	}
+
+	return parent, nil
+}
+
+func verifyBlock(
+	header *types.Header,
+	body *types.Body,
+	receipts types.Receipts,
+	parent *types.Header,
+	number uint64,
+) error {
+	if header.Number != number {
+		return fmt.Errorf("unexpected number %d", header.Number)
+	}
+
+	if parent != nil && header.ParentHash != parent.Hash {
+		return fmt.Errorf("parent hash %s doesn't match the previous block %s", header.ParentHash, parent.Hash)
+	}
+
+	// the genesis block has neither the body nor the receipts stored
+	if number == 0 {
+		return nil
+	}
+
+	if root := buildroot.CalculateTransactionsRoot(body.Transactions, number); root != header.TxRoot {
+		return fmt.Errorf("transactions root %s doesn't match the header %s", root, header.TxRoot)
+	}
+
+	if root := buildroot.CalculateUncleRoot(body.Uncles); root != header.Sha3Uncles {
+		return fmt.Errorf("uncles root %s doesn't match the header %s", root, header.Sha3Uncles)
+	}
+
+	if len(receipts) != len(body.Transactions) {
+		return fmt.Errorf("%d receipts for %d transactions", len(receipts), len(body.Transactions))
+	}
+
+	if root := buildroot.CalculateReceiptsRoot(receipts); root != header.ReceiptsRoot {
+		return fmt.Errorf("receipts root %s doesn't match the header %s", root, header.ReceiptsRoot)
+	}
+
+	return nil
+}
+
+func checksumFile(path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+
+	defer f.Close()
+
+	h := sha256.New()
+
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return size, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// storeReceipts decodes the receipts in the storage format, which keeps the fields derived from the block
+type storeReceipts types.Receipts
+
+func (r *storeReceipts) UnmarshalRLP(input []byte) error {
+	return (*types.Receipts)(r).UnmarshalStoreRLP(input)
+}
+
+// chunkReader reads the blocks of a single chunk from its files
+type chunkReader struct {
+	files    []*os.File
+	headers  *blockStream
+	bodies   *blockStream
+	receipts *blockStream
+}
+
+func openChunk(dir string, chunk *Chunk) (*chunkReader, error) {
+	reader := &chunkReader{}
+
+	streams := make([]*blockStream, 0, 3)
+
+	for _, file := range chunk.files() {
+		f, err := os.Open(filepath.Join(dir, file.Name))
+		if err != nil {
+			reader.Close()
+
+			return nil, err
+		}
+
+		reader.files = append(reader.files, f)
+		streams = append(streams, newBlockStream(f))
+	}
+
+	reader.headers, reader.bodies, reader.receipts = streams[0], streams[1], streams[2]
+
+	return reader, nil
+}
+
+// next returns the next block of the chunk, the header is nil at the end of the chunk
+func (r *chunkReader) next() (*types.Header, *types.Body, types.Receipts, error) {
+	header := &types.Header{}
+
+	ok, err := r.headers.next(header)
+	if err != nil || !ok {
+		return nil, nil, nil, err
+	}
+
+	body := &types.Body{}
+	if ok, err = r.bodies.next(body); err != nil {
+		return nil, nil, nil, err
+	} else if !ok {
+		return nil, nil, nil, fmt.Errorf("%w: body of block #%d is missing", errInvalidChunk, header.Number)
+	}
+
+	for _, tx := range body.Transactions {
+		tx.ComputeHash()
+	}
+
+	var receipts storeReceipts
+	if ok, err = r.receipts.next(&receipts); err != nil {
+		return nil, nil, nil, err
+	} else if !ok {
+		return nil, nil, nil, fmt.Errorf("%w: receipts of block #%d are missing", errInvalidChunk, header.Number)
+	}
+
+	return header, body, types.Receipts(receipts), nil
+}
+
+func (r *chunkReader) Close() {
+	for _, f := range r.files {
+		_ = f.Close()
+	}
+}
+
+// chunkStream reads the blocks of all chunks of the backup in sequence
+type chunkStream struct {
+	dir      string
+	manifest *Manifest
+	index    int
+	current  *chunkReader
+}
+
+func newChunkStream(dir string, manifest *Manifest) *chunkStream {
+	return &chunkStream{dir: dir, manifest: manifest}
+}
+
+func (c *chunkStream) getMetadata() (*Metadata, error) {
+	return c.manifest.Metadata(), nil
+}
+
+func (c *chunkStream) nextBlock() (*types.Block, error) {
+	for {
+		if c.current == nil {
+			if c.index >= len(c.manifest.Chunks) {
+				return nil, nil
+			}
+
+			reader, err := openChunk(c.dir, c.manifest.Chunks[c.index])
+			if err != nil {
+				return nil, err
+			}
+
+			c.current = reader
+			c.index++
+		}
+
+		header, body, _, err := c.current.next()
+		if err != nil {
+			return nil, err
+		}
+
+		if header == nil {
+			c.current.Close()
+			c.current = nil
+
+			continue
+		}
+
+		return &types.Block{Header: header, Transactions: body.Transactions, Uncles: body.Uncles}, nil
+	}
+}
+
+func (c *chunkStream) Close() {
+	if c.current != nil {
+		c.current.Close()
+		c.current = nil
+	}
+}
+
+// chunkWriter writes a file of the chunk, computing its size and checksum
+type chunkWriter struct {
+	file *os.File
+	path string
+	hash hash.Hash
+	size int64
+}
+
+func newChunkWriter(dir, name string) (*chunkWriter, error) {
+	path := filepath.Join(dir, name)
+
+	// the file of the chunk being exported is overwritten, the chunk is not in the manifest yet
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chunkWriter{file: file, path: path, hash: sha256.New()}, nil
+}
+
+func (w *chunkWriter) write(data []byte) error {
+	n, err := w.file.Write(data)
+	w.size += int64(n)
+
+	if err != nil {
+		return err
+	}
+
+	_, _ = w.hash.Write(data)
+
+	return nil
+}
+
+// close syncs and closes the file, it returns the description of the written file
+func (w *chunkWriter) close() (ChunkFile, error) {
+	err := w.file.Sync()
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return ChunkFile{
+		Name:     filepath.Base(w.path),
+		Size:     w.size,
+		Checksum: hex.EncodeToString(w.hash.Sum(nil)),
+	}, err
+}
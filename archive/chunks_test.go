@@ -0,0 +1,237 @@
+package archive
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/memory"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+)
+
+// writeTestBlocks writes the canonical blocks with a transaction each on top of the parent,
+// the extra data differentiates the blocks of the forks
+func writeTestBlocks(t *testing.T, db *storagev2.Storage, parent *types.Header, count uint64, extra byte) *types.Header {
+	t.Helper()
+
+	receiver := types.StringToAddress("1")
+
+	for i := uint64(0); i < count; i++ {
+		number := parent.Number + 1
+
+		tx := types.NewTx(types.NewLegacyTx(
+			types.WithNonce(number),
+			types.WithGas(21000),
+			types.WithGasPrice(big.NewInt(1)),
+			types.WithTo(&receiver),
+			types.WithValue(big.NewInt(int64(extra))),
+			types.WithSignatureValues(big.NewInt(27), big.NewInt(1), big.NewInt(1)),
+			types.WithFrom(types.StringToAddress("2")),
+		))
+		tx.ComputeHash()
+
+		receipt := &types.Receipt{CumulativeGasUsed: 21000, GasUsed: 21000, TxHash: tx.Hash()}
+		receipt.SetStatus(types.ReceiptSuccess)
+
+		header := &types.Header{
+			Number:       number,
+			ParentHash:   parent.Hash,
+			ExtraData:    []byte{extra},
+			Sha3Uncles:   types.EmptyUncleHash,
+			TxRoot:       buildroot.CalculateTransactionsRoot([]*types.Transaction{tx}, number),
+			ReceiptsRoot: buildroot.CalculateReceiptsRoot([]*types.Receipt{receipt}),
+		}
+		header.ComputeHash()
+
+		w := db.NewWriter()
+		w.PutBody(number, header.Hash, &types.Body{Transactions: []*types.Transaction{tx}})
+		w.PutReceipts(number, header.Hash, []*types.Receipt{receipt})
+		w.PutCanonicalHeader(header, new(big.Int).SetUint64(number))
+		require.NoError(t, w.WriteBatch())
+
+		parent = header
+	}
+
+	return parent
+}
+
+func newTestStorage(t *testing.T, count uint64) (*storagev2.Storage, *types.Header) {
+	t.Helper()
+
+	db, err := memory.NewMemoryStorage()
+	require.NoError(t, err)
+
+	genesis := &types.Header{Sha3Uncles: types.EmptyUncleHash, TxRoot: types.EmptyRootHash}
+	genesis.ComputeHash()
+
+	w := db.NewWriter()
+	w.PutCanonicalHeader(genesis, big.NewInt(0))
+	require.NoError(t, w.WriteBatch())
+
+	writeTestBlocks(t, db, genesis, count, 0)
+
+	return db, genesis
+}
+
+func chunkRanges(manifest *Manifest) [][2]uint64 {
+	ranges := make([][2]uint64, len(manifest.Chunks))
+	for i, chunk := range manifest.Chunks {
+		ranges[i] = [2]uint64{chunk.From, chunk.To}
+	}
+
+	return ranges
+}
+
+func TestExportChunks(t *testing.T) {
+	t.Parallel()
+
+	db, genesis := newTestStorage(t, 10)
+	dir := filepath.Join(t.TempDir(), "backup")
+
+	manifest, err := ExportChunks(db, hclog.NewNullLogger(), 0, nil, 4, dir)
+	require.NoError(t, err)
+	require.Equal(t, [][2]uint64{{0, 3}, {4, 7}, {8, 10}}, chunkRanges(manifest))
+
+	head, _ := db.ReadCanonicalHash(10)
+	require.Equal(t, &Metadata{Latest: 10, LatestHash: head}, manifest.Metadata())
+
+	verified, err := VerifyChunks(dir)
+	require.NoError(t, err)
+	require.Equal(t, manifest, verified)
+
+	// the chunked backup is restored the same way as the archive file
+	chain := &mockChain{genesis: &types.Block{Header: genesis}}
+	require.NoError(t, RestoreChain(chain, dir, progress.NewProgressionWrapper(progress.ChainSyncRestore)))
+	require.Len(t, chain.blocks, 10)
+	require.Equal(t, head, chain.Header().Hash)
+	require.Len(t, chain.blocks[4].Transactions, 1)
+
+	// the archive file is exported from the storage as well
+	archivePath := filepath.Join(t.TempDir(), "chain.dat")
+
+	_, _, err = ExportChain(NewStorageChain(db), hclog.NewNullLogger(), 0, nil, archivePath)
+	require.NoError(t, err)
+
+	fromArchive := &mockChain{genesis: &types.Block{Header: genesis}}
+	require.NoError(t, RestoreChain(fromArchive, archivePath, progress.NewProgressionWrapper(progress.ChainSyncRestore)))
+	require.Equal(t, chain.blocks, fromArchive.blocks)
+}
+
+func TestExportChunks_Resume(t *testing.T) {
+	t.Parallel()
+
+	db, _ := newTestStorage(t, 10)
+	dir := t.TempDir()
+	to := uint64(5)
+
+	manifest, err := ExportChunks(db, hclog.NewNullLogger(), 1, &to, 4, dir)
+	require.NoError(t, err)
+	require.Equal(t, [][2]uint64{{1, 4}, {5, 5}}, chunkRanges(manifest))
+
+	firstChunk := *manifest.Chunks[0]
+
+	// the complete chunks are kept, the partial one is extended
+	manifest, err = ExportChunks(db, hclog.NewNullLogger(), 1, nil, 4, dir)
+	require.NoError(t, err)
+	require.Equal(t, [][2]uint64{{1, 4}, {5, 8}, {9, 10}}, chunkRanges(manifest))
+	require.Equal(t, firstChunk, *manifest.Chunks[0])
+
+	_, err = os.Stat(filepath.Join(dir, chunkFileName(headersKind, 5, 5)))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// the reorg of the local chain replaces the chunks starting with the fork
+	forkParentHash, _ := db.ReadCanonicalHash(6)
+	forkParent, err := db.ReadHeader(6, forkParentHash)
+	require.NoError(t, err)
+
+	newHead := writeTestBlocks(t, db, forkParent, 6, 1)
+
+	manifest, err = ExportChunks(db, hclog.NewNullLogger(), 1, nil, 4, dir)
+	require.NoError(t, err)
+	require.Equal(t, [][2]uint64{{1, 4}, {5, 8}, {9, 12}}, chunkRanges(manifest))
+	require.Equal(t, firstChunk, *manifest.Chunks[0])
+
+	latest, latestHash, _ := manifest.Latest()
+	require.Equal(t, newHead.Number, latest)
+	require.Equal(t, newHead.Hash, latestHash)
+
+	_, err = VerifyChunks(dir)
+	require.NoError(t, err)
+
+	// the corrupted chunk is exported again
+	bodiesPath := filepath.Join(dir, manifest.Chunks[1].Bodies.Name)
+	require.NoError(t, os.WriteFile(bodiesPath, []byte{0xc0}, 0644))
+
+	_, err = VerifyChunks(dir)
+	require.ErrorIs(t, err, errChecksumMismatch)
+
+	_, err = ExportChunks(db, hclog.NewNullLogger(), 1, nil, 4, dir)
+	require.NoError(t, err)
+
+	_, err = VerifyChunks(dir)
+	require.NoError(t, err)
+
+	// the existing backup is resumed only with the same parameters
+	_, err = ExportChunks(db, hclog.NewNullLogger(), 1, nil, 5, dir)
+	require.ErrorIs(t, err, errManifestMismatch)
+}
+
+func TestVerifyChunks_InvalidBlocks(t *testing.T) {
+	t.Parallel()
+
+	db, _ := newTestStorage(t, 8)
+	dir := t.TempDir()
+
+	manifest, err := ExportChunks(db, hclog.NewNullLogger(), 1, nil, 4, dir)
+	require.NoError(t, err)
+
+	// the files match their checksums, but not the headers of the blocks
+	manifest.Chunks[1].Bodies = manifest.Chunks[0].Bodies
+	require.NoError(t, saveManifest(dir, manifest))
+
+	_, err = VerifyChunks(dir)
+	require.ErrorIs(t, err, errInvalidChunk)
+
+	// the chunks must be contiguous
+	manifest.Chunks = manifest.Chunks[1:]
+	require.NoError(t, saveManifest(dir, manifest))
+
+	_, err = VerifyChunks(dir)
+	require.ErrorIs(t, err, errInvalidChunk)
+}
+
+func TestRestoreChunks_Diverged(t *testing.T) {
+	t.Parallel()
+
+	db, genesis := newTestStorage(t, 4)
+	dir := t.TempDir()
+
+	_, err := ExportChunks(db, hclog.NewNullLogger(), 0, nil, 4, dir)
+	require.NoError(t, err)
+
+	// the local chain has a different block at the height included in the backup
+	localDB, _ := newTestStorage(t, 0)
+	localHead := writeTestBlocks(t, localDB, genesis, 2, 1)
+
+	localBlocks := make([]*types.Block, 0, 2)
+
+	for n := uint64(1); n <= localHead.Number; n++ {
+		block, ok := NewStorageChain(localDB).GetBlockByNumber(n, true)
+		require.True(t, ok)
+
+		localBlocks = append(localBlocks, block)
+	}
+
+	chain := &mockChain{genesis: &types.Block{Header: genesis}, blocks: localBlocks}
+
+	err = RestoreChain(chain, dir, progress.NewProgressionWrapper(progress.ChainSyncRestore))
+	require.ErrorIs(t, err, errChainDiverged)
+	require.Len(t, chain.blocks, 2)
+}
@@ -0,0 +1,376 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+var errHeadNotFound = errors.New("head block not found")
+
+type exportBlockchain interface {
+	Header() *types.Header
+	GetBlockByNumber(uint64, bool) (*types.Block, bool)
+}
+
+// ExportChain writes the blocks of the local chain with the specific range to given path,
+// in the same format as CreateBackup. The blocks up to the latest one are written if to is not given
+func ExportChain(
+	chain exportBlockchain,
+	logger hclog.Logger,
+	from uint64,
+	to *uint64,
+	outPath string,
+) (uint64, uint64, error) {
+	head := chain.Header()
+	if head == nil {
+		return 0, 0, errHeadNotFound
+	}
+
+	latest := head.Number
+
+	target := latest
+	if to != nil && *to < latest {
+		target = *to
+	}
+
+	if from > target {
+		return 0, 0, errors.New("from must not be greater than to")
+	}
+
+	targetBlock, ok := chain.GetBlockByNumber(target, false)
+	if !ok {
+		return 0, 0, fmt.Errorf("block #%d not found", target)
+	}
+
+	// always create new file, throw error if the file exists
+	fs, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	writeBlocks := func() error {
+		if err := writeMetadata(fs, logger, target, targetBlock.Hash()); err != nil {
+			return err
+		}
+
+		for i := from; i <= target; i++ {
+			block, ok := chain.GetBlockByNumber(i, true)
+			if !ok {
+				return fmt.Errorf("block #%d not found", i)
+			}
+
+			if _, err := fs.Write(block.MarshalRLP()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	err = writeBlocks()
+	if closeErr := fs.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		if removeErr := os.Remove(outPath); removeErr != nil {
+			logger.Error("an error occurred while removing file", "err", removeErr)
+		}
+
+		return 0, 0, err
+	}
+
+	logger.Info("Exported blocks", "from", from, "to", target, "path", outPath)
+
+	return from, target, nil
+}
+
+// ChainReader reads the canonical chain directly from the blockchain storage of the stopped node
+type ChainReader interface {
+	ReadHeadNumber() (uint64, bool)
+	ReadCanonicalHash(uint64) (types.Hash, bool)
+	ReadHeader(uint64, types.Hash) (*types.Header, error)
+	ReadBody(uint64, types.Hash) (*types.Body, error)
+	ReadReceipts(uint64, types.Hash) ([]*types.Receipt, error)
+}
+
+// StorageChain exposes the blockchain storage to ExportChain, so that the archive file
+// can be written without the running node
+type StorageChain struct {
+	reader ChainReader
+}
+
+func NewStorageChain(reader ChainReader) *StorageChain {
+	return &StorageChain{reader: reader}
+}
+
+// Header returns the head header, or nil if the storage has no readable head
+func (s *StorageChain) Header() *types.Header {
+	head, ok := s.reader.ReadHeadNumber()
+	if !ok {
+		return nil
+	}
+
+	header, _, _, err := readCanonicalBlock(s.reader, head, false)
+	if err != nil {
+		return nil
+	}
+
+	return header
+}
+
+func (s *StorageChain) GetBlockByNumber(n uint64, full bool) (*types.Block, bool) {
+	header, body, _, err := readCanonicalBlock(s.reader, n, false)
+	if err != nil {
+		return nil, false
+	}
+
+	block := &types.Block{Header: header}
+	if full {
+		block.Transactions, block.Uncles = body.Transactions, body.Uncles
+	}
+
+	return block, true
+}
+
+// readCanonicalBlock reads the canonical block with the given number, the receipts are read if requested
+func readCanonicalBlock(
+	reader ChainReader,
+	n uint64,
+	withReceipts bool,
+) (*types.Header, *types.Body, []*types.Receipt, error) {
+	hash, ok := reader.ReadCanonicalHash(n)
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("block #%d not found", n)
+	}
+
+	header, err := reader.ReadHeader(n, hash)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read the header of block #%d: %w", n, err)
+	}
+
+	// the genesis block is stored without the body and the receipts
+	if n == 0 {
+		return header, &types.Body{}, []*types.Receipt{}, nil
+	}
+
+	body, err := reader.ReadBody(n, hash)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read the body of block #%d: %w", n, err)
+	}
+
+	var receipts []*types.Receipt
+
+	if withReceipts {
+		if receipts, err = reader.ReadReceipts(n, hash); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to read the receipts of block #%d: %w", n, err)
+		}
+	}
+
+	return header, body, receipts, nil
+}
+
+// ExportChunks writes the canonical blocks with the specific range to the backup directory,
+// chunkSize blocks per chunk. The export is resumed if the directory holds the backup of the previous run:
+// the chunks which are intact and still canonical are kept, the rest are exported again,
+// so the chunks written before a reorg of the local chain are replaced
+func ExportChunks(
+	reader ChainReader,
+	logger hclog.Logger,
+	from uint64,
+	to *uint64,
+	chunkSize uint64,
+	dir string,
+) (*Manifest, error) {
+	if chunkSize == 0 {
+		return nil, errors.New("chunk size must be greater than zero")
+	}
+
+	head, ok := reader.ReadHeadNumber()
+	if !ok {
+		return nil, errHeadNotFound
+	}
+
+	target := head
+	if to != nil && *to < head {
+		target = *to
+	}
+
+	if from > target {
+		return nil, errors.New("from must not be greater than to")
+	}
+
+	genesis, ok := reader.ReadCanonicalHash(0)
+	if !ok {
+		return nil, errors.New("genesis block not found")
+	}
+
+	if err := common.CreateDirSafe(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	manifest, err := LoadManifest(dir)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		manifest = &Manifest{Version: manifestVersion, Genesis: genesis, From: from, ChunkSize: chunkSize}
+	case err != nil:
+		return nil, err
+	case manifest.Genesis != genesis || manifest.From != from || manifest.ChunkSize != chunkSize:
+		return nil, errManifestMismatch
+	default:
+		keepResumableChunks(reader, logger, dir, manifest, target)
+	}
+
+	next := from
+	if latest, _, ok := manifest.Latest(); ok {
+		next = latest + 1
+	}
+
+	for next <= target {
+		chunk, err := writeChunk(reader, dir, next, min(next+chunkSize-1, target))
+		if err != nil {
+			return nil, err
+		}
+
+		manifest.Chunks = append(manifest.Chunks, chunk)
+
+		if err := saveManifest(dir, manifest); err != nil {
+			return nil, err
+		}
+
+		logger.Info("Exported chunk", "from", chunk.From, "to", chunk.To, "target", target)
+
+		next = chunk.To + 1
+	}
+
+	// the manifest is written even if nothing was exported, so that an empty range is a valid backup
+	if err := saveManifest(dir, manifest); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Exported blocks", "from", from, "to", target, "path", dir)
+
+	return manifest, nil
+}
+
+// keepResumableChunks drops the chunks which are corrupted, not canonical anymore or not complete,
+// together with all chunks following them
+func keepResumableChunks(reader ChainReader, logger hclog.Logger, dir string, manifest *Manifest, target uint64) {
+	keep := 0
+
+	for _, chunk := range manifest.Chunks {
+		if hash, ok := reader.ReadCanonicalHash(chunk.To); !ok || hash != chunk.Hash {
+			logger.Info("Chunk is not canonical anymore, exporting it again", "from", chunk.From, "to", chunk.To)
+
+			break
+		}
+
+		if err := verifyChunkFiles(dir, chunk); err != nil {
+			logger.Info("Chunk is corrupted, exporting it again", "from", chunk.From, "to", chunk.To, "err", err)
+
+			break
+		}
+
+		// the last chunk is extended if it isn't full, the chunks beyond the target are dropped
+		if chunk.To > target || (chunk.To-chunk.From+1 < manifest.ChunkSize && chunk.To < target) {
+			break
+		}
+
+		keep++
+	}
+
+	for _, chunk := range manifest.Chunks[keep:] {
+		for _, file := range chunk.files() {
+			if err := os.Remove(filepath.Join(dir, file.Name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				logger.Error("an error occurred while removing file", "err", err)
+			}
+		}
+	}
+
+	manifest.Chunks = manifest.Chunks[:keep]
+}
+
+// writeChunk writes the headers, the bodies and the receipts of the blocks to the chunk files
+func writeChunk(reader ChainReader, dir string, from, to uint64) (*Chunk, error) {
+	writers := make([]*chunkWriter, 0, 3)
+
+	closeAll := func() ([]ChunkFile, error) {
+		files := make([]ChunkFile, len(writers))
+
+		var err error
+
+		for i, w := range writers {
+			file, closeErr := w.close()
+			if err == nil {
+				err = closeErr
+			}
+
+			files[i] = file
+		}
+
+		return files, err
+	}
+
+	for _, kind := range []string{headersKind, bodiesKind, receiptsKind} {
+		w, err := newChunkWriter(dir, chunkFileName(kind, from, to))
+		if err != nil {
+			_, _ = closeAll()
+
+			return nil, err
+		}
+
+		writers = append(writers, w)
+	}
+
+	writeBlocks := func() (types.Hash, error) {
+		var hash types.Hash
+
+		for n := from; n <= to; n++ {
+			header, body, receipts, err := readCanonicalBlock(reader, n, true)
+			if err != nil {
+				return types.ZeroHash, err
+			}
+
+			for i, data := range [][]byte{
+				header.MarshalRLP(),
+				body.MarshalRLPTo(nil),
+				types.Receipts(receipts).MarshalStoreRLPTo(nil),
+			} {
+				if err := writers[i].write(data); err != nil {
+					return types.ZeroHash, err
+				}
+			}
+
+			hash = header.Hash
+		}
+
+		return hash, nil
+	}
+
+	hash, err := writeBlocks()
+
+	files, closeErr := closeAll()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Chunk{
+		From:     from,
+		To:       to,
+		Hash:     hash,
+		Headers:  files[0],
+		Bodies:   files[1],
+		Receipts: files[2],
+	}, nil
+}
@@ -0,0 +1,83 @@
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/memory"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+type mockExportChain struct {
+	*mockChain
+}
+
+func newTestExportChain(count uint64) *mockExportChain {
+	chain := &mockChain{}
+	parentHash := types.ZeroHash
+
+	for i := uint64(0); i < count; i++ {
+		header := &types.Header{Number: i, ParentHash: parentHash}
+		header.ComputeHash()
+
+		chain.blocks = append(chain.blocks, &types.Block{Header: header})
+		parentHash = header.Hash
+	}
+
+	chain.genesis = chain.blocks[0]
+
+	return &mockExportChain{chain}
+}
+
+func TestExportChain(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestExportChain(10)
+	dir := t.TempDir()
+	to := uint64(5)
+
+	outPath := filepath.Join(dir, "chain.dat")
+
+	from, exportedTo, err := ExportChain(chain, hclog.NewNullLogger(), 1, &to, outPath)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), from)
+	require.Equal(t, to, exportedTo)
+
+	// the existing file is not overwritten
+	_, _, err = ExportChain(chain, hclog.NewNullLogger(), 1, &to, outPath)
+	require.ErrorIs(t, err, os.ErrExist)
+
+	// the range is validated before the file is created
+	invalidPath := filepath.Join(dir, "invalid.dat")
+
+	_, _, err = ExportChain(chain, hclog.NewNullLogger(), 7, &to, invalidPath)
+	require.Error(t, err)
+	require.NoFileExists(t, invalidPath)
+
+	// the archive can be restored to the chain with the genesis only
+	restored := &mockChain{genesis: chain.genesis, blocks: []*types.Block{chain.genesis}}
+	require.NoError(t, RestoreChain(restored, outPath, progress.NewProgressionWrapper(progress.ChainSyncRestore)))
+	require.Len(t, restored.blocks, 6)
+	require.Equal(t, chain.blocks[5].Hash(), restored.blocks[5].Hash())
+
+	// the latest block is exported if to is not given
+	latestPath := filepath.Join(dir, "latest.dat")
+
+	_, exportedTo, err = ExportChain(chain, hclog.NewNullLogger(), 0, nil, latestPath)
+	require.NoError(t, err)
+	require.Equal(t, uint64(9), exportedTo)
+
+	// the storage without the head block is not exported
+	db, err := memory.NewMemoryStorage()
+	require.NoError(t, err)
+
+	emptyPath := filepath.Join(dir, "empty.dat")
+
+	_, _, err = ExportChain(NewStorageChain(db), hclog.NewNullLogger(), 0, nil, emptyPath)
+	require.ErrorIs(t, err, errHeadNotFound)
+	require.NoFileExists(t, emptyPath)
+}
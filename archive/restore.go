@@ -17,7 +17,10 @@ const (
 	restore = "restore"
 )
 
+var errChainDiverged = errors.New("the backup diverges from the local chain")
+
 type blockchainInterface interface {
+	Header() *types.Header
 	SubscribeEvents() blockchain.Subscription
 	UnsubscribeEvents(blockchain.Subscription)
 	Genesis() types.Hash
@@ -27,20 +30,63 @@ type blockchainInterface interface {
 	VerifyFinalizedBlock(*types.Block) (*types.FullBlock, error)
 }
 
-// RestoreChain reads blocks from the archive and write to the chain
+// blockSource is the sequence of the blocks read from the backup
+type blockSource interface {
+	getMetadata() (*Metadata, error)
+	nextBlock() (*types.Block, error)
+}
+
+// RestoreChain reads blocks from the archive and write to the chain.
+// The path is either the archive file or the directory of the chunked backup
 func RestoreChain(chain blockchainInterface, filePath string, progression *progress.ProgressionWrapper) error {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() {
+		return restoreChunks(chain, filePath, progression)
+	}
+
 	fp, err := os.Open(filePath)
 	if err != nil {
 		return err
 	}
 
+	defer fp.Close()
+
 	blockStream := newBlockStream(fp)
 
 	return importBlocks(chain, blockStream, progression)
 }
 
+// restoreChunks verifies the whole chunked backup before writing its blocks to the chain
+func restoreChunks(chain blockchainInterface, dir string, progression *progress.ProgressionWrapper) error {
+	manifest, err := VerifyChunks(dir)
+	if err != nil {
+		return fmt.Errorf("invalid backup: %w", err)
+	}
+
+	if len(manifest.Chunks) == 0 {
+		return nil
+	}
+
+	if manifest.Genesis != chain.Genesis() {
+		return fmt.Errorf(
+			"the genesis of the backup (%s) does not match blockchain genesis (%s)",
+			manifest.Genesis,
+			chain.Genesis(),
+		)
+	}
+
+	stream := newChunkStream(dir, manifest)
+	defer stream.Close()
+
+	return importBlocks(chain, stream, progression)
+}
+
 // import blocks scans all blocks from stream and write them to chain
-func importBlocks(chain blockchainInterface, blockStream *blockStream, progression *progress.ProgressionWrapper) error {
+func importBlocks(chain blockchainInterface, blockStream blockSource, progression *progress.ProgressionWrapper) error {
 	shutdownCh := common.GetTerminationSignalCh()
 
 	metadata, err := blockStream.getMetadata()
@@ -68,6 +114,17 @@ func importBlocks(chain blockchainInterface, blockStream *blockStream, progressi
 		return nil
 	}
 
+	// the blocks below the local head can't be written, the chain would be left with a gap
+	if head := chain.Header(); head != nil && firstBlock.Number() <= head.Number {
+		return fmt.Errorf(
+			"%w at block #%d (local hash %s, backup hash %s)",
+			errChainDiverged,
+			firstBlock.Number(),
+			chain.GetHashByNumber(firstBlock.Number()),
+			firstBlock.Hash(),
+		)
+	}
+
 	// Create a blockchain subscription for the sync progression and start tracking
 	subscription := chain.SubscribeEvents()
 	progression.StartProgression(firstBlock.Number(), subscription)
@@ -116,7 +173,7 @@ func importBlocks(chain blockchainInterface, blockStream *blockStream, progressi
 // returns the first block to be written into chain
 func consumeCommonBlocks(
 	chain blockchainInterface,
-	blockStream *blockStream,
+	blockStream blockSource,
 	shutdownCh <-chan os.Signal,
 ) (*types.Block, error) {
 	for {
@@ -199,6 +256,24 @@ func (b *blockStream) nextBlock() (*types.Block, error) {
 	return block, nil
 }
 
+// next consumes the next RLP encoded array from input and decodes it, returns false at the end of input
+func (b *blockStream) next(v types.RLPUnmarshaler) (bool, error) {
+	size, err := b.loadRLPArray()
+	if err != nil {
+		return false, err
+	}
+
+	if size == 0 {
+		return false, nil
+	}
+
+	if err := v.UnmarshalRLP(b.buffer[:size]); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 // loadRLPArray loads RLP encoded array from input to buffer
 func (b *blockStream) loadRLPArray() (uint64, error) {
 	prefix, err := b.loadRLPPrefix()
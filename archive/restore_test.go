@@ -26,6 +26,14 @@ type mockChain struct {
 	blocks  []*types.Block
 }
 
+func (m *mockChain) Header() *types.Header {
+	if latest := getLatestBlockFromMockChain(m); latest != nil {
+		return latest.Header
+	}
+
+	return m.genesis.Header
+}
+
 func (m *mockChain) Genesis() types.Hash {
 	return m.genesis.Hash()
 }
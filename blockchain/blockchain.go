@@ -73,6 +73,8 @@ type Blockchain struct {
 
 	gpAverage *gasPriceAverage // A reference to the average gas price
 
+	statePruner StatePruner // The online state pruning, nil if the pruning is disabled
+
 	writeLock sync.Mutex
 }
 
@@ -96,6 +98,11 @@ type Executor interface {
 	ProcessBlock(parentRoot types.Hash, block *types.Block, blockCreator types.Address) (*state.Transition, error)
 }
 
+// StatePruner retains the states of the canonical blocks and prunes the states leaving the retention window
+type StatePruner interface {
+	InsertBlockState(root types.Hash) error
+}
+
 type TxSigner interface {
 	// Sender returns the sender of the transaction
 	Sender(tx *types.Transaction) (types.Address, error)
@@ -276,6 +283,11 @@ func (b *Blockchain) ComputeGenesis() error {
 		)
 
 		b.setCurrentHeader(header, diff)
+
+		// index the blocks written before the log index existed
+		if err := b.RebuildLogIndex(); err != nil {
+			return fmt.Errorf("failed to rebuild log index: %w", err)
+		}
 	} else {
 		// empty storage, write the genesis
 		if err := b.writeGenesis(b.genesisConfig.Genesis); err != nil {
@@ -297,6 +309,11 @@ func (b *Blockchain) SetConsensus(c Verifier) {
 	b.consensus = c
 }
 
+// SetStatePruner sets the state pruning, it must be set before any block is written
+func (b *Blockchain) SetStatePruner(p StatePruner) {
+	b.statePruner = p
+}
+
 // setCurrentHeader sets the current header
 func (b *Blockchain) setCurrentHeader(h *types.Header, diff *big.Int) {
 	// Update the header (atomic)
@@ -403,6 +420,7 @@ func (b *Blockchain) writeGenesisImpl(header *types.Header) error {
 	newTD := new(big.Int).SetUint64(header.Difficulty)
 
 	batchWriter.PutCanonicalHeader(header, newTD)
+	batchWriter.PutLogIndexHead(header.Number)
 
 	if err := b.writeBatchAndUpdate(batchWriter, header, newTD, true); err != nil {
 		return err
@@ -600,6 +618,8 @@ func (b *Blockchain) WriteHeadersWithBodies(headers []*types.Header) error {
 			return err
 		}
 
+		b.writeLogIndex(batchWriter, header)
+
 		if err := b.writeBatchAndUpdate(batchWriter, header, newTD, isCanonical); err != nil {
 			return err
 		}
@@ -711,6 +731,26 @@ func (b *Blockchain) verifyBlockParent(childBlock *types.Block) error {
 // - The receipts match up
 // - The execution result matches up
 func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, error) {
+	if err := b.verifyBlockRoots(block); err != nil {
+		return nil, err
+	}
+
+	// Execute the transactions in the block and grab the result
+	blockResult, executeErr := b.executeBlockTransactions(block)
+	if executeErr != nil {
+		return nil, fmt.Errorf("unable to execute block transactions, %w", executeErr)
+	}
+
+	// Verify the local execution result with the proposed block data
+	if err := blockResult.verifyBlockResult(block); err != nil {
+		return nil, fmt.Errorf("unable to verify block execution result, %w", err)
+	}
+
+	return blockResult.Receipts, nil
+}
+
+// verifyBlockRoots makes sure that the uncles and transactions roots match up with the block body
+func (b *Blockchain) verifyBlockRoots(block *types.Block) error {
 	// Make sure the Uncles root matches up
 	if hash := buildroot.CalculateUncleRoot(block.Uncles); hash != block.Header.Sha3Uncles {
 		b.logger.Error(fmt.Sprintf(
@@ -719,7 +759,7 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, erro
 			block.Header.Sha3Uncles,
 		))
 
-		return nil, ErrInvalidSha3Uncles
+		return ErrInvalidSha3Uncles
 	}
 
 	// Make sure the transactions root matches up
@@ -730,21 +770,62 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, erro
 			block.Header.TxRoot,
 		))
 
-		return nil, ErrInvalidTxRoot
+		return ErrInvalidTxRoot
 	}
 
-	// Execute the transactions in the block and grab the result
-	blockResult, executeErr := b.executeBlockTransactions(block)
-	if executeErr != nil {
-		return nil, fmt.Errorf("unable to execute block transactions, %w", executeErr)
+	return nil
+}
+
+// VerifyFinalizedBlockWithReceipts verifies the finalized block against the given receipts
+// instead of executing its transactions. It is used for importing the history
+// below the state downloaded from the peers, so the state root is not checked
+func (b *Blockchain) VerifyFinalizedBlockWithReceipts(
+	block *types.Block,
+	receipts []*types.Receipt,
+) (*types.FullBlock, error) {
+	if block == nil {
+		return nil, ErrNoBlock
 	}
 
-	// Verify the local execution result with the proposed block data
-	if err := blockResult.verifyBlockResult(block); err != nil {
-		return nil, fmt.Errorf("unable to verify block execution result, %w", err)
+	// Make sure the consensus layer verifies this block header
+	if err := b.consensus.VerifyHeader(block.Header); err != nil {
+		return nil, fmt.Errorf("failed to verify the header: %w", err)
 	}
 
-	return blockResult.Receipts, nil
+	if err := b.verifyBlockParent(block); err != nil {
+		return nil, err
+	}
+
+	if err := b.verifyBlockRoots(block); err != nil {
+		return nil, err
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return nil, ErrInvalidReceiptsSize
+	}
+
+	if buildroot.CalculateReceiptsRoot(receipts) != block.Header.ReceiptsRoot {
+		return nil, ErrInvalidReceiptsRoot
+	}
+
+	// the fields which are not part of the receipts root are derived from the block
+	var cumulativeGasUsed uint64
+
+	for i, receipt := range receipts {
+		if receipt.CumulativeGasUsed < cumulativeGasUsed {
+			return nil, ErrInvalidGasUsed
+		}
+
+		receipt.GasUsed = receipt.CumulativeGasUsed - cumulativeGasUsed
+		receipt.TxHash = block.Transactions[i].Hash()
+		cumulativeGasUsed = receipt.CumulativeGasUsed
+	}
+
+	if cumulativeGasUsed != block.Header.GasUsed {
+		return nil, ErrInvalidGasUsed
+	}
+
+	return &types.FullBlock{Block: block, Receipts: receipts}, nil
 }
 
 // verifyBlockResult verifies that the block transaction execution result
@@ -845,6 +926,8 @@ func (b *Blockchain) WriteFullBlock(fblock *types.FullBlock, source string) erro
 		return err
 	}
 
+	b.writeLogIndex(batchWriter, header)
+
 	// write the receipts, do it only after the header has been written.
 	// Otherwise, a client might ask for a header once the receipt is valid,
 	// but before it is written into the storage
@@ -910,6 +993,8 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 		return err
 	}
 
+	b.writeLogIndex(batchWriter, header)
+
 	// Fetch the block receipts
 	blockReceipts, receiptsErr := b.extractBlockReceipts(block)
 	if receiptsErr != nil {
@@ -1157,9 +1242,25 @@ func (b *Blockchain) GetHashByNumber(blockNumber uint64) types.Hash {
 
 // dispatchEvent pushes a new event to the stream
 func (b *Blockchain) dispatchEvent(evnt *Event) {
+	b.pruneStates(evnt)
 	b.stream.push(evnt)
 }
 
+// pruneStates passes the state roots of the new canonical blocks to the state pruning.
+// It runs under the write lock, so every root is counted before the next block can be
+// executed and before the roots leaving the retention window release their nodes
+func (b *Blockchain) pruneStates(evnt *Event) {
+	if b.statePruner == nil {
+		return
+	}
+
+	for _, header := range evnt.NewChain {
+		if err := b.statePruner.InsertBlockState(header.StateRoot); err != nil {
+			b.logger.Error("failed to prune the state", "block", header.Number, "err", err)
+		}
+	}
+}
+
 // writeHeaderImpl writes a block and the data, assumes the genesis is already set
 // Returning parameters (is canonical header, new total difficulty, error)
 func (b *Blockchain) writeHeaderImpl(
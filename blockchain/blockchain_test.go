@@ -26,6 +26,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/leveldb"
 	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/memory"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
 )
 
 const (
@@ -1542,6 +1543,7 @@ func TestBlockchain_WriteFullBlock(t *testing.T) {
 	}
 
 	storageMock, _ := memory.NewMemoryStorage()
+	prunerMock := &mockStatePruner{}
 
 	bc := &Blockchain{
 		gpAverage: &gasPriceAverage{
@@ -1559,7 +1561,8 @@ func TestBlockchain_WriteFullBlock(t *testing.T) {
 			},
 			Genesis: &chain.Genesis{},
 		},
-		stream: newEventStream(),
+		stream:      newEventStream(),
+		statePruner: prunerMock,
 	}
 
 	bc.headersCache, _ = lru.New(10)
@@ -1568,7 +1571,8 @@ func TestBlockchain_WriteFullBlock(t *testing.T) {
 	existingTD := big.NewInt(1)
 	existingHeader := &types.Header{Number: 1}
 	header := &types.Header{
-		Number: 2,
+		Number:    2,
+		StateRoot: types.StringToHash("0x2"),
 	}
 	receipts := []*types.Receipt{
 		{GasUsed: 100},
@@ -1605,6 +1609,7 @@ func TestBlockchain_WriteFullBlock(t *testing.T) {
 	require.Equal(t, existingHeader.Number, bc.currentHeader.Load().Number)
 	require.Equal(t, existingTD, bc.currentDifficulty.Load())
 	require.True(t, bc.difficultyCache.Contains(existingHeader.Hash))
+	require.Empty(t, prunerMock.roots)
 
 	_, err = bc.db.ReadBlockLookup(existingHeader.Hash)
 	require.Error(t, err)
@@ -1621,6 +1626,8 @@ func TestBlockchain_WriteFullBlock(t *testing.T) {
 
 	require.NoError(t, err)
 	require.Equal(t, header.Number, bc.currentHeader.Load().Number)
+	// the state root is counted before the write returns
+	require.Equal(t, []types.Hash{header.StateRoot}, prunerMock.roots)
 
 	n, err := bc.db.ReadBlockLookup(header.Hash)
 	require.NoError(t, err)
@@ -1655,6 +1662,16 @@ func TestBlockchain_WriteFullBlock(t *testing.T) {
 	require.NotNil(t, r)
 }
 
+type mockStatePruner struct {
+	roots []types.Hash
+}
+
+func (m *mockStatePruner) InsertBlockState(root types.Hash) error {
+	m.roots = append(m.roots, root)
+
+	return nil
+}
+
 func TestDiskUsageWriteBatchAndUpdate(t *testing.T) {
 	const (
 		checkInterval  = 100 * time.Millisecond
@@ -2077,3 +2094,87 @@ func dirSize(tb testing.TB, dir string) (int64, error) {
 
 	return totalSize, nil
 }
+
+func TestBlockchain_VerifyFinalizedBlockWithReceipts(t *testing.T) {
+	t.Parallel()
+
+	parent := &types.Header{GasLimit: 100000}
+	parent.ComputeHash()
+
+	tx := types.NewTx(types.NewLegacyTx(types.WithNonce(1), types.WithGas(30000))).ComputeHash()
+
+	newBlock := func(receipts []*types.Receipt) *types.Block {
+		header := &types.Header{
+			Number:       1,
+			ParentHash:   parent.Hash,
+			GasLimit:     100000,
+			GasUsed:      30000,
+			Sha3Uncles:   types.EmptyUncleHash,
+			TxRoot:       buildroot.CalculateTransactionsRoot([]*types.Transaction{tx}, 1),
+			ReceiptsRoot: buildroot.CalculateReceiptsRoot(receipts),
+		}
+		header.ComputeHash()
+
+		return &types.Block{Header: header, Transactions: []*types.Transaction{tx}}
+	}
+
+	newReceipts := func(cumulativeGasUsed uint64) []*types.Receipt {
+		success := types.ReceiptSuccess
+
+		return []*types.Receipt{{CumulativeGasUsed: cumulativeGasUsed, Status: &success, Logs: []*types.Log{}}}
+	}
+
+	blockchain, err := NewMockBlockchain(map[TestCallbackType]interface{}{
+		StorageCallback: func(storage *storagev2.Storage) {
+			w := storage.NewWriter()
+
+			w.PutBlockLookup(parent.Hash, parent.Number)
+			w.PutHeader(parent)
+			require.NoError(t, w.WriteBatch())
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("Valid block", func(t *testing.T) {
+		t.Parallel()
+
+		block := newBlock(newReceipts(30000))
+
+		fullBlock, err := blockchain.VerifyFinalizedBlockWithReceipts(block, newReceipts(30000))
+		require.NoError(t, err)
+		require.Equal(t, block, fullBlock.Block)
+		require.Equal(t, tx.Hash(), fullBlock.Receipts[0].TxHash)
+		require.Equal(t, uint64(30000), fullBlock.Receipts[0].GasUsed)
+	})
+
+	t.Run("Invalid receipts size", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := blockchain.VerifyFinalizedBlockWithReceipts(newBlock(newReceipts(30000)), nil)
+		require.ErrorIs(t, err, ErrInvalidReceiptsSize)
+	})
+
+	t.Run("Invalid receipts root", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := blockchain.VerifyFinalizedBlockWithReceipts(newBlock(newReceipts(30000)), newReceipts(20000))
+		require.ErrorIs(t, err, ErrInvalidReceiptsRoot)
+	})
+
+	t.Run("Invalid gas used", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := blockchain.VerifyFinalizedBlockWithReceipts(newBlock(newReceipts(20000)), newReceipts(20000))
+		require.ErrorIs(t, err, ErrInvalidGasUsed)
+	})
+
+	t.Run("Missing parent", func(t *testing.T) {
+		t.Parallel()
+
+		block := newBlock(newReceipts(30000))
+		block.Header.ParentHash = types.StringToHash("01")
+
+		_, err := blockchain.VerifyFinalizedBlockWithReceipts(block, newReceipts(30000))
+		require.ErrorIs(t, err, ErrParentNotFound)
+	})
+}
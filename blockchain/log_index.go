@@ -0,0 +1,246 @@
+package blockchain
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// logIndexSectionSize is the number of blocks covered by a single bloom bit vector
+	logIndexSectionSize uint64 = 4096
+
+	// logIndexVectorSize is the size in bytes of a single bloom bit vector
+	logIndexVectorSize = logIndexSectionSize / 8
+
+	// bloomBitsCount is the number of bits in the logs bloom of a header
+	bloomBitsCount = types.BloomByteLength * 8
+)
+
+// RebuildLogIndex indexes the logs blooms of the canonical blocks that are not yet covered
+// by the log index. It is used to build the index for chains created before the index existed
+func (b *Blockchain) RebuildLogIndex() error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	from := uint64(1)
+	if head, ok := b.db.ReadLogIndexHead(); ok {
+		from = head + 1
+	}
+
+	to := b.Header().Number
+	if from > to {
+		return nil
+	}
+
+	b.logger.Info("rebuilding log index", "from", from, "to", to)
+
+	for section := from / logIndexSectionSize; section <= to/logIndexSectionSize; section++ {
+		first := max(from, section*logIndexSectionSize)
+		last := min(to, (section+1)*logIndexSectionSize-1)
+
+		vectors := map[uint16][]byte{}
+
+		for n := first; n <= last; n++ {
+			header, ok := b.GetHeaderByNumber(n)
+			if !ok {
+				return fmt.Errorf("header %d not found", n)
+			}
+
+			for bit := uint(0); bit < bloomBitsCount; bit++ {
+				if !header.LogsBloom.IsBitSet(bit) {
+					continue
+				}
+
+				vector, ok := vectors[uint16(bit)]
+				if !ok {
+					vector = b.readLogIndexVector(section, uint16(bit))
+					vectors[uint16(bit)] = vector
+				}
+
+				setVectorBit(vector, n%logIndexSectionSize)
+			}
+		}
+
+		batchWriter := b.db.NewWriter()
+
+		for bit, vector := range vectors {
+			batchWriter.PutLogIndexBits(section, bit, vector)
+		}
+
+		batchWriter.PutLogIndexHead(last)
+
+		if err := batchWriter.WriteBatch(); err != nil {
+			return err
+		}
+	}
+
+	b.logger.Info("log index rebuilt", "head", to)
+
+	return nil
+}
+
+// GetLogIndexCandidates returns the numbers of the blocks in the [from, to] range
+// whose logs bloom may contain logs matching the given addresses and topics.
+// Blocks which are not covered by the log index are always returned as candidates
+func (b *Blockchain) GetLogIndexCandidates(
+	from, to uint64,
+	addresses []types.Address,
+	topics [][]types.Hash,
+) []uint64 {
+	if current := b.Header().Number; to > current {
+		to = current
+	}
+
+	candidates := make([]uint64, 0)
+	if from > to {
+		return candidates
+	}
+
+	indexHead, ok := b.db.ReadLogIndexHead()
+	if !ok {
+		indexHead = 0
+	}
+
+	groups := logIndexQueryGroups(addresses, topics)
+	if len(groups) == 0 || indexHead < from {
+		// nothing to filter on, or nothing indexed in the range
+		for n := from; n <= to; n++ {
+			candidates = append(candidates, n)
+		}
+
+		return candidates
+	}
+
+	indexedTo := min(to, indexHead)
+
+	for section := from / logIndexSectionSize; section <= indexedTo/logIndexSectionSize; section++ {
+		matches := b.matchLogIndexSection(section, groups)
+
+		first := max(from, section*logIndexSectionSize)
+		last := min(indexedTo, (section+1)*logIndexSectionSize-1)
+
+		for n := first; n <= last; n++ {
+			if isVectorBitSet(matches, n%logIndexSectionSize) {
+				candidates = append(candidates, n)
+			}
+		}
+	}
+
+	for n := indexedTo + 1; n <= to; n++ {
+		candidates = append(candidates, n)
+	}
+
+	return candidates
+}
+
+// matchLogIndexSection returns the bit vector of the section blocks which match all of the groups
+func (b *Blockchain) matchLogIndexSection(section uint64, groups [][][3]uint) []byte {
+	vectors := map[uint][]byte{}
+	getVector := func(bit uint) []byte {
+		vector, ok := vectors[bit]
+		if !ok {
+			vector = b.readLogIndexVector(section, uint16(bit))
+			vectors[bit] = vector
+		}
+
+		return vector
+	}
+
+	result := make([]byte, logIndexVectorSize)
+	for i := range result {
+		result[i] = 0xff
+	}
+
+	for _, group := range groups {
+		groupMatches := make([]byte, logIndexVectorSize)
+
+		// a group matches if any of its elements has all of the bloom bits set
+		for _, bits := range group {
+			first, second, third := getVector(bits[0]), getVector(bits[1]), getVector(bits[2])
+
+			for i := range groupMatches {
+				groupMatches[i] |= first[i] & second[i] & third[i]
+			}
+		}
+
+		for i := range result {
+			result[i] &= groupMatches[i]
+		}
+	}
+
+	return result
+}
+
+// readLogIndexVector reads the bloom bit vector from the DB, returning an empty vector if it doesn't exist
+func (b *Blockchain) readLogIndexVector(section uint64, bit uint16) []byte {
+	vector := make([]byte, logIndexVectorSize)
+
+	if data, ok := b.db.ReadLogIndexBits(section, bit); ok {
+		copy(vector, data)
+	}
+
+	return vector
+}
+
+// writeLogIndex adds the header logs bloom to the log index.
+// Index entries are never removed, so blocks that end up on a fork only produce false positives
+func (b *Blockchain) writeLogIndex(batchWriter *storagev2.Writer, header *types.Header) {
+	section := header.Number / logIndexSectionSize
+
+	for bit := uint(0); bit < bloomBitsCount; bit++ {
+		if !header.LogsBloom.IsBitSet(bit) {
+			continue
+		}
+
+		vector := b.readLogIndexVector(section, uint16(bit))
+		setVectorBit(vector, header.Number%logIndexSectionSize)
+		batchWriter.PutLogIndexBits(section, uint16(bit), vector)
+	}
+
+	// move the index head only if there are no gaps in the index,
+	// otherwise the missing blocks are going to be indexed on the next rebuild
+	if head, ok := b.db.ReadLogIndexHead(); ok && head+1 == header.Number {
+		batchWriter.PutLogIndexHead(header.Number)
+	}
+}
+
+// logIndexQueryGroups converts the addresses and topics of a log query into groups of bloom bits.
+// A block matches the query if for each of the groups, any of the group elements has all the bits set
+func logIndexQueryGroups(addresses []types.Address, topics [][]types.Hash) [][][3]uint {
+	groups := make([][][3]uint, 0, len(topics)+1)
+
+	if len(addresses) > 0 {
+		group := make([][3]uint, len(addresses))
+		for i, addr := range addresses {
+			group[i] = types.BloomBits(addr.Bytes())
+		}
+
+		groups = append(groups, group)
+	}
+
+	for _, sub := range topics {
+		if len(sub) == 0 {
+			// wildcard topic
+			continue
+		}
+
+		group := make([][3]uint, len(sub))
+		for i, topic := range sub {
+			group[i] = types.BloomBits(topic.Bytes())
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+func setVectorBit(vector []byte, pos uint64) {
+	vector[pos/8] |= 1 << (7 - pos%8)
+}
+
+func isVectorBitSet(vector []byte, pos uint64) bool {
+	return vector[pos/8]&(1<<(7-pos%8)) != 0
+}
@@ -0,0 +1,152 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestHeadersWithLogs creates a chain of headers where the logs bloom
+// of each header contains the logs returned by the logs function
+func newTestHeadersWithLogs(n int, logs func(i uint64) []*types.Log) []*types.Header {
+	headers := NewTestHeaders(n)
+
+	for i, header := range headers {
+		if i > 0 {
+			header.ParentHash = headers[i-1].Hash
+		}
+
+		header.LogsBloom = types.CreateBloom([]*types.Receipt{{Logs: logs(header.Number)}})
+		header.ComputeHash()
+	}
+
+	return headers
+}
+
+func TestBlockchain_GetLogIndexCandidates(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1  = types.StringToAddress("1")
+		addr2  = types.StringToAddress("2")
+		topic1 = types.StringToHash("1")
+		topic2 = types.StringToHash("2")
+	)
+
+	headers := newTestHeadersWithLogs(20, func(i uint64) []*types.Log {
+		switch {
+		case i%5 == 0:
+			return []*types.Log{{Address: addr1, Topics: []types.Hash{topic1}}}
+		case i%7 == 0:
+			return []*types.Log{{Address: addr2, Topics: []types.Hash{topic2}}}
+		default:
+			return nil
+		}
+	})
+
+	b := NewTestBlockchain(t, headers)
+
+	head, ok := b.db.ReadLogIndexHead()
+	require.True(t, ok)
+	require.Equal(t, uint64(19), head)
+
+	cases := []struct {
+		name      string
+		from, to  uint64
+		addresses []types.Address
+		topics    [][]types.Hash
+		expected  []uint64
+	}{
+		{
+			name:      "single address",
+			from:      1,
+			to:        19,
+			addresses: []types.Address{addr1},
+			expected:  []uint64{5, 10, 15},
+		},
+		{
+			name:      "multiple addresses",
+			from:      1,
+			to:        19,
+			addresses: []types.Address{addr1, addr2},
+			expected:  []uint64{5, 7, 10, 14, 15},
+		},
+		{
+			name:     "topics only",
+			from:     6,
+			to:       19,
+			topics:   [][]types.Hash{{topic2}},
+			expected: []uint64{7, 14},
+		},
+		{
+			name:      "address and topic mismatch",
+			from:      1,
+			to:        19,
+			addresses: []types.Address{addr1},
+			topics:    [][]types.Hash{{topic2}},
+			expected:  []uint64{},
+		},
+		{
+			name:     "wildcard topic",
+			from:     1,
+			to:       19,
+			topics:   [][]types.Hash{{}, {}},
+			expected: []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19},
+		},
+		{
+			name:      "range beyond head",
+			from:      12,
+			to:        100,
+			addresses: []types.Address{addr1},
+			expected:  []uint64{15},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, c.expected, b.GetLogIndexCandidates(c.from, c.to, c.addresses, c.topics))
+		})
+	}
+}
+
+func TestBlockchain_RebuildLogIndex(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("1")
+
+	headers := newTestHeadersWithLogs(10, func(i uint64) []*types.Log {
+		if i%3 == 0 {
+			return []*types.Log{{Address: addr}}
+		}
+
+		return nil
+	})
+
+	b := NewTestBlockchain(t, nil)
+
+	// simulate a chain written before the log index existed
+	for _, header := range headers {
+		batchWriter := b.db.NewWriter()
+		td := new(big.Int).SetUint64(header.Number)
+
+		batchWriter.PutCanonicalHeader(header, td)
+		require.NoError(t, b.writeBatchAndUpdate(batchWriter, header, td, true))
+	}
+
+	// blocks not covered by the index are always candidates
+	require.Len(t, b.GetLogIndexCandidates(1, 9, []types.Address{addr}, nil), 9)
+
+	require.NoError(t, b.RebuildLogIndex())
+
+	head, ok := b.db.ReadLogIndexHead()
+	require.True(t, ok)
+	require.Equal(t, uint64(9), head)
+
+	require.Equal(t, []uint64{3, 6, 9}, b.GetLogIndexCandidates(1, 9, []types.Address{addr}, nil))
+}
@@ -17,12 +17,14 @@ var tableMapper = map[uint8][]byte{
 	storagev2.DIFFICULTY:   []byte("d"), // DB key = block number + block hash + mapper, value = block total diffculty
 	storagev2.HEADER:       []byte("h"), // DB key = block number + block hash + mapper, value = block header
 	storagev2.RECEIPTS:     []byte("r"), // DB key = block number + block hash + mapper, value = block receipts
+	storagev2.LOG_INDEX:    []byte("l"), // DB key = section number + bloom bit + mapper, value = section bit vector
 	storagev2.CANONICAL:    {},          // DB key = block number + mapper, value = block hash
 	storagev2.FORK:         {},          // DB key = FORK_KEY + mapper, value = fork hashes
 	storagev2.HEAD_HASH:    {},          // DB key = HEAD_HASH_KEY + mapper, value = head hash
 	storagev2.HEAD_NUMBER:  {},          // DB key = HEAD_NUMBER_KEY + mapper, value = head number
 	storagev2.BLOCK_LOOKUP: {},          // DB key = block hash + mapper, value = block number
 	storagev2.TX_LOOKUP:    {},          // DB key = tx hash + mapper, value = block number
+	storagev2.LOG_HEAD:     {},          // DB key = LOG_HEAD_KEY + mapper, value = last indexed block number
 }
 
 // NewLevelDBStorage creates the new storage reference with leveldb default options
@@ -43,6 +43,8 @@ var tableMapper = map[uint8]string{
 	storagev2.HEAD_NUMBER:  "HeadNumber",
 	storagev2.BLOCK_LOOKUP: "BlockLookup",
 	storagev2.TX_LOOKUP:    "TxLookup",
+	storagev2.LOG_INDEX:    "LogIndex",
+	storagev2.LOG_HEAD:     "LogHead",
 }
 
 // NewMdbxStorage creates the new storage reference for mdbx database
@@ -29,12 +29,15 @@ type Writer struct {
 }
 
 // Tables
+//
+//nolint:stylecheck // needed because linter considers _ in name as an error
 const (
 	BODY       = uint8(0)
 	CANONICAL  = uint8(2)
 	DIFFICULTY = uint8(4)
 	HEADER     = uint8(6)
 	RECEIPTS   = uint8(8)
+	LOG_INDEX  = uint8(10)
 )
 
 // Lookup tables
@@ -46,6 +49,7 @@ const (
 	HEAD_NUMBER  = uint8(4) | LOOKUP_INDEX
 	BLOCK_LOOKUP = uint8(6) | LOOKUP_INDEX
 	TX_LOOKUP    = uint8(8) | LOOKUP_INDEX
+	LOG_HEAD     = uint8(10) | LOOKUP_INDEX
 )
 
 //nolint:stylecheck // needed because linter considers _ in name as an error
@@ -64,6 +68,7 @@ var (
 	FORK_KEY        = []byte("0000000f")
 	HEAD_HASH_KEY   = []byte("0000000h")
 	HEAD_NUMBER_KEY = []byte("0000000n")
+	LOG_HEAD_KEY    = []byte("0000000l")
 )
 
 var ErrNotFound = fmt.Errorf("not found")
@@ -109,6 +109,27 @@ func (s *Storage) ReadReceipts(bn uint64, bh types.Hash) ([]*types.Receipt, erro
 	return *receipts, err
 }
 
+// LOG INDEX //
+
+// ReadLogIndexBits reads the bloom bit vector of the given section
+func (s *Storage) ReadLogIndexBits(section uint64, bit uint16) ([]byte, bool) {
+	return s.get(LOG_INDEX, getLogIndexKey(section, bit))
+}
+
+// ReadLogIndexHead returns the number of the last block covered by the log index
+func (s *Storage) ReadLogIndexHead() (uint64, bool) {
+	data, ok := s.get(LOG_HEAD, LOG_HEAD_KEY)
+	if !ok {
+		return 0, false
+	}
+
+	if len(data) != 8 {
+		return 0, false
+	}
+
+	return common.EncodeBytesToUint64(data), true
+}
+
 // TX LOOKUP //
 
 // ReadTxLookup reads the block number using the transaction hash
@@ -1,6 +1,7 @@
 package storagev2
 
 import (
+	"encoding/binary"
 	"math/big"
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
@@ -60,6 +61,15 @@ func (w *Writer) PutForks(forks []types.Hash) {
 	w.putRlp(FORK, FORK_KEY, &fs)
 }
 
+func (w *Writer) PutLogIndexBits(section uint64, bit uint16, bits []byte) {
+	// section_u64 + bit_u16 -> bit vector of the section
+	w.putIntoTable(LOG_INDEX, getLogIndexKey(section, bit), bits)
+}
+
+func (w *Writer) PutLogIndexHead(bn uint64) {
+	w.putIntoTable(LOG_HEAD, LOG_HEAD_KEY, common.EncodeUint64ToBytes(bn))
+}
+
 func (w *Writer) putRlp(t uint8, k []byte, raw types.RLPMarshaler) {
 	var data []byte
 
@@ -100,6 +110,10 @@ func (w *Writer) getBatch(t uint8) Batch {
 	return w.batch[MAINDB_INDEX]
 }
 
+func getLogIndexKey(section uint64, bit uint16) []byte {
+	return binary.BigEndian.AppendUint16(common.EncodeUint64ToBytes(section), bit)
+}
+
 func getKey(n uint64, h types.Hash) []byte {
 	a, b := common.EncodeUint64ToBytes(n), h.Bytes()
 
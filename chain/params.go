@@ -96,6 +96,7 @@ const (
 	EIP3855        = "EIP3855"
 	Berlin         = "Berlin"
 	EIP3607        = "EIP3607"
+	Cancun         = "cancun"
 )
 
 // Forks is map which contains all forks and their starting blocks from genesis
@@ -135,6 +136,7 @@ func (f *Forks) At(block uint64) ForksInTime {
 		EIP3855:        f.IsActive(EIP3855, block),
 		Berlin:         f.IsActive(Berlin, block),
 		EIP3607:        f.IsActive(EIP3607, block),
+		Cancun:         f.IsActive(Cancun, block),
 	}
 }
 
@@ -188,18 +190,19 @@ type ForksInTime struct {
 	Governance,
 	EIP3855,
 	Berlin,
-	EIP3607 bool
+	EIP3607,
+	Cancun bool
 }
 
 func (f ForksInTime) String() string {
 	return fmt.Sprintf("EIP150: %t, EIP158: %t, EIP155: %t, "+
 		"Homestead: %t, Byzantium: %t, Constantinople: %t, "+
 		"Petersburg: %t, Istanbul: %t, Berlin: %t, London: %t"+
-		"Governance: %t, EIP3855: %t, EIP3607: %t",
+		"Governance: %t, EIP3855: %t, EIP3607: %t, Cancun: %t",
 		f.EIP150, f.EIP158, f.EIP155,
 		f.Homestead, f.Byzantium, f.Constantinople, f.Petersburg,
 		f.Istanbul, f.Berlin, f.London,
-		f.Governance, f.EIP3855, f.EIP3607)
+		f.Governance, f.EIP3855, f.EIP3607, f.Cancun)
 }
 
 // AllForksEnabled should contain all supported forks by current edge version
@@ -217,4 +220,5 @@ var AllForksEnabled = &Forks{
 	EIP3855:        NewFork(0),
 	Berlin:         NewFork(0),
 	EIP3607:        NewFork(0),
+	Cancun:         NewFork(0),
 }
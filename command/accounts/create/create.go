@@ -3,6 +3,7 @@ package create
 import (
 	"fmt"
 
+	"github.com/0xPolygon/polygon-edge/accounts/hdwallet"
 	"github.com/0xPolygon/polygon-edge/command"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/jsonrpc"
@@ -37,6 +38,13 @@ func setFlags(cmd *cobra.Command) {
 		"passphrase for access to private key",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.hd,
+		hdFlag,
+		false,
+		"create HD wallet from newly generated mnemonic, deriving accounts along the BIP-44 path m/44'/60'/0'/0",
+	)
+
 	_ = cmd.MarkFlagRequired(passphraseFlag)
 	helper.RegisterJSONRPCFlag(cmd)
 }
@@ -51,6 +59,32 @@ func runCommand(cmd *cobra.Command, _ []string) {
 		return
 	}
 
+	if params.hd {
+		// the mnemonic is generated locally, the node never sends it back
+		mnemonic, err := hdwallet.NewMnemonic()
+		if err != nil {
+			outputter.SetError(fmt.Errorf("can't generate mnemonic: %w", err))
+
+			return
+		}
+
+		var wallet hdWalletResponse
+
+		if err := client.EndpointCall("personal_importMnemonic", &wallet, mnemonic, params.passphrase); err != nil {
+			outputter.SetError(fmt.Errorf("can't create new hd wallet: %w", err))
+
+			return
+		}
+
+		outputter.SetCommandResult(command.Results{&createResult{
+			Address:  wallet.Address,
+			WalletID: wallet.ID,
+			Mnemonic: mnemonic,
+		}})
+
+		return
+	}
+
 	var address types.Address
 
 	if err := client.EndpointCall("personal_newAccount", &address, params.passphrase); err != nil {
@@ -8,26 +8,47 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
-const passphraseFlag = "passphrase"
+const (
+	passphraseFlag = "passphrase"
+	hdFlag         = "hd"
+)
 
 type createParams struct {
 	passphrase string
+	hd         bool
 	jsonRPC    string
 }
 
-type createResult struct {
+// hdWalletResponse is the response of personal_importMnemonic
+type hdWalletResponse struct {
+	ID      string        `json:"id"`
 	Address types.Address `json:"address"`
 }
 
+type createResult struct {
+	Address  types.Address `json:"address"`
+	WalletID string        `json:"walletID,omitempty"`
+	Mnemonic string        `json:"mnemonic,omitempty"`
+}
+
 func (i *createResult) GetOutput() string {
 	var buffer bytes.Buffer
 
-	vals := make([]string, 0, 2)
+	vals := make([]string, 0, 3)
 	vals = append(vals, fmt.Sprintf("Address|%s", i.Address.String()))
 
+	if i.WalletID != "" {
+		vals = append(vals, fmt.Sprintf("HD wallet ID|%s", i.WalletID))
+		vals = append(vals, fmt.Sprintf("Mnemonic|%s", i.Mnemonic))
+	}
+
 	buffer.WriteString("\n[Created accounts]\n")
 	buffer.WriteString(helper.FormatKV(vals))
 	buffer.WriteString("\n")
 
+	if i.Mnemonic != "" {
+		buffer.WriteString("\nThe mnemonic is not shown again, store it in a safe place to recover the wallet\n")
+	}
+
 	return buffer.String()
 }
@@ -18,8 +18,10 @@ func GetCommand() *cobra.Command {
 	importCmd := &cobra.Command{
 		Use:   "insert",
 		Short: "Insert existing key to new account with private key and auth passphrase",
-		PreRun: func(cmd *cobra.Command, args []string) {
+		PreRunE: func(cmd *cobra.Command, args []string) error {
 			params.jsonRPC = helper.GetJSONRPCAddress(cmd)
+
+			return params.validateFlags()
 		},
 		Run: runCommand,
 	}
@@ -37,6 +39,13 @@ func setFlags(cmd *cobra.Command) {
 		"privateKey key of new account",
 	)
 
+	cmd.Flags().StringVar(
+		&params.mnemonic,
+		mnemonicFlag,
+		"",
+		"mnemonic of new HD wallet, deriving accounts along the BIP-44 path m/44'/60'/0'/0",
+	)
+
 	cmd.Flags().StringVar(
 		&params.passphrase,
 		passphraseFlag,
@@ -44,6 +53,7 @@ func setFlags(cmd *cobra.Command) {
 		"passphrase for access to private key",
 	)
 
+	cmd.MarkFlagsMutuallyExclusive(privateKeyFlag, mnemonicFlag)
 	_ = cmd.MarkFlagRequired(passphraseFlag)
 	helper.RegisterJSONRPCFlag(cmd)
 }
@@ -58,6 +68,21 @@ func runCommand(cmd *cobra.Command, _ []string) {
 		return
 	}
 
+	if params.mnemonic != "" {
+		var wallet hdWalletResponse
+
+		if err := client.EndpointCall("personal_importMnemonic", &wallet,
+			params.mnemonic, params.passphrase); err != nil {
+			outputter.SetError(fmt.Errorf("can't import mnemonic: %w", err))
+
+			return
+		}
+
+		outputter.SetCommandResult(command.Results{&insertResult{Address: wallet.Address, WalletID: wallet.ID}})
+
+		return
+	}
+
 	var address types.Address
 
 	if err := client.EndpointCall("personal_importRawKey", &address,
@@ -2,6 +2,7 @@ package insert
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/command/helper"
@@ -10,25 +11,48 @@ import (
 
 const (
 	privateKeyFlag = "private-key"
+	mnemonicFlag   = "mnemonic"
 	passphraseFlag = "passphrase"
 )
 
+var errNoKeyProvided = errors.New("either private key or mnemonic must be provided")
+
 type insertParams struct {
 	privateKey string
+	mnemonic   string
 	passphrase string
 	jsonRPC    string
 }
 
-type insertResult struct {
+func (ip *insertParams) validateFlags() error {
+	if ip.privateKey == "" && ip.mnemonic == "" {
+		return errNoKeyProvided
+	}
+
+	return nil
+}
+
+// hdWalletResponse is the response of personal_importMnemonic
+type hdWalletResponse struct {
+	ID      string        `json:"id"`
 	Address types.Address `json:"address"`
 }
 
+type insertResult struct {
+	Address  types.Address `json:"address"`
+	WalletID string        `json:"walletID,omitempty"`
+}
+
 func (i *insertResult) GetOutput() string {
 	var buffer bytes.Buffer
 
 	vals := make([]string, 0, 2)
 	vals = append(vals, fmt.Sprintf("Address|%s", i.Address.String()))
 
+	if i.WalletID != "" {
+		vals = append(vals, fmt.Sprintf("HD wallet ID|%s", i.WalletID))
+	}
+
 	buffer.WriteString("\n[Inserted accounts]\n")
 	buffer.WriteString(helper.FormatKV(vals))
 	buffer.WriteString("\n")
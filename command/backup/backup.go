@@ -4,6 +4,8 @@ import (
 	"github.com/0xPolygon/polygon-edge/command"
 	"github.com/spf13/cobra"
 
+	"github.com/0xPolygon/polygon-edge/command/backup/export"
+	"github.com/0xPolygon/polygon-edge/command/backup/importer"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 )
 
@@ -20,9 +22,20 @@ func GetCommand() *cobra.Command {
 	setFlags(backupCmd)
 	helper.SetRequiredFlags(backupCmd, params.getRequiredFlags())
 
+	registerSubcommands(backupCmd)
+
 	return backupCmd
 }
 
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// backup export
+		export.GetCommand(),
+		// backup import
+		importer.GetCommand(),
+	)
+}
+
 func setFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(
 		&params.out,
@@ -0,0 +1,86 @@
+package export
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use: "export",
+		Short: "Exports the blocks from the data directory of the stopped node to the chunked backup " +
+			"or to the archive file. The interrupted chunked export is resumed",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(exportCmd)
+	helper.SetRequiredFlags(exportCmd, params.getRequiredFlags())
+
+	return exportCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.out,
+		outFlag,
+		"",
+		"the backup directory, or the archive file if the archive format is used",
+	)
+
+	cmd.Flags().StringVar(
+		&params.fromRaw,
+		fromFlag,
+		"0",
+		"the beginning height of the chain in backup",
+	)
+
+	cmd.Flags().StringVar(
+		&params.toRaw,
+		toFlag,
+		"",
+		"the end height of the chain in backup, the latest block if not set",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.chunkSize,
+		chunkSizeFlag,
+		archive.DefaultChunkSize,
+		"the number of blocks in a single chunk",
+	)
+
+	cmd.Flags().StringVar(
+		&params.format,
+		formatFlag,
+		chunksFormat,
+		fmt.Sprintf("the format of the backup (%s or %s)", chunksFormat, archiveFormat),
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.export(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
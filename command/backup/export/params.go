@@ -0,0 +1,133 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/leveldb"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag   = "data-dir"
+	outFlag       = "out"
+	fromFlag      = "from"
+	toFlag        = "to"
+	chunkSizeFlag = "chunk-size"
+	formatFlag    = "format"
+
+	chunksFormat  = "chunks"
+	archiveFormat = "archive"
+)
+
+var (
+	params = &exportParams{}
+)
+
+var (
+	errDecodeRange      = errors.New("unable to decode range value")
+	errInvalidRange     = errors.New(`invalid "to" value; must be >= "from"`)
+	errInvalidChunkSize = errors.New(`invalid "chunk-size" value; must be greater than zero`)
+	errUnknownFormat    = fmt.Errorf("unknown format; must be %s or %s", chunksFormat, archiveFormat)
+)
+
+type exportParams struct {
+	dataDir   string
+	out       string
+	chunkSize uint64
+	format    string
+
+	fromRaw string
+	toRaw   string
+
+	from uint64
+	to   *uint64
+
+	resFrom uint64
+	resTo   uint64
+	chunks  int
+}
+
+func (p *exportParams) validateFlags() error {
+	var parseErr error
+
+	if p.from, parseErr = common.ParseUint64orHex(&p.fromRaw); parseErr != nil {
+		return errDecodeRange
+	}
+
+	if p.toRaw != "" {
+		var parsedTo uint64
+
+		if parsedTo, parseErr = common.ParseUint64orHex(&p.toRaw); parseErr != nil {
+			return errDecodeRange
+		}
+
+		if p.from > parsedTo {
+			return errInvalidRange
+		}
+
+		p.to = &parsedTo
+	}
+
+	if p.chunkSize == 0 {
+		return errInvalidChunkSize
+	}
+
+	if p.format != chunksFormat && p.format != archiveFormat {
+		return errUnknownFormat
+	}
+
+	return nil
+}
+
+func (p *exportParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		outFlag,
+	}
+}
+
+func (p *exportParams) export() error {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "backup",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	db, err := leveldb.NewLevelDBStorage(filepath.Join(p.dataDir, "blockchain"), logger)
+	if err != nil {
+		return fmt.Errorf("failed to open the blockchain storage: %w", err)
+	}
+
+	defer db.Close()
+
+	if p.format == archiveFormat {
+		p.resFrom, p.resTo, err = archive.ExportChain(archive.NewStorageChain(db), logger, p.from, p.to, p.out)
+
+		return err
+	}
+
+	manifest, err := archive.ExportChunks(db, logger, p.from, p.to, p.chunkSize, p.out)
+	if err != nil {
+		return err
+	}
+
+	p.resFrom = manifest.From
+	p.resTo, _, _ = manifest.Latest()
+	p.chunks = len(manifest.Chunks)
+
+	return nil
+}
+
+func (p *exportParams) getResult() command.CommandResult {
+	return &ExportResult{
+		From:   p.resFrom,
+		To:     p.resTo,
+		Out:    p.out,
+		Format: p.format,
+		Chunks: p.chunks,
+	}
+}
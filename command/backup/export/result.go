@@ -0,0 +1,37 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type ExportResult struct {
+	From   uint64 `json:"from"`
+	To     uint64 `json:"to"`
+	Out    string `json:"out"`
+	Format string `json:"format"`
+	Chunks int    `json:"chunks,omitempty"`
+}
+
+func (r *ExportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	vals := []string{
+		fmt.Sprintf("Path|%s", r.Out),
+		fmt.Sprintf("Format|%s", r.Format),
+		fmt.Sprintf("From|%d", r.From),
+		fmt.Sprintf("To|%d", r.To),
+	}
+
+	if r.Format == chunksFormat {
+		vals = append(vals, fmt.Sprintf("Chunks|%d", r.Chunks))
+	}
+
+	buffer.WriteString("\n[BACKUP EXPORT]\n")
+	buffer.WriteString("Exported blocks successfully:\n")
+	buffer.WriteString(helper.FormatKV(vals))
+
+	return buffer.String()
+}
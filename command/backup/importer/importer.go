@@ -0,0 +1,87 @@
+package importer
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	importCmd := &cobra.Command{
+		Use: "import",
+		Short: "Verifies the chunked backup or the archive file and imports its blocks to the data directory " +
+			"of the stopped node, executing them on top of the local chain",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(importCmd)
+	helper.SetRequiredFlags(importCmd, params.getRequiredFlags())
+
+	return importCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.in,
+		inFlag,
+		"",
+		"the backup directory or the archive file",
+	)
+
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		genesisPathFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file used by the node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.backend,
+		backendFlag,
+		itrie.LevelDBBackend,
+		"the database backend of the state storage",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.verifyOnly,
+		verifyOnlyFlag,
+		false,
+		"only verify the chunked backup, without the data directory",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.trustBackup,
+		trustBackupFlag,
+		false,
+		"import the blocks without verifying their seals. The checksums of the backup only prove its integrity, "+
+			"not its origin, so import only the backups of a trusted origin",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.importBackup(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
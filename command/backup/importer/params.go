@@ -0,0 +1,248 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/leveldb"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	inFlag          = "in"
+	dataDirFlag     = "data-dir"
+	genesisPathFlag = "chain"
+	backendFlag     = "state-storage-backend"
+	verifyOnlyFlag  = "verify-only"
+	trustBackupFlag = "trust-backup"
+)
+
+var (
+	params = &importParams{}
+)
+
+var (
+	errDataDirRequired = errors.New(`the "data-dir" flag is required unless only verifying`)
+	errVerifyArchive   = errors.New("only the chunked backup can be verified without the data directory")
+	errNotInitialized  = errors.New("the blockchain storage is not initialized, start the node once before importing")
+	errUntrustedBackup = errors.New(`the seals of the imported blocks are not verified, ` +
+		`the "trust-backup" flag is required to import the backup of a trusted origin`)
+)
+
+type importParams struct {
+	in          string
+	dataDir     string
+	genesisPath string
+	backend     string
+	verifyOnly  bool
+	trustBackup bool
+
+	isChunked  bool
+	from       uint64
+	to         uint64
+	headBefore uint64
+	headAfter  uint64
+}
+
+func (p *importParams) validateFlags() error {
+	info, err := os.Stat(p.in)
+	if err != nil {
+		return fmt.Errorf("the backup not found: %w", err)
+	}
+
+	p.isChunked = info.IsDir()
+
+	if p.verifyOnly {
+		if !p.isChunked {
+			return errVerifyArchive
+		}
+
+		return nil
+	}
+
+	if p.dataDir == "" {
+		return errDataDirRequired
+	}
+
+	// the checksums of the backup only prove its integrity and the seals are not verified offline,
+	// so the blocks above the genesis are imported only if the origin of the backup is trusted
+	if !p.trustBackup {
+		return errUntrustedBackup
+	}
+
+	return nil
+}
+
+func (p *importParams) getRequiredFlags() []string {
+	return []string{
+		inFlag,
+	}
+}
+
+func (p *importParams) importBackup() error {
+	// the chunked backup is verified as a whole before anything is written
+	if p.isChunked {
+		manifest, err := archive.VerifyChunks(p.in)
+		if err != nil {
+			return fmt.Errorf("invalid backup: %w", err)
+		}
+
+		p.from = manifest.From
+		p.to, _, _ = manifest.Latest()
+	}
+
+	if p.verifyOnly {
+		return nil
+	}
+
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "backup",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	chain, stateStorage, err := p.openChain(logger)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := chain.Close(); err != nil {
+			logger.Error("failed to close the blockchain storage", "err", err)
+		}
+
+		if err := stateStorage.Close(); err != nil {
+			logger.Error("failed to close the state storage", "err", err)
+		}
+	}()
+
+	p.headBefore = chain.Header().Number
+
+	if err := archive.RestoreChain(chain, p.in, progress.NewProgressionWrapper(progress.ChainSyncRestore)); err != nil {
+		return err
+	}
+
+	p.headAfter = chain.Header().Number
+
+	return nil
+}
+
+// openChain creates the blockchain on top of the data directory, which executes the imported blocks
+// the same way as the node does, but without the consensus
+func (p *importParams) openChain(logger hclog.Logger) (*blockchain.Blockchain, itrie.Storage, error) {
+	config, err := chain.ImportFromFile(p.genesisPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load the genesis file: %w", err)
+	}
+
+	db, err := leveldb.NewLevelDBStorage(filepath.Join(p.dataDir, "blockchain"), logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open the blockchain storage: %w", err)
+	}
+
+	// the genesis state root is computed by the node on the first start,
+	// the rest of the genesis header is checked against the genesis file
+	genesisHash, ok := db.ReadCanonicalHash(0)
+	if !ok {
+		_ = db.Close()
+
+		return nil, nil, errNotInitialized
+	}
+
+	genesisHeader, err := db.ReadHeader(0, genesisHash)
+	if err != nil {
+		_ = db.Close()
+
+		return nil, nil, fmt.Errorf("failed to read the genesis header: %w", err)
+	}
+
+	config.Genesis.StateRoot = genesisHeader.StateRoot
+
+	stateStorage, err := itrie.NewStorage(p.backend, filepath.Join(p.dataDir, itrie.StorageDir(p.backend)), logger)
+	if err != nil {
+		_ = db.Close()
+
+		return nil, nil, fmt.Errorf("failed to open the state storage: %w", err)
+	}
+
+	executor := state.NewExecutor(config.Params, itrie.NewState(stateStorage), logger.Named("executor"))
+
+	if config.Params.GetEngine() == string(server.PolyBFTConsensus) {
+		if executor.IsL1OriginatedToken, err = polybft.IsL1OriginatedTokenCheck(config.Params); err != nil {
+			_ = db.Close()
+			_ = stateStorage.Close()
+
+			return nil, nil, err
+		}
+	}
+
+	signer := crypto.NewSigner(config.Params.Forks.At(0), uint64(config.Params.ChainID))
+
+	bc, err := blockchain.NewBlockchain(logger, db, config, &offlineVerifier{}, executor, signer)
+	if err != nil {
+		_ = db.Close()
+		_ = stateStorage.Close()
+
+		return nil, nil, err
+	}
+
+	executor.GetHash = bc.GetHashHelper
+
+	if err := bc.ComputeGenesis(); err != nil {
+		_ = db.Close()
+		_ = stateStorage.Close()
+
+		return nil, nil, err
+	}
+
+	return bc, stateStorage, nil
+}
+
+func (p *importParams) getResult() command.CommandResult {
+	return &ImportResult{
+		In:         p.in,
+		Chunked:    p.isChunked,
+		VerifyOnly: p.verifyOnly,
+		From:       p.from,
+		To:         p.to,
+		HeadBefore: p.headBefore,
+		HeadAfter:  p.headAfter,
+	}
+}
+
+// offlineVerifier replaces the consensus while importing the blocks to the stopped node.
+// The seals are not verified, the blocks are only checked against the local chain by executing them,
+// which doesn't reject the forged chain, hence the import requires the backup to be trusted
+type offlineVerifier struct{}
+
+func (v *offlineVerifier) VerifyHeader(*types.Header) error {
+	return nil
+}
+
+func (v *offlineVerifier) ProcessHeaders([]*types.Header) error {
+	return nil
+}
+
+func (v *offlineVerifier) GetBlockCreator(h *types.Header) (types.Address, error) {
+	return types.BytesToAddress(h.Miner), nil
+}
+
+func (v *offlineVerifier) PreCommitState(*types.Block, *state.Transition) error {
+	return nil
+}
+
+func (v *offlineVerifier) GetLatestChainConfig() (*chain.Params, error) {
+	return nil, nil
+}
@@ -0,0 +1,50 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type ImportResult struct {
+	In         string `json:"in"`
+	Chunked    bool   `json:"chunked"`
+	VerifyOnly bool   `json:"verify_only"`
+	From       uint64 `json:"from,omitempty"`
+	To         uint64 `json:"to,omitempty"`
+	HeadBefore uint64 `json:"head_before,omitempty"`
+	HeadAfter  uint64 `json:"head_after,omitempty"`
+}
+
+func (r *ImportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	vals := []string{
+		fmt.Sprintf("Backup|%s", r.In),
+	}
+
+	if r.Chunked {
+		vals = append(vals,
+			fmt.Sprintf("From|%d", r.From),
+			fmt.Sprintf("To|%d", r.To),
+		)
+	}
+
+	if r.VerifyOnly {
+		buffer.WriteString("\n[BACKUP VERIFY]\n")
+		buffer.WriteString("Verified backup successfully, the checksums prove its integrity, not its origin:\n")
+	} else {
+		vals = append(vals,
+			fmt.Sprintf("Head before import|%d", r.HeadBefore),
+			fmt.Sprintf("Head after import|%d", r.HeadAfter),
+		)
+
+		buffer.WriteString("\n[BACKUP IMPORT]\n")
+		buffer.WriteString("Imported blocks successfully:\n")
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
+
+	return buffer.String()
+}
@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/url"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/chain"
@@ -47,13 +48,21 @@ const (
 
 // HandleSignals is a helper method for handling signals sent to the console
 // Like stop, error, etc.
+// If reloadFn is set, SIGHUP calls it instead of shutting down the client
 func HandleSignals(
 	closeFn func(),
+	reloadFn func(),
 	outputter command.OutputFormatter,
 ) error {
 	signalCh := common.GetTerminationSignalCh()
 	sig := <-signalCh
 
+	for sig == syscall.SIGHUP && reloadFn != nil {
+		reloadFn()
+
+		sig = <-signalCh
+	}
+
 	closeMessage := fmt.Sprintf("\n[SIGNAL] Caught signal: %v\n", sig)
 	closeMessage += "Gracefully shutting down client...\n"
 
@@ -46,7 +46,15 @@ func setFlags(cmd *cobra.Command) {
 		&params.loadTestType,
 		loadTestTypeFlag,
 		"eoa",
-		"the type of load test to run (supported types: eoa, erc20, erc721, mixed)",
+		"the type of load test to run (supported types: eoa, erc20, erc721, mixed, scenario)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.scenarioFile,
+		scenarioFlag,
+		"",
+		"the YAML or JSON file of the scenario load test, declaring the contracts to deploy, "+
+			"the weighted mix of calls and the phases with their target TPS (used by the scenario type)",
 	)
 
 	cmd.Flags().StringVar(
@@ -126,6 +134,7 @@ func runCommand(cmd *cobra.Command, _ []string) {
 		Mnemonnic:            params.mnemonic,
 		LoadTestType:         params.loadTestType,
 		LoadTestName:         params.loadTestName,
+		ScenarioFile:         params.scenarioFile,
 		JSONRPCUrl:           params.jsonRPCAddress,
 		ReceiptsTimeout:      params.receiptsTimeout,
 		TxPoolTimeout:        params.txPoolTimeout,
@@ -2,6 +2,7 @@ package loadtest
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/loadtest/runner"
@@ -14,6 +15,7 @@ const (
 
 	loadTestTypeFlag = "type"
 	loadTestNameFlag = "name"
+	scenarioFlag     = "scenario"
 
 	txPoolTimeoutFlag = "txpool-timeout"
 
@@ -32,12 +34,15 @@ var (
 	errInvalidVUs              = errors.New("vus must be greater than 0")
 	errInvalidTxsPerUser       = errors.New("txs-per-user must be greater than 0")
 	errInvalidBatchSize        = errors.New("batch-size must be greater than 0 and less or equal to txs-per-user")
+	errNoScenarioProvided      = errors.New("no scenario file provided for the scenario load test")
+	errScenarioNotSupported    = errors.New("scenario file is supported only by the scenario load test type")
 )
 
 type loadTestParams struct {
 	mnemonic       string
 	loadTestType   string
 	loadTestName   string
+	scenarioFile   string
 	jsonRPCAddress string
 
 	receiptsTimeout time.Duration
@@ -65,6 +70,18 @@ func (ltp *loadTestParams) validateFlags() error {
 		return errUnsupportedLoadTestType
 	}
 
+	if strings.EqualFold(ltp.loadTestType, runner.ScenarioTestType) {
+		if ltp.scenarioFile == "" {
+			return errNoScenarioProvided
+		}
+
+		if _, err := runner.LoadScenario(ltp.scenarioFile); err != nil {
+			return err
+		}
+	} else if ltp.scenarioFile != "" {
+		return errScenarioNotSupported
+	}
+
 	if ltp.vus < 1 {
 		return errInvalidVUs
 	}
@@ -0,0 +1,194 @@
+package monitor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/Ethernal-Tech/ethgo"
+	"github.com/Ethernal-Tech/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+)
+
+var errNoEvents = errors.New("no events found in the ABI")
+
+// eventDecoder decodes the logs of the known events into the named events and arguments
+type eventDecoder struct {
+	// events are the known events indexed by their topic (signature hash),
+	// multiple events share the same topic if they differ only in the indexed arguments
+	events map[ethgo.Hash][]*abi.Event
+}
+
+// newEventDecoder creates the event decoder from the ABI files on the given paths
+func newEventDecoder(abiPaths []string) (*eventDecoder, error) {
+	d := &eventDecoder{events: make(map[ethgo.Hash][]*abi.Event)}
+
+	for _, path := range abiPaths {
+		contractABI, err := loadABI(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := d.addABI(contractABI); err != nil {
+			return nil, fmt.Errorf("failed to add ABI '%s': %w", path, err)
+		}
+	}
+
+	return d, nil
+}
+
+// loadABI reads the ABI file, which is either the plain ABI JSON array
+// or the compiled contract artifact containing the ABI in the abi field
+func loadABI(path string) (*abi.ABI, error) {
+	raw, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ABI file '%s': %w", path, err)
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(string(raw)), "[") {
+		contractABI, err := abi.NewABI(string(raw))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ABI file '%s': %w", path, err)
+		}
+
+		return contractABI, nil
+	}
+
+	var artifact struct {
+		ABI *abi.ABI `json:"abi"`
+	}
+
+	if err := json.Unmarshal(raw, &artifact); err != nil {
+		return nil, fmt.Errorf("failed to parse ABI file '%s': %w", path, err)
+	}
+
+	if artifact.ABI == nil {
+		return nil, fmt.Errorf("ABI file '%s' contains no abi field", path)
+	}
+
+	return artifact.ABI, nil
+}
+
+func (d *eventDecoder) addABI(contractABI *abi.ABI) error {
+	added := 0
+
+	for _, event := range contractABI.Events {
+		// anonymous events have no signature topic, so they can not be matched
+		if event.Anonymous {
+			continue
+		}
+
+		id := event.ID()
+
+		if !d.hasEvent(id, event) {
+			d.events[id] = append(d.events[id], event)
+		}
+
+		added++
+	}
+
+	if added == 0 {
+		return errNoEvents
+	}
+
+	return nil
+}
+
+func (d *eventDecoder) hasEvent(id ethgo.Hash, event *abi.Event) bool {
+	for _, e := range d.events[id] {
+		if reflect.DeepEqual(e.Inputs.TupleElems(), event.Inputs.TupleElems()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decode decodes the log into the contract event,
+// it returns nil if the log does not match any of the known events
+func (d *eventDecoder) decode(log *ethgo.Log) *ContractEvent {
+	if len(log.Topics) == 0 {
+		return nil
+	}
+
+	for _, event := range d.events[log.Topics[0]] {
+		values, err := event.ParseLog(log)
+		if err != nil {
+			// the event with the same signature but different indexed arguments
+			continue
+		}
+
+		elems := event.Inputs.TupleElems()
+		names := make([]string, len(elems))
+		args := make(map[string]interface{}, len(elems))
+
+		for i, elem := range elems {
+			names[i] = elem.Name
+			args[elem.Name] = formatArg(values[elem.Name])
+		}
+
+		return &ContractEvent{
+			Type:        eventContract,
+			BlockNumber: log.BlockNumber,
+			BlockHash:   log.BlockHash,
+			TxHash:      log.TransactionHash,
+			LogIndex:    log.LogIndex,
+			Address:     log.Address,
+			Event:       event.Name,
+			Signature:   event.Sig(),
+			Args:        args,
+			argNames:    names,
+		}
+	}
+
+	return nil
+}
+
+// formatArg converts the decoded argument into the value which is readable in the JSON output:
+// numbers above 64 bits are decimal strings and byte values are hex strings
+func formatArg(arg interface{}) interface{} {
+	switch v := arg.(type) {
+	case nil:
+		return nil
+	case *big.Int:
+		return v.String()
+	case ethgo.Address:
+		return v.String()
+	case []byte:
+		return hex.EncodeToHex(v)
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for name, elem := range v {
+			res[name] = formatArg(elem)
+		}
+
+		return res
+	}
+
+	value := reflect.ValueOf(arg)
+
+	switch value.Kind() {
+	case reflect.Array, reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			buf := make([]byte, value.Len())
+			reflect.Copy(reflect.ValueOf(buf), value)
+
+			return hex.EncodeToHex(buf)
+		}
+
+		res := make([]interface{}, value.Len())
+		for i := range res {
+			res[i] = formatArg(value.Index(i).Interface())
+		}
+
+		return res
+	default:
+		return arg
+	}
+}
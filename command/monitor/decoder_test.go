@@ -0,0 +1,170 @@
+package monitor
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Ethernal-Tech/ethgo"
+	"github.com/Ethernal-Tech/ethgo/abi"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+const testTokenABI = `[
+	{"type":"event","name":"Transfer","anonymous":false,"inputs":[
+		{"name":"from","type":"address","indexed":true},
+		{"name":"to","type":"address","indexed":true},
+		{"name":"value","type":"uint256","indexed":false}
+	]},
+	{"type":"event","name":"Memo","anonymous":false,"inputs":[
+		{"name":"id","type":"bytes32","indexed":false},
+		{"name":"parts","type":"uint64[]","indexed":false}
+	]}
+]`
+
+var (
+	testToken    = ethgo.HexToAddress("0x1000000000000000000000000000000000000001")
+	testSender   = ethgo.HexToAddress("0x2000000000000000000000000000000000000002")
+	testReceiver = ethgo.HexToAddress("0x3000000000000000000000000000000000000003")
+)
+
+func newTestDecoder(t *testing.T) *eventDecoder {
+	t.Helper()
+
+	dir := t.TempDir()
+	abiPath := filepath.Join(dir, "token.abi")
+	artifactPath := filepath.Join(dir, "Token.json")
+
+	require.NoError(t, os.WriteFile(abiPath, []byte(testTokenABI), 0600))
+	require.NoError(t, os.WriteFile(artifactPath, []byte(`{"abi":`+testTokenABI+`,"bytecode":"0x"}`), 0600))
+
+	// the same events from both the plain ABI and the artifact are registered once
+	decoder, err := newEventDecoder([]string{abiPath, artifactPath})
+	require.NoError(t, err)
+	require.Len(t, decoder.events, 2)
+
+	return decoder
+}
+
+func newTransferLog(t *testing.T, blockHash ethgo.Hash, value int64) *ethgo.Log {
+	t.Helper()
+
+	event := abi.MustNewEvent("event Transfer(address indexed from, address indexed to, uint256 value)")
+
+	data, err := abi.MustNewType("uint256").Encode(big.NewInt(value))
+	require.NoError(t, err)
+
+	return &ethgo.Log{
+		BlockNumber:     5,
+		BlockHash:       blockHash,
+		TransactionHash: ethgo.HexToHash("0xab"),
+		LogIndex:        1,
+		Address:         testToken,
+		Topics: []ethgo.Hash{
+			event.ID(),
+			ethgo.BytesToHash(testSender.Bytes()),
+			ethgo.BytesToHash(testReceiver.Bytes()),
+		},
+		Data: data,
+	}
+}
+
+func TestEventDecoder_Decode(t *testing.T) {
+	t.Parallel()
+
+	decoder := newTestDecoder(t)
+	blockHash := ethgo.HexToHash("0x05")
+
+	event := decoder.decode(newTransferLog(t, blockHash, 100))
+	require.NotNil(t, event)
+	require.Equal(t, "Transfer", event.Event)
+	require.Equal(t, "Transfer(address,address,uint256)", event.Signature)
+	require.Equal(t, blockHash, event.BlockHash)
+	require.Equal(t, []string{"from", "to", "value"}, event.argNames)
+	require.Equal(t, map[string]interface{}{
+		"from":  testSender.String(),
+		"to":    testReceiver.String(),
+		"value": "100",
+	}, event.Args)
+
+	memo := abi.MustNewEvent("event Memo(bytes32 id, uint64[] parts)")
+
+	data, err := memo.Inputs.Encode(map[string]interface{}{
+		"id":    ethgo.HexToHash("0x01"),
+		"parts": []uint64{1, 2},
+	})
+	require.NoError(t, err)
+
+	event = decoder.decode(&ethgo.Log{Topics: []ethgo.Hash{memo.ID()}, Data: data})
+	require.NotNil(t, event)
+	require.Equal(t, map[string]interface{}{
+		"id":    ethgo.HexToHash("0x01").String(),
+		"parts": []interface{}{uint64(1), uint64(2)},
+	}, event.Args)
+	require.Contains(t, event.GetOutput(), "= [1,2]")
+
+	// unknown events and the logs not matching the known event layout are skipped
+	require.Nil(t, decoder.decode(&ethgo.Log{Topics: []ethgo.Hash{ethgo.HexToHash("0x01")}}))
+	require.Nil(t, decoder.decode(&ethgo.Log{}))
+
+	invalid := newTransferLog(t, blockHash, 100)
+	invalid.Topics = invalid.Topics[:1]
+	require.Nil(t, decoder.decode(invalid))
+}
+
+func TestNewEventDecoder_InvalidABI(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	noEvents := filepath.Join(dir, "noevents.json")
+	noABI := filepath.Join(dir, "noabi.json")
+
+	require.NoError(t, os.WriteFile(noEvents, []byte(`[{"type":"function","name":"foo","inputs":[]}]`), 0600))
+	require.NoError(t, os.WriteFile(noABI, []byte(`{"bytecode":"0x"}`), 0600))
+
+	_, err := newEventDecoder([]string{noEvents})
+	require.ErrorIs(t, err, errNoEvents)
+
+	_, err = newEventDecoder([]string{noABI})
+	require.ErrorContains(t, err, "contains no abi field")
+
+	_, err = newEventDecoder([]string{filepath.Join(dir, "missing.json")})
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+type mockLogsClient map[ethgo.Hash][]*ethgo.Log
+
+func (m mockLogsClient) GetLogs(filter *ethgo.LogFilter) ([]*ethgo.Log, error) {
+	return m[*filter.BlockHash], nil
+}
+
+func TestContractEventsHandler(t *testing.T) {
+	t.Parallel()
+
+	oldHash := ethgo.HexToHash("0x05")
+	newHash := ethgo.HexToHash("0x06")
+
+	client := mockLogsClient{
+		newHash: {newTransferLog(t, newHash, 7), {Topics: []ethgo.Hash{ethgo.HexToHash("0x01")}}},
+	}
+
+	handler := newContractEventsHandler(client, newTestDecoder(t), []ethgo.Address{testToken})
+
+	results, err := handler(&proto.BlockchainEvent{
+		Added:   []*proto.BlockchainEvent_Header{{Number: 5, Hash: newHash.String()}},
+		Removed: []*proto.BlockchainEvent_Header{{Number: 5, Hash: oldHash.String()}},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	// the reorg is reported before the events of the new block
+	require.Equal(t, &ReorgEvent{Type: eventReorg, BlockNumber: 5, BlockHash: oldHash.String()}, results[0])
+
+	event, ok := results[1].(*ContractEvent)
+	require.True(t, ok)
+	require.Equal(t, newHash, event.BlockHash)
+	require.Equal(t, "7", event.Args["value"])
+}
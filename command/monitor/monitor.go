@@ -6,40 +6,95 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/Ethernal-Tech/ethgo"
+
 	"github.com/0xPolygon/polygon-edge/command"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/spf13/cobra"
 
 	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 )
 
+var (
+	params monitorParams
+)
+
+// eventHandler converts the blockchain event into the results written to the output
+type eventHandler func(*proto.BlockchainEvent) ([]command.CommandResult, error)
+
 func GetCommand() *cobra.Command {
 	monitorCmd := &cobra.Command{
-		Use:   "monitor",
-		Short: "Starts logging block add / remove events on the blockchain",
-		Run:   runCommand,
+		Use: "monitor",
+		Short: "Starts logging block add / remove events on the blockchain, " +
+			"or the decoded contract events if the ABI files are provided",
+		PreRunE: runPreRun,
+		Run:     runCommand,
 	}
 
 	helper.RegisterGRPCAddressFlag(monitorCmd)
+	setFlags(monitorCmd)
 
 	return monitorCmd
 }
 
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(
+		&params.abiPaths,
+		abiFlag,
+		[]string{},
+		"the ABI files (plain ABI or compiled contract artifact) of the events "+
+			"which are decoded from the logs of the new blocks",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.addressesRaw,
+		addressFlag,
+		[]string{},
+		"the addresses of the contracts whose events are decoded (all contracts if not set)",
+	)
+
+	helper.RegisterJSONRPCFlag(cmd)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.jsonRPCAddress = helper.GetJSONRPCAddress(cmd)
+
+	return params.validateFlags()
+}
+
 func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
 
+	handler := eventHandler(newBlockEventResults)
+
+	if params.decodeEvents() {
+		client, err := jsonrpc.NewEthClient(params.jsonRPCAddress)
+		if err != nil {
+			outputter.SetError(fmt.Errorf("failed to connect to the JSON-RPC: %w", err))
+
+			return
+		}
+
+		defer client.Close()
+
+		handler = newContractEventsHandler(client, params.decoder, params.addresses)
+	}
+
 	subscribeToEvents(
 		outputter,
 		helper.GetGRPCAddress(cmd),
+		handler,
 	)
 }
 
 func subscribeToEvents(
 	outputter command.OutputFormatter,
 	grpcAddress string,
+	handler eventHandler,
 ) {
 	ctx, cancelFn := context.WithCancel(context.Background())
 	defer cancelFn()
@@ -55,9 +110,57 @@ func subscribeToEvents(
 	runSubscribeLoop(
 		stream,
 		outputter,
+		handler,
 	)
 }
 
+func newBlockEventResults(e *proto.BlockchainEvent) ([]command.CommandResult, error) {
+	return []command.CommandResult{NewBlockEventResult(e)}, nil
+}
+
+// logsClient fetches the logs of the blocks
+type logsClient interface {
+	GetLogs(filter *ethgo.LogFilter) ([]*ethgo.Log, error)
+}
+
+// newContractEventsHandler creates the handler which decodes the logs of the added blocks
+// emitted by the given contracts, and notifies about the removed blocks (reorgs)
+func newContractEventsHandler(client logsClient, decoder *eventDecoder, addresses []ethgo.Address) eventHandler {
+	return func(e *proto.BlockchainEvent) ([]command.CommandResult, error) {
+		results := make([]command.CommandResult, 0, len(e.Removed))
+
+		// the removed blocks come first, so the consumers drop
+		// their events before the events of the new fork arrive
+		for _, removed := range e.Removed {
+			results = append(results, &ReorgEvent{
+				Type:        eventReorg,
+				BlockNumber: removed.Number,
+				BlockHash:   removed.Hash,
+			})
+		}
+
+		for _, added := range e.Added {
+			blockHash := ethgo.HexToHash(added.Hash)
+
+			logs, err := client.GetLogs(&ethgo.LogFilter{
+				Address:   addresses,
+				BlockHash: &blockHash,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get logs of the block %d: %w", added.Number, err)
+			}
+
+			for _, log := range logs {
+				if event := decoder.decode(log); event != nil {
+					results = append(results, event)
+				}
+			}
+		}
+
+		return results, nil
+	}
+}
+
 func getMonitorStream(
 	ctx context.Context,
 	grpcAddress string,
@@ -73,6 +176,7 @@ func getMonitorStream(
 func runSubscribeLoop(
 	stream proto.System_SubscribeClient,
 	outputter command.OutputFormatter,
+	handler eventHandler,
 ) {
 	doneCh := make(chan struct{})
 
@@ -97,8 +201,18 @@ func runSubscribeLoop(
 				break
 			}
 
-			outputter.SetCommandResult(NewBlockEventResult(streamEvent))
-			flushOutput()
+			results, err := handler(streamEvent)
+			if err != nil {
+				outputter.SetError(err)
+				outputter.WriteOutput()
+
+				break
+			}
+
+			for _, result := range results {
+				outputter.SetCommandResult(result)
+				flushOutput()
+			}
 		}
 
 		doneCh <- struct{}{}
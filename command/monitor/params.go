@@ -0,0 +1,61 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Ethernal-Tech/ethgo"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	abiFlag     = "abi"
+	addressFlag = "address"
+)
+
+var errAddressWithoutABI = errors.New("contract addresses are monitored only together with the ABI files")
+
+type monitorParams struct {
+	abiPaths     []string
+	addressesRaw []string
+
+	addresses      []ethgo.Address
+	decoder        *eventDecoder
+	jsonRPCAddress string
+}
+
+// decodeEvents returns true if the contract events are decoded instead of logging the block events
+func (p *monitorParams) decodeEvents() bool {
+	return len(p.abiPaths) > 0
+}
+
+func (p *monitorParams) validateFlags() error {
+	if !p.decodeEvents() {
+		if len(p.addressesRaw) > 0 {
+			return errAddressWithoutABI
+		}
+
+		return nil
+	}
+
+	p.addresses = make([]ethgo.Address, len(p.addressesRaw))
+
+	for i, raw := range p.addressesRaw {
+		addr, err := types.IsValidAddress(raw, false)
+		if err != nil {
+			return fmt.Errorf("invalid contract address: %w", err)
+		}
+
+		p.addresses[i] = ethgo.Address(addr)
+	}
+
+	decoder, err := newEventDecoder(p.abiPaths)
+	if err != nil {
+		return err
+	}
+
+	p.decoder = decoder
+
+	return nil
+}
@@ -2,8 +2,11 @@ package monitor
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 
+	"github.com/Ethernal-Tech/ethgo"
+
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 )
@@ -11,6 +14,9 @@ import (
 const (
 	eventAdded   = "ADD BLOCK"
 	eventRemoved = "REMOVE BLOCK"
+
+	eventContract = "CONTRACT EVENT"
+	eventReorg    = "REORG"
 )
 
 type BlockchainEvent struct {
@@ -72,3 +78,79 @@ func (r *BlockEventResult) getCombinedEvents() []BlockchainEvent {
 
 	return append(events, r.Events.Removed...)
 }
+
+// ContractEvent is the log of the monitored contract decoded into the named event and arguments
+type ContractEvent struct {
+	Type        string                 `json:"type"`
+	BlockNumber uint64                 `json:"blockNumber"`
+	BlockHash   ethgo.Hash             `json:"blockHash"`
+	TxHash      ethgo.Hash             `json:"txHash"`
+	LogIndex    uint64                 `json:"logIndex"`
+	Address     ethgo.Address          `json:"address"`
+	Event       string                 `json:"event"`
+	Signature   string                 `json:"signature"`
+	Args        map[string]interface{} `json:"args"`
+
+	// argNames are the argument names in the order of the event declaration
+	argNames []string
+}
+
+func (e *ContractEvent) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CONTRACT EVENT]\n")
+
+	vals := make([]string, 0, 6+len(e.argNames))
+	vals = append(vals,
+		fmt.Sprintf("Event|%s", e.Signature),
+		fmt.Sprintf("Contract|%s", e.Address),
+		fmt.Sprintf("Block Number|%d", e.BlockNumber),
+		fmt.Sprintf("Block Hash|%s", e.BlockHash),
+		fmt.Sprintf("Tx Hash|%s", e.TxHash),
+		fmt.Sprintf("Log Index|%d", e.LogIndex),
+	)
+
+	for _, name := range e.argNames {
+		vals = append(vals, fmt.Sprintf("%s|%s", name, formatArgOutput(e.Args[name])))
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
+
+	return buffer.String()
+}
+
+// ReorgEvent notifies that the block was removed from the canonical chain,
+// so the contract events previously reported for it are no longer valid
+type ReorgEvent struct {
+	Type        string `json:"type"`
+	BlockNumber int64  `json:"blockNumber"`
+	BlockHash   string `json:"blockHash"`
+}
+
+func (e *ReorgEvent) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[REORG]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Removed Block Number|%d", e.BlockNumber),
+		fmt.Sprintf("Removed Block Hash|%s", e.BlockHash),
+	}))
+
+	return buffer.String()
+}
+
+// formatArgOutput formats the argument for the text output,
+// arrays and tuples are printed in the JSON format
+func formatArgOutput(arg interface{}) string {
+	switch arg.(type) {
+	case []interface{}, map[string]interface{}:
+		raw, err := json.Marshal(arg)
+		if err != nil {
+			return fmt.Sprint(arg)
+		}
+
+		return string(raw)
+	default:
+		return fmt.Sprint(arg)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/command/secrets"
 	polybftsecrets "github.com/0xPolygon/polygon-edge/command/secrets/init"
 	"github.com/0xPolygon/polygon-edge/command/server"
+	"github.com/0xPolygon/polygon-edge/command/state"
 	"github.com/0xPolygon/polygon-edge/command/status"
 	"github.com/0xPolygon/polygon-edge/command/txpool"
 	"github.com/0xPolygon/polygon-edge/command/validator"
@@ -63,6 +64,7 @@ func (rc *RootCommand) registerSubCommands() {
 		loadtest.GetCommand(),
 		sanitycheck.GetCommand(),
 		accounts.GetCommand(),
+		state.GetCommand(),
 	)
 }
 
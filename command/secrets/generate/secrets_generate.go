@@ -50,11 +50,15 @@ func setFlags(cmd *cobra.Command) {
 		typeFlag,
 		string(secrets.HashicorpVault),
 		fmt.Sprintf(
-			"the type of the secrets manager. Available types: %s, %s, %s and %s",
+			"the type of the secrets manager. Available types: %s, %s, %s, %s and %s "+
+				"(the extra fields %s and %s)",
 			secrets.HashicorpVault,
 			secrets.AWSSSM,
 			secrets.GCPSSM,
 			secrets.AlibabaSSM,
+			secrets.EncryptedLocal,
+			secrets.Path,
+			secrets.PassphraseFile,
 		),
 	)
 
@@ -17,8 +17,10 @@ const (
 	accountFlag            = "account"
 	privateKeyFlag         = "private"
 	insecureLocalStoreFlag = "insecure"
+	passphraseFileFlag     = "passphrase-file"
 	networkFlag            = "network"
 	jsonTLSCertFlag        = "json-tls-cert"
+	jsonRPCAdminTokenFlag  = "json-rpc-admin-token"
 	numFlag                = "num"
 	outputFlag             = "output"
 
@@ -33,12 +35,14 @@ type initParams struct {
 	generatesAccount     bool
 	generatesNetwork     bool
 	generatesJSONTLSCert bool
+	generatesAdminToken  bool
 
 	printPrivateKey bool
 
 	numberOfSecrets int
 
 	insecureLocalStore bool
+	passphraseFile     string
 
 	output bool
 }
@@ -105,6 +109,13 @@ func (ip *initParams) setFlags(cmd *cobra.Command) {
 		"the flag indicating whether a new self signed TLS certificate is created for JSON RPC",
 	)
 
+	cmd.Flags().BoolVar(
+		&ip.generatesAdminToken,
+		jsonRPCAdminTokenFlag,
+		false,
+		"the flag indicating whether a new token authorizing the JSON RPC admin namespace calls is created",
+	)
+
 	cmd.Flags().BoolVar(
 		&ip.printPrivateKey,
 		privateKeyFlag,
@@ -116,7 +127,16 @@ func (ip *initParams) setFlags(cmd *cobra.Command) {
 		&ip.insecureLocalStore,
 		insecureLocalStoreFlag,
 		false,
-		"the flag indicating should the secrets stored on the local storage be encrypted",
+		"the flag indicating whether the secrets are stored on the local storage in plaintext, "+
+			"otherwise they are encrypted with the passphrase",
+	)
+
+	cmd.Flags().StringVar(
+		&ip.passphraseFile,
+		passphraseFileFlag,
+		"",
+		fmt.Sprintf("the path to the file with the passphrase of the encrypted local secrets, "+
+			"if omitted, the %s environment variable or the prompt is used", secrets.PassphraseEnv),
 	)
 
 	cmd.Flags().BoolVar(
@@ -141,7 +161,7 @@ func (ip *initParams) Execute() (Results, error) {
 			configDir = fmt.Sprintf("%s%d", ip.accountConfig, i+1)
 		}
 
-		secretManager, err := GetSecretsManager(dataDir, configDir, ip.insecureLocalStore)
+		secretManager, err := GetSecretsManagerWithPassphrase(dataDir, configDir, ip.passphraseFile, ip.insecureLocalStore)
 		if err != nil {
 			return results, err
 		}
@@ -186,6 +206,12 @@ func (ip *initParams) initKeys(secretsManager secrets.SecretsManager) ([]string,
 		}
 	}
 
+	if ip.generatesAdminToken {
+		if err := ip.generateAdminToken(secretsManager, &generated); err != nil {
+			return generated, err
+		}
+	}
+
 	return generated, nil
 }
 
@@ -236,6 +262,20 @@ func (ip *initParams) generateJSONTLSCert(secretsManager secrets.SecretsManager,
 	return nil
 }
 
+func (ip *initParams) generateAdminToken(secretsManager secrets.SecretsManager, generated *[]string) error {
+	if secretsManager.HasSecret(secrets.JSONRPCAdminToken) {
+		return nil
+	}
+
+	if _, err := helper.InitJSONRPCAdminToken(secretsManager); err != nil {
+		return fmt.Errorf("error initializing json rpc admin token: %w", err)
+	}
+
+	*generated = append(*generated, secrets.JSONRPCAdminToken)
+
+	return nil
+}
+
 // getResult gets keys from secret manager and return result to display
 func (ip *initParams) getResult(
 	secretsManager secrets.SecretsManager,
@@ -280,6 +320,15 @@ func (ip *initParams) getResult(
 		}
 	}
 
+	if ip.generatesAdminToken && ip.printPrivateKey {
+		token, err := secretsManager.GetSecret(secrets.JSONRPCAdminToken)
+		if err != nil {
+			return nil, err
+		}
+
+		res.AdminToken = string(token)
+	}
+
 	res.Insecure = ip.insecureLocalStore
 
 	return res, nil
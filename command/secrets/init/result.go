@@ -28,6 +28,7 @@ type SecretsInitResult struct {
 	NodeID        string        `json:"node_id"`
 	PrivateKey    string        `json:"private_key"`
 	BLSPrivateKey string        `json:"bls_private_key"`
+	AdminToken    string        `json:"admin_token,omitempty"`
 	Insecure      bool          `json:"insecure"`
 	Generated     string        `json:"generated"`
 }
@@ -65,6 +66,13 @@ func (r *SecretsInitResult) GetOutput() string {
 
 	vals = append(vals, fmt.Sprintf("Node ID|%s", r.NodeID))
 
+	if r.AdminToken != "" {
+		vals = append(
+			vals,
+			fmt.Sprintf("JSON RPC admin token|%s", r.AdminToken),
+		)
+	}
+
 	if r.Insecure {
 		buffer.WriteString("\n[WARNING: INSECURE LOCAL SECRETS - SHOULD NOT BE RUN IN PRODUCTION]\n")
 	}
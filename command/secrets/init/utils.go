@@ -6,6 +6,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/secrets/helper"
+	"github.com/0xPolygon/polygon-edge/secrets/local"
 )
 
 // common flags for all polybft commands
@@ -27,18 +28,25 @@ const (
 
 // common errors for all polybft commands
 var (
-	ErrInvalidNum                     = fmt.Errorf("num flag value should be between 1 and %d", maxInitNum)
-	ErrInvalidParams                  = errors.New("no config file or data directory passed in")
-	ErrUnsupportedType                = errors.New("unsupported secrets manager")
-	ErrSecureLocalStoreNotImplemented = errors.New(
-		"use a secrets backend, or supply an --insecure flag " +
-			"to store the private keys locally on the filesystem, " +
-			"avoid doing so in production")
+	ErrInvalidNum          = fmt.Errorf("num flag value should be between 1 and %d", maxInitNum)
+	ErrInvalidParams       = errors.New("no config file or data directory passed in")
+	ErrUnsupportedType     = errors.New("unsupported secrets manager")
+	ErrPlaintextLocalStore = errors.New(
+		"the local secrets are not encrypted, run the secrets migrate command to encrypt them " +
+			"or supply an --insecure flag to keep them in plaintext, avoid doing so in production")
 )
 
 // GetSecretsManager function resolves secrets manager instance based on provided data or config paths.
-// insecureLocalStore defines if utilization of local secrets manager is allowed.
+// insecureLocalStore defines if utilization of plaintext local secrets manager is allowed,
+// the encrypted local secrets are decrypted with the passphrase from the environment or the terminal prompt.
 func GetSecretsManager(dataPath, configPath string, insecureLocalStore bool) (secrets.SecretsManager, error) {
+	return GetSecretsManagerWithPassphrase(dataPath, configPath, "", insecureLocalStore)
+}
+
+// GetSecretsManagerWithPassphrase resolves secrets manager instance the same way as GetSecretsManager,
+// the passphrase of the encrypted local secrets is read from the given file if it is specified.
+func GetSecretsManagerWithPassphrase(
+	dataPath, configPath, passphraseFile string, insecureLocalStore bool) (secrets.SecretsManager, error) {
 	if configPath != "" {
 		secretsConfig, readErr := secrets.ReadConfig(configPath)
 		if readErr != nil {
@@ -52,11 +60,20 @@ func GetSecretsManager(dataPath, configPath string, insecureLocalStore bool) (se
 		return helper.InitCloudSecretsManager(secretsConfig)
 	}
 
-	// Storing secrets on a local file system should only be allowed with --insecure flag,
+	// Encrypted local secrets are always decrypted, no matter whether plaintext secrets are allowed
+	if local.IsEncryptedStore(dataPath) {
+		return helper.SetupEncryptedLocalSecretsManager(dataPath, passphraseFile, false)
+	}
+
+	// Storing plaintext secrets on a local file system should only be allowed with --insecure flag,
 	// to raise awareness that it should be only used in development/testing environments.
-	// Production setups should use one of the supported secrets managers
+	// Otherwise the local secrets are encrypted with the passphrase
 	if !insecureLocalStore {
-		return nil, ErrSecureLocalStoreNotImplemented
+		if local.IsPlaintextStore(dataPath) {
+			return nil, ErrPlaintextLocalStore
+		}
+
+		return helper.SetupEncryptedLocalSecretsManager(dataPath, passphraseFile, true)
 	}
 
 	return helper.SetupLocalSecretsManager(dataPath)
@@ -0,0 +1,75 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/secrets/helper"
+	"github.com/0xPolygon/polygon-edge/secrets/local"
+)
+
+const (
+	dataDirFlag        = "data-dir"
+	passphraseFileFlag = "passphrase-file"
+)
+
+var (
+	params = &migrateParams{}
+)
+
+var (
+	errNoSecretsFound = errors.New("no local secrets found in the data directory")
+)
+
+type migrateParams struct {
+	dataDir        string
+	passphraseFile string
+
+	encrypted []string
+}
+
+func (mp *migrateParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+func (mp *migrateParams) validateFlags() error {
+	if !common.DirectoryExists(mp.dataDir) {
+		return fmt.Errorf("the data directory provided does not exist: %s", mp.dataDir)
+	}
+
+	if !local.IsPlaintextStore(mp.dataDir) && !local.IsEncryptedStore(mp.dataDir) {
+		return errNoSecretsFound
+	}
+
+	return nil
+}
+
+func (mp *migrateParams) migrateSecrets() error {
+	// the new passphrase is confirmed, unless the secrets are already (partially) encrypted
+	secretsManager, err := helper.SetupEncryptedLocalSecretsManager(
+		mp.dataDir, mp.passphraseFile, !local.IsEncryptedStore(mp.dataDir))
+	if err != nil {
+		return err
+	}
+
+	encryptedManager, ok := secretsManager.(*local.EncryptedLocalSecretsManager)
+	if !ok {
+		return errors.New("invalid type assertion")
+	}
+
+	mp.encrypted, err = encryptedManager.EncryptExisting()
+
+	return err
+}
+
+func (mp *migrateParams) getResult() command.CommandResult {
+	return &SecretsMigrateResult{
+		DataDir:   mp.dataDir,
+		Encrypted: strings.Join(mp.encrypted, ", "),
+	}
+}
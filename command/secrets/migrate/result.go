@@ -0,0 +1,31 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type SecretsMigrateResult struct {
+	DataDir   string `json:"data_dir"`
+	Encrypted string `json:"encrypted"`
+}
+
+func (r *SecretsMigrateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	encrypted := r.Encrypted
+	if encrypted == "" {
+		encrypted = "none, the secrets are already encrypted"
+	}
+
+	buffer.WriteString("\n[SECRETS MIGRATE]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Data Directory|%s", r.DataDir),
+		fmt.Sprintf("Encrypted Secrets|%s", encrypted),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,60 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/secrets"
+)
+
+func GetCommand() *cobra.Command {
+	secretsMigrateCmd := &cobra.Command{
+		Use: "migrate",
+		Short: "Encrypts the plaintext local secrets in the provided data directory with the passphrase. " +
+			"The node must be stopped during the migration",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(secretsMigrateCmd)
+	helper.SetRequiredFlags(secretsMigrateCmd, params.getRequiredFlags())
+
+	return secretsMigrateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the directory for the Polygon Edge data with the plaintext local secrets",
+	)
+
+	cmd.Flags().StringVar(
+		&params.passphraseFile,
+		passphraseFileFlag,
+		"",
+		fmt.Sprintf("the path to the file with the passphrase the secrets are encrypted with, "+
+			"if omitted, the %s environment variable or the prompt is used", secrets.PassphraseEnv),
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.migrateSecrets(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -19,6 +19,8 @@ const (
 	validatorFlag = "validator"
 	blsFlag       = "bls"
 	nodeIDFlag    = "node-id"
+
+	passphraseFileFlag = "passphrase-file"
 )
 
 var (
@@ -32,8 +34,9 @@ var (
 )
 
 type outputParams struct {
-	dataDir    string
-	configPath string
+	dataDir        string
+	configPath     string
+	passphraseFile string
 
 	outputNodeID    bool
 	outputValidator bool
@@ -139,7 +142,7 @@ func (op *outputParams) initLocalSecretsManager() error {
 		return fmt.Errorf(strings.Join(errs, "\n"))
 	}
 
-	local, err := helper.SetupLocalSecretsManager(op.dataDir)
+	local, err := helper.OpenLocalSecretsManager(op.dataDir, op.passphraseFile)
 	if err != nil {
 		return err
 	}
@@ -1,7 +1,10 @@
 package output
 
 import (
+	"fmt"
+
 	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/spf13/cobra"
 )
 
@@ -38,6 +41,14 @@ func setFlags(cmd *cobra.Command) {
 			"if omitted, the local FS secrets manager is used",
 	)
 
+	cmd.Flags().StringVar(
+		&params.passphraseFile,
+		passphraseFileFlag,
+		"",
+		fmt.Sprintf("the path to the file with the passphrase of the encrypted local secrets, "+
+			"if omitted, the %s environment variable or the prompt is used", secrets.PassphraseEnv),
+	)
+
 	cmd.Flags().BoolVar(
 		&params.outputBLS,
 		blsFlag,
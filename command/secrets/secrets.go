@@ -4,6 +4,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/command/secrets/generate"
 	initCmd "github.com/0xPolygon/polygon-edge/command/secrets/init"
+	"github.com/0xPolygon/polygon-edge/command/secrets/migrate"
 	"github.com/0xPolygon/polygon-edge/command/secrets/output"
 	"github.com/spf13/cobra"
 )
@@ -29,5 +30,7 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		generate.GetCommand(),
 		// secrets output public data
 		output.GetCommand(),
+		// secrets migrate plaintext local secrets to the encrypted ones
+		migrate.GetCommand(),
 	)
 }
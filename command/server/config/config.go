@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/network"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/hashicorp/hcl"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"gopkg.in/yaml.v3"
@@ -39,9 +40,21 @@ type Config struct {
 
 	Relayer bool `json:"relayer" yaml:"relayer"`
 
+	SnapSync bool `json:"snap_sync" yaml:"snap_sync"`
+
+	StatePruningRetention uint64 `json:"state_pruning_retention" yaml:"state_pruning_retention"`
+	StateStorageBackend   string `json:"state_storage_backend" yaml:"state_storage_backend"`
+
+	RemoteSignerURL       string `json:"remote_signer_url" yaml:"remote_signer_url"`
+	RemoteSignerTokenFile string `json:"remote_signer_token_file" yaml:"remote_signer_token_file"`
+
 	ConcurrentRequestsDebug uint64 `json:"concurrent_requests_debug" yaml:"concurrent_requests_debug"`
 	WebSocketReadLimit      uint64 `json:"web_socket_read_limit" yaml:"web_socket_read_limit"`
 
+	JSONRPCAdmin bool `json:"json_rpc_admin" yaml:"json_rpc_admin"`
+
+	JSONRPCAccess *JSONRPCAccess `json:"json_rpc_access" yaml:"json_rpc_access"`
+
 	MetricsInterval time.Duration `json:"metrics_interval" yaml:"metrics_interval"`
 
 	EventTracker *EventTracker `json:"event_tracker" yaml:"event_tracker"`
@@ -69,6 +82,11 @@ type TxPool struct {
 	PriceLimit         uint64 `json:"price_limit" yaml:"price_limit"`
 	MaxSlots           uint64 `json:"max_slots" yaml:"max_slots"`
 	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+
+	Journal         bool          `json:"journal" yaml:"journal"`
+	JournalRotation time.Duration `json:"journal_rotation" yaml:"journal_rotation"`
+
+	Lifetime time.Duration `json:"lifetime" yaml:"lifetime"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -76,6 +94,23 @@ type Headers struct {
 	AccessControlAllowOrigins []string `json:"access_control_allow_origins" yaml:"access_control_allow_origins"`
 }
 
+// JSONRPCAccess defines the JSON-RPC rate limits and the lists of the allowed and denied namespaces or methods
+type JSONRPCAccess struct {
+	APIKeyHeader     string                `json:"api_key_header" yaml:"api_key_header"`
+	APIKeys          []string              `json:"api_keys" yaml:"api_keys"`
+	ClientRateLimit  float64               `json:"client_rate_limit" yaml:"client_rate_limit"`
+	ClientBurst      int                   `json:"client_burst" yaml:"client_burst"`
+	MethodRateLimits map[string]*RateLimit `json:"method_rate_limits" yaml:"method_rate_limits"`
+	Allow            []string              `json:"allow" yaml:"allow"`
+	Deny             []string              `json:"deny" yaml:"deny"`
+}
+
+// RateLimit defines the token bucket refilled with the rate of tokens per second and holding up to burst tokens
+type RateLimit struct {
+	Rate  float64 `json:"rate" yaml:"rate"`
+	Burst int     `json:"burst" yaml:"burst"`
+}
+
 // EventTracker defines configuration parameters for EventTracker
 type EventTracker struct {
 	SyncBatchSize          uint64 `json:"sync_batch_size" yaml:"sync_batch_size"`
@@ -99,6 +134,16 @@ const (
 	// the connection sends a close message to the peer and returns ErrReadLimit to the application.
 	DefaultWebSocketReadLimit uint64 = 8192
 
+	// DefaultJSONRPCAPIKeyHeader is the HTTP header with the API key identifying the client by the JSON-RPC rate limits
+	DefaultJSONRPCAPIKeyHeader = "X-API-Key"
+
+	// DefaultTxPoolJournalRotation specifies the time interval at which the local transactions journal is regenerated
+	DefaultTxPoolJournalRotation time.Duration = time.Hour
+
+	// DefaultTxPoolLifetime specifies the maximum time the enqueued transactions
+	// of an inactive non-local account are kept in the pool
+	DefaultTxPoolLifetime time.Duration = 3 * time.Hour
+
 	// DefaultMetricsInterval specifies the time interval after which Prometheus metrics will be generated.
 	// A value of 0 means the metrics are disabled.
 	DefaultMetricsInterval time.Duration = time.Second * 8
@@ -143,6 +188,9 @@ func DefaultConfig() *Config {
 			PriceLimit:         0,
 			MaxSlots:           4096,
 			MaxAccountEnqueued: 128,
+			Journal:            false,
+			JournalRotation:    DefaultTxPoolJournalRotation,
+			Lifetime:           DefaultTxPoolLifetime,
 		},
 		LogLevel:    "INFO",
 		RestoreFile: "",
@@ -156,9 +204,15 @@ func DefaultConfig() *Config {
 		JSONRPCBatchRequestLimit: DefaultJSONRPCBatchRequestLimit,
 		JSONRPCBlockRangeLimit:   DefaultJSONRPCBlockRangeLimit,
 		Relayer:                  false,
+		SnapSync:                 false,
+		StatePruningRetention:    0,
+		StateStorageBackend:      itrie.LevelDBBackend,
 		ConcurrentRequestsDebug:  DefaultConcurrentRequestsDebug,
 		WebSocketReadLimit:       DefaultWebSocketReadLimit,
 		MetricsInterval:          DefaultMetricsInterval,
+		JSONRPCAccess: &JSONRPCAccess{
+			APIKeyHeader: DefaultJSONRPCAPIKeyHeader,
+		},
 		EventTracker: &EventTracker{
 			SyncBatchSize:          DefaultSyncBatchSize,
 			NumBlockConfirmations:  DefaultNumBlockConfirmations,
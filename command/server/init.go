@@ -20,6 +20,7 @@ import (
 
 var (
 	errDataDirectoryUndefined = errors.New("data directory not defined")
+	errInvalidRateLimit       = errors.New("json-rpc rate limit and burst must not be negative")
 )
 
 func (p *serverParams) initConfigFromFile() error {
@@ -32,6 +33,26 @@ func (p *serverParams) initConfigFromFile() error {
 	return nil
 }
 
+// loadConfig reads the config file the server was started with again
+// and generates the server configuration from it, the same way as on the startup
+func (p *serverParams) loadConfig() (*server.Config, error) {
+	reloaded := &serverParams{
+		configPath:  p.configPath,
+		devInterval: p.devInterval,
+		isDevMode:   p.isDevMode,
+	}
+
+	if err := reloaded.initConfigFromFile(); err != nil {
+		return nil, err
+	}
+
+	if err := reloaded.initRawParams(); err != nil {
+		return nil, err
+	}
+
+	return reloaded.generateConfig(), nil
+}
+
 func (p *serverParams) initRawParams() error {
 	if err := p.initBlockGasTarget(); err != nil {
 		return err
@@ -53,6 +74,10 @@ func (p *serverParams) initRawParams() error {
 		p.initDevMode()
 	}
 
+	if err := p.initJSONRPCAccess(); err != nil {
+		return err
+	}
+
 	p.initPeerLimits()
 	p.initLogFileLocation()
 
@@ -61,6 +86,26 @@ func (p *serverParams) initRawParams() error {
 	return p.initAddresses()
 }
 
+func (p *serverParams) initJSONRPCAccess() error {
+	if p.rawConfig.JSONRPCAccess == nil {
+		p.rawConfig.JSONRPCAccess = config.DefaultConfig().JSONRPCAccess
+	}
+
+	access := p.rawConfig.JSONRPCAccess
+
+	if access.ClientRateLimit < 0 || access.ClientBurst < 0 {
+		return errInvalidRateLimit
+	}
+
+	for method, limit := range access.MethodRateLimits {
+		if limit == nil || limit.Rate < 0 || limit.Burst < 0 {
+			return fmt.Errorf("%w: %s", errInvalidRateLimit, method)
+		}
+	}
+
+	return nil
+}
+
 func (p *serverParams) initDataDirLocation() error {
 	if p.rawConfig.DataDir == "" {
 		return errDataDirectoryUndefined
@@ -6,6 +6,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
@@ -30,6 +31,9 @@ const (
 	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
 	maxSlotsFlag                 = "max-slots"
 	maxEnqueuedFlag              = "max-enqueued"
+	txPoolJournalFlag            = "txpool-journal"
+	txPoolJournalRotationFlag    = "txpool-journal-rotation"
+	txPoolLifetimeFlag           = "txpool-lifetime"
 	blockGasTargetFlag           = "block-gas-target"
 	secretsConfigFlag            = "secrets-config"
 	restoreFlag                  = "restore"
@@ -44,9 +48,25 @@ const (
 
 	relayerFlag = "relayer"
 
+	snapSyncFlag = "snap-sync"
+
+	statePruningRetentionFlag = "state-pruning-retention"
+	stateStorageBackendFlag   = "state-storage-backend"
+
+	remoteSignerURLFlag       = "remote-signer-url"
+	remoteSignerTokenFileFlag = "remote-signer-token-file"
+
 	concurrentRequestsDebugFlag = "concurrent-requests-debug"
 	webSocketReadLimitFlag      = "websocket-read-limit"
 
+	jsonRPCAdminFlag = "json-rpc-admin"
+
+	jsonRPCAPIKeyHeaderFlag = "json-rpc-api-key-header"
+	jsonRPCRateLimitFlag    = "json-rpc-rate-limit"
+	jsonRPCRateBurstFlag    = "json-rpc-rate-burst"
+	jsonRPCAllowFlag        = "json-rpc-allow"
+	jsonRPCDenyFlag         = "json-rpc-deny"
+
 	metricsIntervalFlag = "metrics-interval"
 
 	// event tracker
@@ -62,10 +82,11 @@ const (
 var (
 	params = &serverParams{
 		rawConfig: &config.Config{
-			Telemetry:    &config.Telemetry{},
-			Network:      &config.Network{},
-			TxPool:       &config.TxPool{},
-			EventTracker: &config.EventTracker{},
+			Telemetry:     &config.Telemetry{},
+			Network:       &config.Network{},
+			TxPool:        &config.TxPool{},
+			EventTracker:  &config.EventTracker{},
+			JSONRPCAccess: &config.JSONRPCAccess{},
 		},
 	}
 )
@@ -150,6 +171,19 @@ func (p *serverParams) setJSONLogFormat(jsonLogFormat bool) {
 	p.rawConfig.JSONLogFormat = jsonLogFormat
 }
 
+func (p *serverParams) getMethodRateLimits() map[string]*jsonrpc.RateLimit {
+	limits := make(map[string]*jsonrpc.RateLimit, len(p.rawConfig.JSONRPCAccess.MethodRateLimits))
+
+	for method, limit := range p.rawConfig.JSONRPCAccess.MethodRateLimits {
+		limits[method] = &jsonrpc.RateLimit{
+			Rate:  limit.Rate,
+			Burst: limit.Burst,
+		}
+	}
+
+	return limits
+}
+
 func (p *serverParams) generateConfig() *server.Config {
 	return &server.Config{
 		Chain: p.genesisConfig,
@@ -160,6 +194,16 @@ func (p *serverParams) generateConfig() *server.Config {
 			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
 			ConcurrentRequestsDebug:  p.rawConfig.ConcurrentRequestsDebug,
 			WebSocketReadLimit:       p.rawConfig.WebSocketReadLimit,
+			AdminEnabled:             p.rawConfig.JSONRPCAdmin,
+			APIKeyHeader:             p.rawConfig.JSONRPCAccess.APIKeyHeader,
+			APIKeys:                  p.rawConfig.JSONRPCAccess.APIKeys,
+			ClientRateLimit: &jsonrpc.RateLimit{
+				Rate:  p.rawConfig.JSONRPCAccess.ClientRateLimit,
+				Burst: p.rawConfig.JSONRPCAccess.ClientBurst,
+			},
+			MethodRateLimits: p.getMethodRateLimits(),
+			AllowedMethods:   p.rawConfig.JSONRPCAccess.Allow,
+			DeniedMethods:    p.rawConfig.JSONRPCAccess.Deny,
 		},
 		GRPCAddr:   p.grpcAddress,
 		LibP2PAddr: p.libp2pAddress,
@@ -192,8 +236,17 @@ func (p *serverParams) generateConfig() *server.Config {
 		TLSCertFile:        p.rawConfig.TLSCertFile,
 		TLSKeyFile:         p.rawConfig.TLSKeyFile,
 
-		Relayer:         p.relayer,
-		MetricsInterval: p.rawConfig.MetricsInterval,
+		TxPoolJournal:         p.rawConfig.TxPool.Journal,
+		TxPoolJournalRotation: p.rawConfig.TxPool.JournalRotation,
+		TxPoolLifetime:        p.rawConfig.TxPool.Lifetime,
+
+		Relayer:               p.relayer,
+		SnapSync:              p.rawConfig.SnapSync,
+		StatePruningRetention: p.rawConfig.StatePruningRetention,
+		StateStorageBackend:   p.rawConfig.StateStorageBackend,
+		RemoteSignerURL:       p.rawConfig.RemoteSignerURL,
+		RemoteSignerTokenFile: p.rawConfig.RemoteSignerTokenFile,
+		MetricsInterval:       p.rawConfig.MetricsInterval,
 		EventTracker: &server.EventTracker{
 			SyncBatchSize:          p.rawConfig.EventTracker.SyncBatchSize,
 			NumBlockConfirmations:  p.rawConfig.EventTracker.NumBlockConfirmations,
@@ -0,0 +1,48 @@
+package reload
+
+import (
+	"context"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/spf13/cobra"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	reloadCmd := &cobra.Command{
+		Use: "reload",
+		Short: "Makes the running client re-read its config file and apply the settings which can be changed " +
+			"without the restart. The same as sending SIGHUP to the client process",
+		Run: runCommand,
+	}
+
+	return reloadCmd
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	response, err := reloadConfig(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&ReloadResult{
+		Applied:         response.Applied,
+		RestartRequired: response.RestartRequired,
+	})
+}
+
+func reloadConfig(grpcAddress string) (*proto.ReloadConfigResponse, error) {
+	client, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.ReloadConfig(context.Background(), &empty.Empty{})
+}
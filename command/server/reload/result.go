@@ -0,0 +1,42 @@
+package reload
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type ReloadResult struct {
+	Applied         []string `json:"applied"`
+	RestartRequired []string `json:"restart_required"`
+}
+
+func (r *ReloadResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CONFIG RELOAD]\n")
+
+	if len(r.Applied) == 0 && len(r.RestartRequired) == 0 {
+		buffer.WriteString("No changed settings found\n")
+
+		return buffer.String()
+	}
+
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Applied|%s", formatSettings(r.Applied)),
+		fmt.Sprintf("Restart required|%s", formatSettings(r.RestartRequired)),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+func formatSettings(settings []string) string {
+	if len(settings) == 0 {
+		return "-"
+	}
+
+	return strings.Join(settings, ", ")
+}
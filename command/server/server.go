@@ -7,7 +7,10 @@ import (
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
 	"github.com/0xPolygon/polygon-edge/command/server/export"
+	"github.com/0xPolygon/polygon-edge/command/server/reload"
+	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/spf13/cobra"
 )
@@ -34,6 +37,8 @@ func registerSubcommands(baseCmd *cobra.Command) {
 	baseCmd.AddCommand(
 		// server export
 		export.GetCommand(),
+		// server reload
+		reload.GetCommand(),
 	)
 }
 
@@ -193,6 +198,28 @@ func setFlags(cmd *cobra.Command) {
 		"maximum number of enqueued transactions per account",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.TxPool.Journal,
+		txPoolJournalFlag,
+		defaultConfig.TxPool.Journal,
+		"persist the locally submitted transactions to disk, so they survive node restarts",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.rawConfig.TxPool.JournalRotation,
+		txPoolJournalRotationFlag,
+		defaultConfig.TxPool.JournalRotation,
+		"the time interval at which the local transactions journal is regenerated",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.rawConfig.TxPool.Lifetime,
+		txPoolLifetimeFlag,
+		defaultConfig.TxPool.Lifetime,
+		"the maximum time the enqueued transactions of an inactive non-local account are kept in the pool "+
+			"(0 disables the eviction)",
+	)
+
 	cmd.Flags().StringArrayVar(
 		&params.rawConfig.CorsAllowedOrigins,
 		corsOriginFlag,
@@ -250,6 +277,41 @@ func setFlags(cmd *cobra.Command) {
 		"start the state sync relayer service (PolyBFT only)",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.SnapSync,
+		snapSyncFlag,
+		defaultConfig.SnapSync,
+		"download the state at a recent block from the peers instead of executing the whole chain (PolyBFT only)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.StatePruningRetention,
+		statePruningRetentionFlag,
+		defaultConfig.StatePruningRetention,
+		"the number of the latest blocks whose state is kept, the older state is pruned (0 disables the pruning)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.StateStorageBackend,
+		stateStorageBackendFlag,
+		defaultConfig.StateStorageBackend,
+		fmt.Sprintf("the database backend of the state storage (%s or %s)", itrie.LevelDBBackend, itrie.MdbxBackend),
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.RemoteSignerURL,
+		remoteSignerURLFlag,
+		defaultConfig.RemoteSignerURL,
+		"the url of the remote signer holding the validator keys, the local keys are used if not set (PolyBFT only)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.RemoteSignerTokenFile,
+		remoteSignerTokenFileFlag,
+		defaultConfig.RemoteSignerTokenFile,
+		"path to the file with the bearer token sent to the remote signer",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.ConcurrentRequestsDebug,
 		concurrentRequestsDebugFlag,
@@ -264,6 +326,52 @@ func setFlags(cmd *cobra.Command) {
 		"maximum size in bytes for a message read from the peer by websocket",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.JSONRPCAdmin,
+		jsonRPCAdminFlag,
+		false,
+		fmt.Sprintf("enables the JSON-RPC admin namespace, the calls are authorized by the %s secret",
+			secrets.JSONRPCAdminToken),
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCAccess.APIKeyHeader,
+		jsonRPCAPIKeyHeaderFlag,
+		defaultConfig.JSONRPCAccess.APIKeyHeader,
+		"the HTTP header with the API key identifying the client by the json-rpc rate limits, "+
+			"the clients without a known API key are identified by the IP address",
+	)
+
+	cmd.Flags().Float64Var(
+		&params.rawConfig.JSONRPCAccess.ClientRateLimit,
+		jsonRPCRateLimitFlag,
+		defaultConfig.JSONRPCAccess.ClientRateLimit,
+		"max number of json-rpc requests per second of a single client, value of 0 disables it",
+	)
+
+	cmd.Flags().IntVar(
+		&params.rawConfig.JSONRPCAccess.ClientBurst,
+		jsonRPCRateBurstFlag,
+		defaultConfig.JSONRPCAccess.ClientBurst,
+		"max number of json-rpc requests of a single client handled at once, "+
+			"value of 0 sets it to the rate limit",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCAccess.Allow,
+		jsonRPCAllowFlag,
+		defaultConfig.JSONRPCAccess.Allow,
+		"the json-rpc namespaces (e.g. eth) or methods (e.g. debug_traceTransaction) allowed to be called, "+
+			"all of them are allowed if not set",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCAccess.Deny,
+		jsonRPCDenyFlag,
+		defaultConfig.JSONRPCAccess.Deny,
+		"the json-rpc namespaces (e.g. debug) or methods (e.g. eth_sendTransaction) denied to be called",
+	)
+
 	cmd.Flags().DurationVar(
 		&params.rawConfig.MetricsInterval,
 		metricsIntervalFlag,
@@ -372,5 +480,17 @@ func runServerLoop(
 		return err
 	}
 
-	return helper.HandleSignals(serverInstance.Close, outputter)
+	var reloadFn func()
+
+	// the configuration can be reloaded only from the config file
+	if params.configPath != "" {
+		serverInstance.SetConfigLoader(params.loadConfig)
+
+		reloadFn = func() {
+			// the outcome is logged by the server
+			_, _ = serverInstance.ReloadConfig()
+		}
+	}
+
+	return helper.HandleSignals(serverInstance.Close, reloadFn, outputter)
 }
@@ -0,0 +1,65 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	migrateCmd := &cobra.Command{
+		Use: "migrate",
+		Short: "Copies the state storage to the database with another backend. " +
+			"The node must be stopped",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(migrateCmd)
+	helper.SetRequiredFlags(migrateCmd, params.getRequiredFlags())
+
+	return migrateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.from,
+		fromFlag,
+		itrie.LevelDBBackend,
+		"the database backend of the existing state storage",
+	)
+
+	cmd.Flags().StringVar(
+		&params.to,
+		toFlag,
+		"",
+		fmt.Sprintf("the database backend of the new state storage (%s or %s)", itrie.LevelDBBackend, itrie.MdbxBackend),
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.migrate(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,110 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	fromFlag    = "from"
+	toFlag      = "to"
+
+	// copyBatchSize is the number of the keys written to the new storage in one batch
+	copyBatchSize = 10000
+)
+
+var (
+	params = &migrateParams{}
+)
+
+var (
+	errSameBackend      = errors.New(`"from" and "to" backends must differ`)
+	errNotIterableStore = errors.New("the existing state storage doesn't support the iteration")
+)
+
+type migrateParams struct {
+	dataDir string
+	from    string
+	to      string
+
+	fromPath string
+	toPath   string
+	copied   int
+	duration time.Duration
+}
+
+func (p *migrateParams) validateFlags() error {
+	if p.from == p.to {
+		return errSameBackend
+	}
+
+	p.fromPath = filepath.Join(p.dataDir, itrie.StorageDir(p.from))
+	p.toPath = filepath.Join(p.dataDir, itrie.StorageDir(p.to))
+
+	if _, err := os.Stat(p.fromPath); err != nil {
+		return fmt.Errorf("the existing state storage not found: %w", err)
+	}
+
+	if _, err := os.Stat(p.toPath); err == nil {
+		return fmt.Errorf("the new state storage %s already exists", p.toPath)
+	}
+
+	return nil
+}
+
+func (p *migrateParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		toFlag,
+	}
+}
+
+func (p *migrateParams) migrate() error {
+	logger := hclog.NewNullLogger()
+
+	source, err := itrie.NewStorage(p.from, p.fromPath, logger)
+	if err != nil {
+		return fmt.Errorf("failed to open the existing state storage: %w", err)
+	}
+
+	defer source.Close()
+
+	iterable, ok := source.(itrie.IterableStorage)
+	if !ok {
+		return errNotIterableStore
+	}
+
+	target, err := itrie.NewStorage(p.to, p.toPath, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create the new state storage: %w", err)
+	}
+
+	defer target.Close()
+
+	start := time.Now()
+
+	if p.copied, err = itrie.CopyStorage(iterable, target, copyBatchSize); err != nil {
+		return fmt.Errorf("failed to copy the state storage: %w", err)
+	}
+
+	p.duration = time.Since(start)
+
+	return nil
+}
+
+func (p *migrateParams) getResult() command.CommandResult {
+	return &MigrateResult{
+		From:     p.fromPath,
+		To:       p.toPath,
+		Keys:     p.copied,
+		Duration: p.duration.String(),
+	}
+}
@@ -0,0 +1,31 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type MigrateResult struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Keys     int    `json:"keys"`
+	Duration string `json:"duration"`
+}
+
+func (r *MigrateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[STATE MIGRATE]\n")
+	buffer.WriteString("Migrated the state storage successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("From|%s", r.From),
+		fmt.Sprintf("To|%s", r.To),
+		fmt.Sprintf("Copied keys|%d", r.Keys),
+		fmt.Sprintf("Duration|%s", r.Duration),
+	}))
+	buffer.WriteString("\nStart the server with the new backend to use the migrated storage\n")
+
+	return buffer.String()
+}
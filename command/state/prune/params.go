@@ -0,0 +1,150 @@
+package prune
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/leveldb"
+	"github.com/0xPolygon/polygon-edge/blockchain/storagev2/mdbx"
+	"github.com/0xPolygon/polygon-edge/command"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	retainFlag  = "retain"
+	backendFlag = "state-storage-backend"
+
+	blockchainBackendFlag = "blockchain-storage-backend"
+
+	defaultRetain = 128
+)
+
+var (
+	params = &pruneParams{}
+)
+
+var (
+	errInvalidRetain    = errors.New(`invalid "retain" value; must be greater than zero`)
+	errInvalidBackend   = errors.New(`invalid "blockchain-storage-backend" value`)
+	errHeadNotFound     = errors.New("unable to read the head block")
+	errNotIterableStore = errors.New("the trie storage doesn't support the iteration")
+)
+
+type pruneParams struct {
+	dataDir string
+	retain  uint64
+	backend string
+
+	blockchainBackend string
+
+	head   uint64
+	result *itrie.PruneResult
+}
+
+func (p *pruneParams) validateFlags() error {
+	if p.retain == 0 {
+		return errInvalidRetain
+	}
+
+	if p.blockchainBackend != itrie.LevelDBBackend && p.blockchainBackend != itrie.MdbxBackend {
+		return errInvalidBackend
+	}
+
+	return nil
+}
+
+func (p *pruneParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+func (p *pruneParams) prune() error {
+	logger := hclog.NewNullLogger()
+
+	roots, err := p.readStateRoots(logger)
+	if err != nil {
+		return err
+	}
+
+	stateStorage, err := itrie.NewStorage(p.backend, filepath.Join(p.dataDir, itrie.StorageDir(p.backend)), logger)
+	if err != nil {
+		return fmt.Errorf("failed to open the trie storage: %w", err)
+	}
+
+	defer stateStorage.Close()
+
+	iterable, ok := stateStorage.(itrie.IterableStorage)
+	if !ok {
+		return errNotIterableStore
+	}
+
+	p.result, err = itrie.Prune(iterable, roots)
+
+	return err
+}
+
+// openBlockchain opens the blockchain storage with the configured backend
+func (p *pruneParams) openBlockchain(logger hclog.Logger) (*storagev2.Storage, error) {
+	path := filepath.Join(p.dataDir, "blockchain")
+
+	if p.blockchainBackend == itrie.MdbxBackend {
+		return mdbx.NewMdbxStorage(path, logger)
+	}
+
+	return leveldb.NewLevelDBStorage(path, logger)
+}
+
+// readStateRoots returns the state roots of the latest canonical blocks, the oldest first
+func (p *pruneParams) readStateRoots(logger hclog.Logger) ([]types.Hash, error) {
+	db, err := p.openBlockchain(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the blockchain storage: %w", err)
+	}
+
+	defer db.Close()
+
+	head, ok := db.ReadHeadNumber()
+	if !ok {
+		return nil, errHeadNotFound
+	}
+
+	p.head = head
+	roots := make([]types.Hash, 0, p.retain)
+
+	from := uint64(0)
+	if head >= p.retain {
+		from = head - p.retain + 1
+	}
+
+	for number := from; number <= head; number++ {
+		hash, ok := db.ReadCanonicalHash(number)
+		if !ok {
+			return nil, fmt.Errorf("unable to read the canonical hash of the block %d", number)
+		}
+
+		header, err := db.ReadHeader(number, hash)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the header of the block %d: %w", number, err)
+		}
+
+		roots = append(roots, header.StateRoot)
+	}
+
+	return roots, nil
+}
+
+func (p *pruneParams) getResult() command.CommandResult {
+	return &PruneResult{
+		Head:          p.head,
+		RetainedRoots: p.result.RetainedRoots,
+		MissingRoots:  p.result.MissingRoots,
+		KeptNodes:     p.result.KeptNodes,
+		DeletedNodes:  p.result.DeletedNodes,
+	}
+}
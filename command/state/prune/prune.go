@@ -0,0 +1,72 @@
+package prune
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	pruneCmd := &cobra.Command{
+		Use: "prune",
+		Short: "Deletes the state which is not reachable from the state roots of the latest blocks. " +
+			"The node must be stopped",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(pruneCmd)
+	helper.SetRequiredFlags(pruneCmd, params.getRequiredFlags())
+
+	return pruneCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.retain,
+		retainFlag,
+		defaultRetain,
+		"the number of the latest blocks whose state is kept",
+	)
+
+	cmd.Flags().StringVar(
+		&params.backend,
+		backendFlag,
+		itrie.LevelDBBackend,
+		"the database backend of the state storage",
+	)
+
+	cmd.Flags().StringVar(
+		&params.blockchainBackend,
+		blockchainBackendFlag,
+		itrie.LevelDBBackend,
+		fmt.Sprintf("the database backend of the blockchain storage (%s or %s)", itrie.LevelDBBackend, itrie.MdbxBackend),
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.prune(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
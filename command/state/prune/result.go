@@ -0,0 +1,32 @@
+package prune
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type PruneResult struct {
+	Head          uint64 `json:"head"`
+	RetainedRoots int    `json:"retained_roots"`
+	MissingRoots  int    `json:"missing_roots"`
+	KeptNodes     int    `json:"kept_nodes"`
+	DeletedNodes  int    `json:"deleted_nodes"`
+}
+
+func (r *PruneResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[STATE PRUNE]\n")
+	buffer.WriteString("Pruned the state successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Head block|%d", r.Head),
+		fmt.Sprintf("Retained state roots|%d", r.RetainedRoots),
+		fmt.Sprintf("Missing state roots|%d", r.MissingRoots),
+		fmt.Sprintf("Kept trie nodes|%d", r.KeptNodes),
+		fmt.Sprintf("Deleted trie nodes|%d", r.DeletedNodes),
+	}))
+
+	return buffer.String()
+}
@@ -0,0 +1,27 @@
+package state
+
+import (
+	"github.com/0xPolygon/polygon-edge/command/state/migrate"
+	"github.com/0xPolygon/polygon-edge/command/state/prune"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	stateCmd := &cobra.Command{
+		Use:   "state",
+		Short: "Top level command for maintaining the state database of the stopped node. Only accepts subcommands.",
+	}
+
+	registerSubcommands(stateCmd)
+
+	return stateCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// state prune
+		prune.GetCommand(),
+		// state migrate
+		migrate.GetCommand(),
+	)
+}
@@ -70,6 +70,12 @@ func setFlags(cmd *cobra.Command) {
 		false,
 		"should subscribe to pruned enqueued tx events in the TxPool",
 	)
+	cmd.Flags().BoolVar(
+		params.eventSubscriptionMap[txpoolProto.EventType_EVICTED],
+		evictedFlag,
+		false,
+		"should subscribe to evicted tx events in the TxPool",
+	)
 }
 
 func runCommand(cmd *cobra.Command, _ []string) {
@@ -11,6 +11,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -41,6 +42,10 @@ type Consensus interface {
 	// GetBridgeProvider returns an instance of BridgeDataProvider
 	GetBridgeProvider() BridgeDataProvider
 
+	// GetEquivocationEvidence returns the evidence of the validators signing conflicting consensus messages
+	// for the heights in the given range (inclusive)
+	GetEquivocationEvidence(fromHeight, toHeight uint64) ([]*types.EquivocationEvidence, error)
+
 	// FilterExtra filters extra data in header that is not a part of block hash
 	FilterExtra(extra []byte) ([]byte, error)
 
@@ -73,6 +78,15 @@ type Config struct {
 
 	// RPCEndpoint
 	RPCEndpoint string
+
+	// SnapSync is true if node should download the state from the peers instead of executing the chain
+	SnapSync bool
+
+	// RemoteSignerURL is the url of the remote signer of the validator keys, empty if the local keys are used
+	RemoteSignerURL string
+
+	// RemoteSignerToken is the bearer token sent to the remote signer
+	RemoteSignerToken string
 }
 
 type Params struct {
@@ -82,6 +96,7 @@ type Params struct {
 	Network        *network.Server
 	Blockchain     *blockchain.Blockchain
 	Executor       *state.Executor
+	StateStorage   itrie.Storage
 	Grpc           *grpc.Server
 	Logger         hclog.Logger
 	SecretsManager secrets.SecretsManager
@@ -260,6 +260,10 @@ func (d *Dev) GetBridgeProvider() consensus.BridgeDataProvider {
 	return nil
 }
 
+func (d *Dev) GetEquivocationEvidence(fromHeight, toHeight uint64) ([]*types.EquivocationEvidence, error) {
+	return nil, nil
+}
+
 func (d *Dev) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
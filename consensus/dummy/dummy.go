@@ -85,6 +85,10 @@ func (d *Dummy) GetBridgeProvider() consensus.BridgeDataProvider {
 	return nil
 }
 
+func (d *Dummy) GetEquivocationEvidence(fromHeight, toHeight uint64) ([]*types.EquivocationEvidence, error) {
+	return nil, nil
+}
+
 func (d *Dummy) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
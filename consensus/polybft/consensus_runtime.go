@@ -19,7 +19,6 @@ import (
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
-	"github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
 	"github.com/0xPolygon/polygon-edge/contracts"
@@ -134,6 +133,9 @@ type consensusRuntime struct {
 	// also handles updating client configuration based on governance proposals
 	governanceManager GovernanceManager
 
+	// equivocationDetector detects the validators signing conflicting consensus messages
+	equivocationDetector *equivocationDetector
+
 	// logger instance
 	logger hcf.Logger
 }
@@ -159,6 +161,8 @@ func newConsensusRuntime(log hcf.Logger, config *runtimeConfig) (*consensusRunti
 		proposerCalculator: proposerCalculator,
 		logger:             log.Named("consensus_runtime"),
 		eventProvider:      NewEventProvider(config.blockchain),
+		equivocationDetector: newEquivocationDetector(config.State.EquivocationStore,
+			log.Named("equivocation_detector")),
 	}
 
 	var bridgeManager BridgeManager
@@ -425,20 +429,21 @@ func (c *consensusRuntime) FSM() error {
 	}
 
 	ff := &fsm{
-		config:              epoch.CurrentClientConfig,
-		forks:               c.config.Forks,
-		parent:              parent,
-		backend:             c.config.blockchain,
-		polybftBackend:      c.config.polybftBackend,
-		exitEventRootHash:   exitRootHash,
-		epochNumber:         epoch.Number,
-		blockBuilder:        blockBuilder,
-		validators:          valSet,
-		isEndOfEpoch:        isEndOfEpoch,
-		isEndOfSprint:       isEndOfSprint,
-		isFirstBlockOfEpoch: isFirstBlockOfEpoch,
-		proposerSnapshot:    proposerSnapshot,
-		logger:              c.logger.Named("fsm"),
+		config:               epoch.CurrentClientConfig,
+		forks:                c.config.Forks,
+		parent:               parent,
+		backend:              c.config.blockchain,
+		polybftBackend:       c.config.polybftBackend,
+		exitEventRootHash:    exitRootHash,
+		epochNumber:          epoch.Number,
+		blockBuilder:         blockBuilder,
+		validators:           valSet,
+		isEndOfEpoch:         isEndOfEpoch,
+		isEndOfSprint:        isEndOfSprint,
+		isFirstBlockOfEpoch:  isFirstBlockOfEpoch,
+		proposerSnapshot:     proposerSnapshot,
+		equivocationDetector: c.equivocationDetector,
+		logger:               c.logger.Named("fsm"),
 	}
 
 	if isEndOfSprint {
@@ -984,7 +989,7 @@ func (c *consensusRuntime) BuildPrepareMessage(proposalHash []byte, view *proto.
 
 // BuildCommitMessage builds a COMMIT message based on the passed in proposal
 func (c *consensusRuntime) BuildCommitMessage(proposalHash []byte, view *proto.View) *proto.IbftMessage {
-	committedSeal, err := c.config.Key.SignWithDomain(proposalHash, signer.DomainCheckpointManager)
+	committedSeal, err := c.config.Key.SignCommittedSeal(proposalHash, view)
 	if err != nil {
 		c.logger.Error("Cannot create committed seal message.", "error", err)
 
@@ -0,0 +1,164 @@
+package polybft
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/armon/go-metrics"
+	hcf "github.com/hashicorp/go-hclog"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// equivocationTrackedHeights is the number of heights below and above the current height
+// whose messages are kept for the equivocation detection
+const equivocationTrackedHeights = 5
+
+// equivocationKey identifies the message which the validator is allowed to sign only once
+type equivocationKey struct {
+	height  uint64
+	round   uint64
+	msgType proto.MessageType
+	sender  types.Address
+}
+
+// equivocationDetector records the signed PREPARE and COMMIT messages per height, round and sender
+// and persists the evidence when the validator signs conflicting messages for the same view
+type equivocationDetector struct {
+	store  *EquivocationStore
+	logger hcf.Logger
+
+	lock     sync.Mutex
+	messages map[equivocationKey]*proto.IbftMessage
+	// height is the current height the recorded messages are kept around
+	height uint64
+}
+
+// newEquivocationDetector creates a new instance of equivocationDetector
+func newEquivocationDetector(store *EquivocationStore, logger hcf.Logger) *equivocationDetector {
+	return &equivocationDetector{
+		store:    store,
+		logger:   logger,
+		messages: map[equivocationKey]*proto.IbftMessage{},
+	}
+}
+
+// addMessage records the message and checks it against the already recorded message of the same sender and view.
+// Only the messages near the current height of the node are recorded, so that a message with an arbitrary height
+// can't evict the others. The sender and the signature of the message must be validated before
+func (d *equivocationDetector) addMessage(msg *proto.IbftMessage, currentHeight uint64) {
+	if d == nil || msg.GetView() == nil {
+		return
+	}
+
+	proposalHash := messageProposalHash(msg)
+	if proposalHash == nil {
+		return
+	}
+
+	key := equivocationKey{
+		height:  msg.View.Height,
+		round:   msg.View.Round,
+		msgType: msg.Type,
+		sender:  types.BytesToAddress(msg.From),
+	}
+
+	if !isTrackedHeight(key.height, currentHeight) {
+		return
+	}
+
+	d.lock.Lock()
+
+	d.pruneLocked(currentHeight)
+
+	existing, exists := d.messages[key]
+	if !exists {
+		d.messages[key] = protobuf.Clone(msg).(*proto.IbftMessage) //nolint:forcetypeassert
+	}
+
+	d.lock.Unlock()
+
+	if !exists || bytes.Equal(messageProposalHash(existing), proposalHash) {
+		return
+	}
+
+	if err := d.reportEvidence(key, existing, msg); err != nil {
+		d.logger.Error("failed to store equivocation evidence", "height", key.height, "round", key.round,
+			"validator", key.sender, "error", err)
+	}
+}
+
+// pruneLocked drops the messages of the heights which are not tracked at the current height, the lock must be held
+func (d *equivocationDetector) pruneLocked(currentHeight uint64) {
+	if currentHeight == d.height {
+		return
+	}
+
+	d.height = currentHeight
+
+	for key := range d.messages {
+		if !isTrackedHeight(key.height, currentHeight) {
+			delete(d.messages, key)
+		}
+	}
+}
+
+// isTrackedHeight checks whether the messages of the height are tracked at the current height
+func isTrackedHeight(height, currentHeight uint64) bool {
+	if height > currentHeight {
+		return height-currentHeight <= equivocationTrackedHeights
+	}
+
+	return currentHeight-height <= equivocationTrackedHeights
+}
+
+// reportEvidence persists the evidence of the conflicting messages
+func (d *equivocationDetector) reportEvidence(key equivocationKey, first, second *proto.IbftMessage) error {
+	firstRaw, err := protobuf.Marshal(first)
+	if err != nil {
+		return err
+	}
+
+	secondRaw, err := protobuf.Marshal(second)
+	if err != nil {
+		return err
+	}
+
+	evidence := &types.EquivocationEvidence{
+		Validator:     key.sender,
+		Height:        key.height,
+		Round:         key.round,
+		MessageType:   key.msgType.String(),
+		FirstMessage:  firstRaw,
+		SecondMessage: secondRaw,
+		DetectedAt:    uint64(time.Now().UTC().Unix()),
+	}
+
+	inserted, err := d.store.insertEvidence(evidence)
+	if err != nil || !inserted {
+		return err
+	}
+
+	metrics.IncrCounter([]string{consensusMetricsPrefix, "equivocations"}, 1)
+
+	d.logger.Warn("validator signed conflicting messages", "type", evidence.MessageType,
+		"height", key.height, "round", key.round, "validator", key.sender)
+
+	return nil
+}
+
+// messageProposalHash returns the proposal hash the message votes for,
+// or nil if the message type is not checked for the equivocation
+func messageProposalHash(msg *proto.IbftMessage) []byte {
+	switch msg.Type {
+	case proto.MessageType_PREPARE:
+		return msg.GetPrepareData().GetProposalHash()
+	case proto.MessageType_COMMIT:
+		return msg.GetCommitData().GetProposalHash()
+	default:
+		return nil
+	}
+}
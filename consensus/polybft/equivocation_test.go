@@ -0,0 +1,193 @@
+package polybft
+
+import (
+	"math"
+	"testing"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func signTestVote(t *testing.T, v *validator.TestValidator, msgType proto.MessageType,
+	view *proto.View, proposalHash []byte) *proto.IbftMessage {
+	t.Helper()
+
+	msg := &proto.IbftMessage{
+		View: view,
+		From: v.Address().Bytes(),
+		Type: msgType,
+	}
+
+	if msgType == proto.MessageType_PREPARE {
+		msg.Payload = &proto.IbftMessage_PrepareData{PrepareData: &proto.PrepareMessage{ProposalHash: proposalHash}}
+	} else {
+		msg.Payload = &proto.IbftMessage_CommitData{CommitData: &proto.CommitMessage{ProposalHash: proposalHash}}
+	}
+
+	msg, err := v.Key().SignIBFTMessage(msg)
+	require.NoError(t, err)
+
+	return msg
+}
+
+func TestEquivocationDetector_ValidateSender(t *testing.T) {
+	t.Parallel()
+
+	state := newTestState(t)
+	validators := validator.NewTestValidatorsWithAliases(t, []string{"A", "B", "C", "D"})
+	detector := newEquivocationDetector(state.EquivocationStore, hclog.NewNullLogger())
+
+	runtime := &consensusRuntime{
+		logger:               hclog.NewNullLogger(),
+		equivocationDetector: detector,
+		fsm: &fsm{
+			parent:               &types.Header{Number: 9},
+			validators:           validator.NewValidatorSet(validators.GetPublicIdentities(), hclog.NewNullLogger()),
+			equivocationDetector: detector,
+		},
+	}
+
+	view := &proto.View{Height: 10, Round: 1}
+	a, b := validators.GetValidator("A"), validators.GetValidator("B")
+
+	// the same vote received twice and the votes of the other types, rounds or validators aren't conflicting
+	for _, msg := range []*proto.IbftMessage{
+		signTestVote(t, a, proto.MessageType_PREPARE, view, []byte{0x1}),
+		signTestVote(t, a, proto.MessageType_PREPARE, view, []byte{0x1}),
+		signTestVote(t, a, proto.MessageType_COMMIT, view, []byte{0x2}),
+		signTestVote(t, a, proto.MessageType_PREPARE, &proto.View{Height: 10, Round: 2}, []byte{0x2}),
+		signTestVote(t, b, proto.MessageType_PREPARE, view, []byte{0x2}),
+	} {
+		require.True(t, runtime.IsValidValidator(msg))
+	}
+
+	evidence, err := state.EquivocationStore.getEvidence(0, 100)
+	require.NoError(t, err)
+	require.Empty(t, evidence)
+
+	// conflicting commit is detected once
+	first := signTestVote(t, a, proto.MessageType_COMMIT, view, []byte{0x2})
+	second := signTestVote(t, a, proto.MessageType_COMMIT, view, []byte{0x3})
+
+	require.True(t, runtime.IsValidValidator(second))
+	require.True(t, runtime.IsValidValidator(signTestVote(t, a, proto.MessageType_COMMIT, view, []byte{0x4})))
+
+	evidence, err = state.EquivocationStore.getEvidence(10, 10)
+	require.NoError(t, err)
+	require.Len(t, evidence, 1)
+
+	require.Equal(t, a.Address(), evidence[0].Validator)
+	require.Equal(t, uint64(10), evidence[0].Height)
+	require.Equal(t, uint64(1), evidence[0].Round)
+	require.Equal(t, proto.MessageType_COMMIT.String(), evidence[0].MessageType)
+
+	// the evidence holds the messages signed by the validator
+	for i, raw := range [][]byte{evidence[0].FirstMessage, evidence[0].SecondMessage} {
+		msg := &proto.IbftMessage{}
+		require.NoError(t, protobuf.Unmarshal(raw, msg))
+		require.Equal(t, []*proto.IbftMessage{first, second}[i].Signature, msg.Signature)
+
+		payload, err := msg.PayloadNoSig()
+		require.NoError(t, err)
+
+		signer, err := wallet.RecoverAddressFromSignature(msg.Signature, payload)
+		require.NoError(t, err)
+		require.Equal(t, a.Address(), signer)
+	}
+}
+
+func TestEquivocationDetector_Prune(t *testing.T) {
+	t.Parallel()
+
+	state := newTestState(t)
+	v := validator.NewTestValidators(t, 1).GetValidators()[0]
+	detector := newEquivocationDetector(state.EquivocationStore, hclog.NewNullLogger())
+
+	vote := func(height uint64, proposalHash byte) *proto.IbftMessage {
+		return signTestVote(t, v, proto.MessageType_PREPARE, &proto.View{Height: height}, []byte{proposalHash})
+	}
+
+	for height := uint64(1); height <= 20; height++ {
+		detector.addMessage(vote(height, 0x1), 10)
+	}
+
+	require.Len(t, detector.messages, 2*equivocationTrackedHeights+1)
+
+	// the messages far from the current height neither are recorded nor evict the recorded ones
+	for _, height := range []uint64{1, 16, math.MaxUint64 - 1, math.MaxUint64} {
+		detector.addMessage(vote(height, 0x2), 10)
+	}
+
+	require.Len(t, detector.messages, 2*equivocationTrackedHeights+1)
+
+	detector.addMessage(vote(10, 0x2), 10)
+
+	evidence, err := state.EquivocationStore.getEvidence(0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Len(t, evidence, 1)
+	require.Equal(t, uint64(10), evidence[0].Height)
+
+	// the messages which are not tracked at the new height are dropped
+	detector.addMessage(vote(12, 0x1), 12)
+	require.Len(t, detector.messages, 2*equivocationTrackedHeights-1)
+
+	// round change and nil messages are skipped
+	detector.addMessage(&proto.IbftMessage{View: &proto.View{Height: 12}, Type: proto.MessageType_ROUND_CHANGE}, 12)
+	detector.addMessage(&proto.IbftMessage{}, 12)
+	require.Len(t, detector.messages, 2*equivocationTrackedHeights-1)
+
+	// the height window doesn't overflow
+	require.True(t, isTrackedHeight(math.MaxUint64, math.MaxUint64-1))
+	require.False(t, isTrackedHeight(math.MaxUint64, 0))
+	require.False(t, isTrackedHeight(0, math.MaxUint64))
+
+	var nilDetector *equivocationDetector
+	nilDetector.addMessage(&proto.IbftMessage{}, 0)
+}
+
+func TestEquivocationStore_GetEvidence(t *testing.T) {
+	t.Parallel()
+
+	state := newTestState(t)
+
+	for height := uint64(1); height <= 5; height++ {
+		for _, validator := range []types.Address{types.StringToAddress("1"), types.StringToAddress("2")} {
+			inserted, err := state.EquivocationStore.insertEvidence(&types.EquivocationEvidence{
+				Validator:   validator,
+				Height:      height,
+				Round:       height % 2,
+				MessageType: proto.MessageType_PREPARE.String(),
+			})
+			require.NoError(t, err)
+			require.True(t, inserted)
+		}
+	}
+
+	// the evidence of the same view, type and validator is stored once
+	inserted, err := state.EquivocationStore.insertEvidence(&types.EquivocationEvidence{
+		Validator:   types.StringToAddress("1"),
+		Height:      1,
+		Round:       1,
+		MessageType: proto.MessageType_PREPARE.String(),
+	})
+	require.NoError(t, err)
+	require.False(t, inserted)
+
+	evidence, err := state.EquivocationStore.getEvidence(2, 4)
+	require.NoError(t, err)
+	require.Len(t, evidence, 6)
+
+	for i, e := range evidence {
+		require.Equal(t, uint64(2+i/2), e.Height)
+	}
+
+	evidence, err = state.EquivocationStore.getEvidence(6, 10)
+	require.NoError(t, err)
+	require.Empty(t, evidence)
+}
@@ -100,6 +100,9 @@ type fsm struct {
 	// proposerCommitmentToRegister is a commitment that is registered via state transaction by proposer
 	proposerCommitmentToRegister *CommitmentMessageSigned
 
+	// equivocationDetector records the validated messages and detects the conflicting ones
+	equivocationDetector *equivocationDetector
+
 	// logger instance
 	logger hclog.Logger
 
@@ -450,6 +453,10 @@ func (f *fsm) ValidateSender(msg *proto.IbftMessage) error {
 		return fmt.Errorf("signer address %s is not included in validator set", signerAddress.String())
 	}
 
+	if f.equivocationDetector != nil {
+		f.equivocationDetector.addMessage(msg, f.parent.Number+1)
+	}
+
 	return nil
 }
 
@@ -457,14 +457,24 @@ func IsL1OriginatedTokenCheck(config *chain.Params) (bool, error) {
 func (p *Polybft) Initialize() error {
 	p.logger.Info("initializing polybft...")
 
-	// read account
-	account, err := wallet.NewAccountFromSecret(p.config.SecretsManager)
-	if err != nil {
-		return fmt.Errorf("failed to read account data. Error: %w", err)
-	}
+	var err error
 
-	// set key
-	p.key = wallet.NewKey(account)
+	// set key, the validator keys are either held by the remote signer or read from the secrets manager
+	if p.config.Config.RemoteSignerURL != "" {
+		remoteSigner, err := wallet.NewRemoteSigner(p.config.Config.RemoteSignerURL, p.config.Config.RemoteSignerToken, 0)
+		if err != nil {
+			return fmt.Errorf("failed to connect to the remote signer. Error: %w", err)
+		}
+
+		p.key = wallet.NewKeyWithSigner(remoteSigner)
+	} else {
+		account, err := wallet.NewAccountFromSecret(p.config.SecretsManager)
+		if err != nil {
+			return fmt.Errorf("failed to read account data. Error: %w", err)
+		}
+
+		p.key = wallet.NewKey(account)
+	}
 
 	// create and set syncer
 	p.syncer = syncer.NewSyncer(
@@ -472,6 +482,8 @@ func (p *Polybft) Initialize() error {
 		p.config.Network,
 		p.config.Blockchain,
 		time.Duration(p.config.BlockTime)*3*time.Second,
+		p.config.StateStorage,
+		p.config.Config.SnapSync,
 	)
 
 	// set blockchain backend
@@ -846,6 +858,11 @@ func (p *Polybft) GetBridgeProvider() consensus.BridgeDataProvider {
 	return p.runtime
 }
 
+// GetEquivocationEvidence is an implementation of Consensus interface
+func (p *Polybft) GetEquivocationEvidence(fromHeight, toHeight uint64) ([]*types.EquivocationEvidence, error) {
+	return p.state.EquivocationStore.getEvidence(fromHeight, toHeight)
+}
+
 // FilterExtra is an implementation of Consensus interface
 func (p *Polybft) FilterExtra(extra []byte) ([]byte, error) {
 	return GetIbftExtraClean(extra)
@@ -0,0 +1,121 @@
+// blade-signer is the reference remote signer holding the validator keys outside the node.
+// The protocol is described in consensus/polybft/remotesigner/README.md
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/command/validator/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/remotesigner"
+)
+
+const defaultRetention = 1024
+
+func main() {
+	var (
+		dataDir     = flag.String("data-dir", "", "the directory of the validator secrets (local secrets manager)")
+		config      = flag.String("config", "", "the path to the secrets manager configuration file")
+		listenAddr  = flag.String("addr", "127.0.0.1:9100", "the address the signer listens on")
+		dbPath      = flag.String("db", "", "the path to the double-sign protection database (default <data-dir>/signer.db)")
+		tokenFile   = flag.String("token-file", "", "the file with the bearer token required from the nodes")
+		tlsCertFile = flag.String("tls-cert-file", "", "the TLS certificate file")
+		tlsKeyFile  = flag.String("tls-key-file", "", "the TLS key file")
+		retention   = flag.Uint64("retention", defaultRetention, "the number of the heights whose signatures are recorded")
+		logLevel    = flag.String("log-level", "INFO", "the log level")
+	)
+
+	flag.Parse()
+
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "blade-signer",
+		Level: hclog.LevelFromString(*logLevel),
+	})
+
+	if err := run(logger, *dataDir, *config, *listenAddr, *dbPath, *tokenFile,
+		*tlsCertFile, *tlsKeyFile, *retention); err != nil {
+		logger.Error("signer failed", "err", err)
+		os.Exit(1)
+	}
+}
+
+func run(logger hclog.Logger, dataDir, config, listenAddr, dbPath, tokenFile,
+	tlsCertFile, tlsKeyFile string, retention uint64) error {
+	if err := helper.ValidateSecretFlags(dataDir, config); err != nil {
+		return err
+	}
+
+	account, err := helper.GetAccount(dataDir, config)
+	if err != nil {
+		return fmt.Errorf("failed to read the validator keys: %w", err)
+	}
+
+	if dbPath == "" {
+		if dataDir == "" {
+			return errors.New("the protection database path is required")
+		}
+
+		dbPath = filepath.Join(dataDir, "signer.db")
+	}
+
+	protection, err := remotesigner.NewProtection(dbPath, retention)
+	if err != nil {
+		return fmt.Errorf("failed to open the protection database: %w", err)
+	}
+
+	defer protection.Close()
+
+	var token string
+
+	if tokenFile != "" {
+		raw, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read the token file: %w", err)
+		}
+
+		token = strings.TrimSpace(string(raw))
+	}
+
+	srv := &http.Server{
+		Addr:              listenAddr,
+		Handler:           remotesigner.NewServer(account, protection, token, logger).Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		logger.Info("signer started", "addr", listenAddr, "address", account.Address())
+
+		if tlsCertFile != "" || tlsKeyFile != "" {
+			errCh <- srv.ListenAndServeTLS(tlsCertFile, tlsKeyFile)
+		} else {
+			errCh <- srv.ListenAndServe()
+		}
+	}()
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-signalCh:
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return srv.Shutdown(ctx)
+}
@@ -0,0 +1,143 @@
+package remotesigner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// signedBucket holds the digests of the signed consensus data by the view and the sign type
+	signedBucket = []byte("signed")
+	// metaBucket holds the watermarks of the protection database
+	metaBucket = []byte("meta")
+
+	// minHeightKey is the lowest height the signer still signs at, the records below it are pruned
+	minHeightKey = []byte("min-height")
+	// maxHeightKey is the highest height the signer has signed at
+	maxHeightKey = []byte("max-height")
+)
+
+// Protection records the signed consensus data and refuses to sign the conflicting data for the same view,
+// which would let the validator be slashed for the equivocation
+type Protection struct {
+	db *bolt.DB
+
+	// retention is the number of the heights below the highest signed height whose records are kept
+	retention uint64
+}
+
+// NewProtection opens the protection database at the given path
+func NewProtection(path string, retention uint64) (*Protection, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{signedBucket, metaBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		db.Close()
+
+		return nil, err
+	}
+
+	return &Protection{db: db, retention: retention}, nil
+}
+
+// Close closes the protection database
+func (p *Protection) Close() error {
+	return p.db.Close()
+}
+
+// Record records the consensus data of the given view before it is signed.
+// It fails if different data of the same kind has already been signed for the view
+// or if the view is too old to be checked
+func (p *Protection) Record(height, round uint64, kind byte, data []byte) error {
+	digest := crypto.Keccak256(data)
+
+	return p.db.Update(func(tx *bolt.Tx) error {
+		signed, meta := tx.Bucket(signedBucket), tx.Bucket(metaBucket)
+
+		if minHeight := readUint64(meta, minHeightKey); height < minHeight {
+			return fmt.Errorf("%w: height %d is below the protected height %d", wallet.ErrDoubleSign, height, minHeight)
+		}
+
+		key := viewKey(height, round, kind)
+
+		if existing := signed.Get(key); existing != nil {
+			if !bytes.Equal(existing, digest) {
+				return fmt.Errorf("%w: already signed other data at height %d, round %d", wallet.ErrDoubleSign, height, round)
+			}
+
+			return nil
+		}
+
+		if err := signed.Put(key, digest); err != nil {
+			return err
+		}
+
+		if height <= readUint64(meta, maxHeightKey) {
+			return nil
+		}
+
+		if err := meta.Put(maxHeightKey, binary.BigEndian.AppendUint64(nil, height)); err != nil {
+			return err
+		}
+
+		if height <= p.retention {
+			return nil
+		}
+
+		return p.prune(signed, meta, height-p.retention)
+	})
+}
+
+// prune deletes the records below the given height, the signer refuses to sign below it afterwards
+func (p *Protection) prune(signed, meta *bolt.Bucket, minHeight uint64) error {
+	var keys [][]byte
+
+	cursor := signed.Cursor()
+	for k, _ := cursor.First(); k != nil && binary.BigEndian.Uint64(k[:8]) < minHeight; k, _ = cursor.Next() {
+		keys = append(keys, k)
+	}
+
+	// the keys are deleted after the iteration, since the deletion moves the cursor
+	for _, k := range keys {
+		if err := signed.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	return meta.Put(minHeightKey, binary.BigEndian.AppendUint64(nil, minHeight))
+}
+
+func viewKey(height, round uint64, kind byte) []byte {
+	key := make([]byte, 17)
+	binary.BigEndian.PutUint64(key[:8], height)
+	binary.BigEndian.PutUint64(key[8:16], round)
+	key[16] = kind
+
+	return key
+}
+
+func readUint64(bucket *bolt.Bucket, key []byte) uint64 {
+	value := bucket.Get(key)
+	if len(value) != 8 {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(value)
+}
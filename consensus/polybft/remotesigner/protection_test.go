@@ -0,0 +1,45 @@
+package remotesigner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+)
+
+func TestProtection_Record(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "signer.db")
+
+	protection, err := NewProtection(path, 2)
+	require.NoError(t, err)
+
+	// the same data can be signed again, the other kind of data is independent
+	require.NoError(t, protection.Record(1, 0, 1, []byte{0x1}))
+	require.NoError(t, protection.Record(1, 0, 1, []byte{0x1}))
+	require.NoError(t, protection.Record(1, 0, 2, []byte{0x2}))
+	require.NoError(t, protection.Record(1, 1, 1, []byte{0x3}))
+
+	require.ErrorIs(t, protection.Record(1, 0, 1, []byte{0x2}), wallet.ErrDoubleSign)
+
+	// the records survive the restart
+	require.NoError(t, protection.Close())
+
+	protection, err = NewProtection(path, 2)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, protection.Close())
+	})
+
+	require.ErrorIs(t, protection.Record(1, 1, 1, []byte{0x1}), wallet.ErrDoubleSign)
+
+	// the records below the retention are pruned and the signer refuses to sign there
+	require.NoError(t, protection.Record(4, 0, 1, []byte{0x4}))
+	require.ErrorIs(t, protection.Record(1, 2, 1, []byte{0x1}), wallet.ErrDoubleSign)
+	require.NoError(t, protection.Record(2, 0, 1, []byte{0x2}))
+	require.NoError(t, protection.Record(3, 0, 1, []byte{0x3}))
+}
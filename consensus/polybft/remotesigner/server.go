@@ -0,0 +1,167 @@
+package remotesigner
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+)
+
+const (
+	// committedSealKind is the protection record kind of the committed seals,
+	// the IBFT messages use their message type as the kind
+	committedSealKind = byte(0x10)
+
+	maxRequestSize = 16 * 1024 * 1024
+)
+
+var errInvalidRequest = errors.New("invalid sign request")
+
+// Server is the reference remote signer, which serves the signatures of the validator keys over HTTP.
+// The consensus data is recorded by the Protection before it is signed
+type Server struct {
+	signer     *wallet.LocalSigner
+	account    *wallet.Account
+	protection *Protection
+	token      string
+	logger     hclog.Logger
+}
+
+// NewServer creates the remote signer of the given account, the empty token disables the authorization
+func NewServer(account *wallet.Account, protection *Protection, token string, logger hclog.Logger) *Server {
+	return &Server{
+		signer:     wallet.NewLocalSigner(account),
+		account:    account,
+		protection: protection,
+		token:      token,
+		logger:     logger,
+	}
+}
+
+// Handler returns the HTTP handler of the remote signer endpoints
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(wallet.RemoteSignerKeysPath, s.authorized(http.MethodGet, s.handleKeys))
+	mux.HandleFunc(wallet.RemoteSignerSignPath, s.authorized(http.MethodPost, s.handleSign))
+
+	return mux
+}
+
+func (s *Server) authorized(method string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			writeResponse(w, http.StatusMethodNotAllowed, &wallet.RemoteSignResponse{Error: "method not allowed"})
+
+			return
+		}
+
+		if s.token != "" {
+			token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+				writeResponse(w, http.StatusUnauthorized, &wallet.RemoteSignResponse{Error: "unauthorized"})
+
+				return
+			}
+		}
+
+		handler(w, r)
+	}
+}
+
+func (s *Server) handleKeys(w http.ResponseWriter, _ *http.Request) {
+	writeResponse(w, http.StatusOK, &wallet.RemoteKeysResponse{
+		Address:      s.account.Address(),
+		BLSPublicKey: s.account.Bls.PublicKey().Marshal(),
+	})
+}
+
+func (s *Server) handleSign(w http.ResponseWriter, r *http.Request) {
+	var req wallet.SignRequest
+
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req); err != nil {
+		writeResponse(w, http.StatusBadRequest, &wallet.RemoteSignResponse{Error: err.Error()})
+
+		return
+	}
+
+	signature, err := s.Sign(&req)
+	if err != nil {
+		status := http.StatusInternalServerError
+
+		switch {
+		case errors.Is(err, wallet.ErrDoubleSign):
+			status = http.StatusConflict
+		case errors.Is(err, errInvalidRequest):
+			status = http.StatusBadRequest
+		}
+
+		s.logger.Warn("refused to sign", "type", req.Type, "height", req.Height, "round", req.Round, "err", err)
+		writeResponse(w, status, &wallet.RemoteSignResponse{Error: err.Error()})
+
+		return
+	}
+
+	writeResponse(w, http.StatusOK, &wallet.RemoteSignResponse{Signature: signature})
+}
+
+// Sign checks the request against the protection database and signs it
+func (s *Server) Sign(req *wallet.SignRequest) ([]byte, error) {
+	switch req.Type {
+	case wallet.SignTypeIBFTMessage:
+		msg, err := wallet.DecodeIBFTMessage(req.Data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidRequest, err)
+		}
+
+		if !bytes.Equal(msg.From, s.account.Address().Bytes()) {
+			return nil, fmt.Errorf("%w: message is not from the validator", errInvalidRequest)
+		}
+
+		// the round change messages can't be used to equivocate, so they aren't recorded
+		if msg.Type != proto.MessageType_ROUND_CHANGE {
+			if err := s.protection.Record(msg.View.Height, msg.View.Round, byte(msg.Type), req.Data); err != nil {
+				return nil, err
+			}
+		}
+
+	case wallet.SignTypeCommittedSeal:
+		if !bytes.Equal(req.Domain, signer.DomainCheckpointManager) {
+			return nil, fmt.Errorf("%w: invalid committed seal domain", errInvalidRequest)
+		}
+
+		if err := s.protection.Record(req.Height, req.Round, committedSealKind, req.Data); err != nil {
+			return nil, err
+		}
+
+	case wallet.SignTypeBLS:
+		// the committed seals are signed only with their view, so that they are recorded
+		if bytes.Equal(req.Domain, signer.DomainCheckpointManager) {
+			return nil, fmt.Errorf("%w: committed seal domain", errInvalidRequest)
+		}
+	}
+
+	signature, err := s.signer.Sign(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidRequest, err)
+	}
+
+	s.logger.Debug("signed", "type", req.Type, "height", req.Height, "round", req.Round)
+
+	return signature, nil
+}
+
+func writeResponse(w http.ResponseWriter, status int, resp interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(resp)
+}
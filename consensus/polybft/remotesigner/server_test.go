@@ -0,0 +1,170 @@
+package remotesigner
+
+import (
+	"math/big"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/wallet"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func newTestServer(t *testing.T, token string) (*wallet.Account, string) {
+	t.Helper()
+
+	account, err := wallet.GenerateAccount()
+	require.NoError(t, err)
+
+	protection, err := NewProtection(filepath.Join(t.TempDir(), "signer.db"), 10)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(NewServer(account, protection, token, hclog.NewNullLogger()).Handler())
+
+	t.Cleanup(func() {
+		srv.Close()
+		require.NoError(t, protection.Close())
+	})
+
+	return account, srv.URL
+}
+
+func TestServer_RemoteSigner(t *testing.T) {
+	t.Parallel()
+
+	account, url := newTestServer(t, "secret")
+
+	_, err := wallet.NewRemoteSigner(url, "wrong", 0)
+	require.ErrorContains(t, err, "unauthorized")
+
+	remote, err := wallet.NewRemoteSigner(url, "secret", 0)
+	require.NoError(t, err)
+	require.Equal(t, account.Address(), remote.Address())
+
+	key := wallet.NewKeyWithSigner(remote)
+	view := &proto.View{Height: 5, Round: 1}
+
+	newPrepare := func(proposalHash []byte) *proto.IbftMessage {
+		return &proto.IbftMessage{
+			View: view,
+			From: account.Address().Bytes(),
+			Type: proto.MessageType_PREPARE,
+			Payload: &proto.IbftMessage_PrepareData{
+				PrepareData: &proto.PrepareMessage{ProposalHash: proposalHash},
+			},
+		}
+	}
+
+	// IBFT message
+	msg, err := key.SignIBFTMessage(newPrepare([]byte{0x1}))
+	require.NoError(t, err)
+
+	payload, err := msg.PayloadNoSig()
+	require.NoError(t, err)
+
+	address, err := wallet.RecoverAddressFromSignature(msg.Signature, payload)
+	require.NoError(t, err)
+	require.Equal(t, account.Address(), address)
+
+	_, err = key.SignIBFTMessage(newPrepare([]byte{0x2}))
+	require.ErrorIs(t, err, wallet.ErrDoubleSign)
+
+	// committed seal
+	_, err = key.SignCommittedSeal([]byte{0x1}, view)
+	require.NoError(t, err)
+
+	_, err = key.SignCommittedSeal([]byte{0x2}, view)
+	require.ErrorIs(t, err, wallet.ErrDoubleSign)
+
+	// transactions and the other BLS domains aren't protected
+	to := types.StringToAddress("0x1")
+	tx := types.NewTx(types.NewLegacyTx(types.WithNonce(1), types.WithGas(21000),
+		types.WithGasPrice(big.NewInt(10)), types.WithTo(&to)))
+
+	signed, err := wallet.NewEcdsaSigner(key).SignTx(tx, 100)
+	require.NoError(t, err)
+
+	sender, err := crypto.NewLondonSigner(100).Sender(signed)
+	require.NoError(t, err)
+	require.Equal(t, account.Address(), sender)
+
+	_, err = key.Sign(crypto.Keccak256([]byte("tx")))
+	require.NoError(t, err)
+}
+
+func TestServer_Sign_ProtectionBypass(t *testing.T) {
+	t.Parallel()
+
+	account, url := newTestServer(t, "")
+
+	remote, err := wallet.NewRemoteSigner(url, "", 0)
+	require.NoError(t, err)
+
+	key := wallet.NewKeyWithSigner(remote)
+	view := &proto.View{Height: 5, Round: 1}
+
+	_, err = key.SignCommittedSeal([]byte{0x1}, view)
+	require.NoError(t, err)
+
+	// the committed seal of another proposal requested as the BLS signature
+	_, err = key.SignWithDomain([]byte{0x2}, signer.DomainCheckpointManager)
+	require.ErrorContains(t, err, "committed seal domain")
+
+	prepare := &proto.IbftMessage{
+		View: view,
+		From: account.Address().Bytes(),
+		Type: proto.MessageType_PREPARE,
+		Payload: &proto.IbftMessage_PrepareData{
+			PrepareData: &proto.PrepareMessage{ProposalHash: []byte{0x2}},
+		},
+	}
+
+	msgRaw, err := protobuf.Marshal(prepare)
+	require.NoError(t, err)
+
+	// the hash of the IBFT message requested as the ECDSA signature
+	_, err = remote.Sign(&wallet.SignRequest{Type: "ecdsa", Data: crypto.Keccak256(msgRaw)})
+	require.ErrorContains(t, err, "unknown sign type")
+
+	// the IBFT message requested as the transaction
+	_, err = remote.Sign(&wallet.SignRequest{Type: wallet.SignTypeTransaction, Data: msgRaw, ChainID: 100})
+	require.ErrorContains(t, err, "cannot unmarshal transaction")
+
+	// the IBFT message is still signed only once
+	_, err = key.SignIBFTMessage(prepare)
+	require.NoError(t, err)
+}
+
+func TestServer_Sign_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	_, url := newTestServer(t, "")
+
+	remote, err := wallet.NewRemoteSigner(url, "", 0)
+	require.NoError(t, err)
+
+	other, err := wallet.GenerateAccount()
+	require.NoError(t, err)
+
+	// message of another validator
+	_, err = wallet.NewKeyWithSigner(remote).SignIBFTMessage(&proto.IbftMessage{
+		View: &proto.View{Height: 1},
+		From: other.Address().Bytes(),
+		Type: proto.MessageType_COMMIT,
+	})
+	require.ErrorContains(t, err, "not from the validator")
+
+	// committed seal with another domain
+	_, err = remote.Sign(&wallet.SignRequest{Type: wallet.SignTypeCommittedSeal, Data: []byte{0x1}, Domain: []byte{0x1}})
+	require.ErrorContains(t, err, "invalid committed seal domain")
+
+	_, err = remote.Sign(&wallet.SignRequest{Type: "unknown", Data: []byte{0x1}})
+	require.ErrorContains(t, err, "unknown sign type")
+}
@@ -43,6 +43,7 @@ type State struct {
 	ProposerSnapshotStore *ProposerSnapshotStore
 	StakeStore            *StakeStore
 	GovernanceStore       *GovernanceStore
+	EquivocationStore     *EquivocationStore
 }
 
 // newState creates new instance of State
@@ -61,6 +62,7 @@ func newState(path string, closeCh chan struct{}) (*State, error) {
 		ProposerSnapshotStore: &ProposerSnapshotStore{db: db},
 		StakeStore:            &StakeStore{db: db},
 		GovernanceStore:       &GovernanceStore{db: db},
+		EquivocationStore:     &EquivocationStore{db: db},
 	}
 
 	if err = s.initStorages(); err != nil {
@@ -94,6 +96,10 @@ func (s *State) initStorages() error {
 			return err
 		}
 
+		if err := s.EquivocationStore.initialize(tx); err != nil {
+			return err
+		}
+
 		_, err := tx.CreateBucketIfNotExists(edgeEventsLastProcessedBlockBucket)
 		if err != nil {
 			return fmt.Errorf("failed to create bucket=%s: %w", string(edgeEventsLastProcessedBlockBucket), err)
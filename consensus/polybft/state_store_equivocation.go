@@ -0,0 +1,99 @@
+package polybft
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// bucket to store equivocation evidence
+	equivocationEvidenceBucket = []byte("equivocationEvidence")
+)
+
+/*
+Bolt DB schema:
+
+equivocation evidence/
+|--> (height+round+messageType+validator) -> *types.EquivocationEvidence (json marshalled)
+*/
+type EquivocationStore struct {
+	db *bolt.DB
+}
+
+// initialize creates necessary buckets in DB if they don't already exist
+func (s *EquivocationStore) initialize(tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(equivocationEvidenceBucket); err != nil {
+		return fmt.Errorf("failed to create bucket=%s: %w", string(equivocationEvidenceBucket), err)
+	}
+
+	return nil
+}
+
+// insertEvidence inserts the equivocation evidence to db,
+// only the first evidence of the validator for the given view and message type is kept
+func (s *EquivocationStore) insertEvidence(evidence *types.EquivocationEvidence) (bool, error) {
+	inserted := false
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(equivocationEvidenceBucket)
+		key := generateEvidenceKey(evidence)
+
+		if bucket.Get(key) != nil {
+			return nil
+		}
+
+		raw, err := json.Marshal(evidence)
+		if err != nil {
+			return err
+		}
+
+		inserted = true
+
+		return bucket.Put(key, raw)
+	})
+
+	return inserted, err
+}
+
+// getEvidence returns the equivocation evidence for the heights in the given range (inclusive)
+func (s *EquivocationStore) getEvidence(fromHeight, toHeight uint64) ([]*types.EquivocationEvidence, error) {
+	var evidence []*types.EquivocationEvidence
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(equivocationEvidenceBucket).Cursor()
+		to := common.EncodeUint64ToBytes(toHeight)
+
+		for k, v := cursor.Seek(common.EncodeUint64ToBytes(fromHeight)); k != nil; k, v = cursor.Next() {
+			if bytes.Compare(k[:8], to) > 0 {
+				break
+			}
+
+			var e *types.EquivocationEvidence
+			if err := json.Unmarshal(v, &e); err != nil {
+				return err
+			}
+
+			evidence = append(evidence, e)
+		}
+
+		return nil
+	})
+
+	return evidence, err
+}
+
+// generateEvidenceKey builds the evidence key ordered by the height and the round
+func generateEvidenceKey(evidence *types.EquivocationEvidence) []byte {
+	return bytes.Join([][]byte{
+		common.EncodeUint64ToBytes(evidence.Height),
+		common.EncodeUint64ToBytes(evidence.Round),
+		[]byte(evidence.MessageType),
+		evidence.Validator.Bytes(),
+	}, nil)
+}
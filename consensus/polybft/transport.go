@@ -59,6 +59,10 @@ func (p *Polybft) createTopics() (err error) {
 
 // Multicast is implementation of core.Transport interface
 func (p *Polybft) Multicast(msg *ibftProto.IbftMessage) {
+	// own messages are recorded as well, so the other node signing with the same key is detected,
+	// they are sent only for the current height
+	p.runtime.equivocationDetector.addMessage(msg, msg.GetView().GetHeight())
+
 	if err := p.consensusTopic.Publish(msg); err != nil {
 		p.logger.Warn("failed to multicast consensus message", "error", err)
 	}
@@ -12,23 +12,30 @@ import (
 )
 
 type Key struct {
-	raw *Account
+	signer Signer
 }
 
 func NewKey(raw *Account) *Key {
 	return &Key{
-		raw: raw,
+		signer: NewLocalSigner(raw),
+	}
+}
+
+// NewKeyWithSigner creates the key whose signatures are made by the given signer
+func NewKeyWithSigner(signer Signer) *Key {
+	return &Key{
+		signer: signer,
 	}
 }
 
 // String returns hex encoded ECDSA address
 func (k *Key) String() string {
-	return k.raw.Ecdsa.Address().String()
+	return k.signer.Address().String()
 }
 
 // Address returns ECDSA address
 func (k *Key) Address() types.Address {
-	return k.raw.Ecdsa.Address()
+	return k.signer.Address()
 }
 
 // Sign signs the provided digest with BLS key
@@ -39,12 +46,18 @@ func (k *Key) Sign(digest []byte) ([]byte, error) {
 
 // SignWithDomain signs the provided digest with BLS key and provided domain
 func (k *Key) SignWithDomain(digest, domain []byte) ([]byte, error) {
-	signature, err := k.raw.Bls.Sign(digest, domain)
-	if err != nil {
-		return nil, err
-	}
+	return k.signer.Sign(&SignRequest{Type: SignTypeBLS, Data: digest, Domain: domain})
+}
 
-	return signature.Marshal()
+// SignCommittedSeal signs the proposal hash with BLS key as the committed seal for the given view
+func (k *Key) SignCommittedSeal(proposalHash []byte, view *proto.View) ([]byte, error) {
+	return k.signer.Sign(&SignRequest{
+		Type:   SignTypeCommittedSeal,
+		Data:   proposalHash,
+		Domain: signer.DomainCheckpointManager,
+		Height: view.Height,
+		Round:  view.Round,
+	})
 }
 
 // SignIBFTMessage signs the IBFT consensus message with ECDSA key
@@ -54,7 +67,7 @@ func (k *Key) SignIBFTMessage(msg *proto.IbftMessage) (*proto.IbftMessage, error
 		return nil, fmt.Errorf("cannot marshal message: %w", err)
 	}
 
-	if msg.Signature, err = k.raw.Ecdsa.Sign(crypto.Keccak256(msgRaw)); err != nil {
+	if msg.Signature, err = k.signer.Sign(&SignRequest{Type: SignTypeIBFTMessage, Data: msgRaw}); err != nil {
 		return nil, fmt.Errorf("cannot create message signature: %w", err)
 	}
 
@@ -72,7 +85,9 @@ func RecoverAddressFromSignature(sig, rawContent []byte) (types.Address, error)
 	return crypto.PubKeyToAddress(pub), nil
 }
 
-// ECDSASigner implements crypto.Key interface and it is used for signing using provided ECDSA key
+var _ crypto.TxKey = (*ECDSASigner)(nil)
+
+// ECDSASigner implements crypto.TxKey interface and it is used for signing the transactions using provided ECDSA key
 type ECDSASigner struct {
 	*Key
 }
@@ -81,6 +96,15 @@ func NewEcdsaSigner(key *Key) *ECDSASigner {
 	return &ECDSASigner{Key: key}
 }
 
-func (k *ECDSASigner) Sign(b []byte) ([]byte, error) {
-	return k.raw.Ecdsa.Sign(b)
+// Sign refuses to sign the arbitrary hash, which could be the hash of a consensus message,
+// the validator key signs only the transactions it can check (see SignTx)
+func (k *ECDSASigner) Sign(_ []byte) ([]byte, error) {
+	return nil, errHashSigning
+}
+
+// SignTx signs the transaction, the signer computes the signing hash from the transaction itself
+func (k *ECDSASigner) SignTx(tx *types.Transaction, chainID uint64) (*types.Transaction, error) {
+	return crypto.NewLondonSigner(chainID).SignTxWithCallback(tx, func(types.Hash) ([]byte, error) {
+		return k.signer.Sign(&SignRequest{Type: SignTypeTransaction, Data: tx.MarshalRLP(), ChainID: chainID})
+	})
 }
@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/0xPolygon/go-ibft/messages/proto"
@@ -8,6 +9,8 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/bls"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/signer"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
 )
 
 func Test_RecoverAddressFromSignature(t *testing.T) {
@@ -47,7 +50,7 @@ func Test_Sign(t *testing.T) {
 		sig, err := bls.UnmarshalSignature(ser)
 		require.NoError(t, err)
 
-		require.True(t, sig.Verify(key.raw.Bls.PublicKey(), msg, signer.DomainCheckpointManager))
+		require.True(t, sig.Verify(account.Bls.PublicKey(), msg, signer.DomainCheckpointManager))
 	}
 }
 
@@ -59,3 +62,32 @@ func Test_String(t *testing.T) {
 		require.Equal(t, key.Address().String(), key.String())
 	}
 }
+
+func Test_EcdsaSigner_SignTx(t *testing.T) {
+	t.Parallel()
+
+	const chainID = 100
+
+	account := generateTestAccount(t)
+	key := NewEcdsaSigner(NewKey(account))
+	to := types.StringToAddress("0x1")
+
+	for _, tx := range []*types.Transaction{
+		types.NewTx(types.NewLegacyTx(types.WithNonce(1), types.WithGas(21000),
+			types.WithGasPrice(big.NewInt(10)), types.WithTo(&to))),
+		types.NewTx(types.NewDynamicFeeTx(types.WithNonce(2), types.WithGas(21000), types.WithTo(&to),
+			types.WithGasFeeCap(big.NewInt(10)), types.WithGasTipCap(big.NewInt(1)),
+			types.WithChainID(big.NewInt(chainID)))),
+	} {
+		signed, err := key.SignTx(tx, chainID)
+		require.NoError(t, err)
+
+		sender, err := crypto.NewLondonSigner(chainID).Sender(signed)
+		require.NoError(t, err)
+		require.Equal(t, account.Address(), sender)
+	}
+
+	// the arbitrary hashes are not signed
+	_, err := key.Sign(crypto.Keccak256([]byte("message")))
+	require.ErrorIs(t, err, errHashSigning)
+}
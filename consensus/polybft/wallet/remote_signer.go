@@ -0,0 +1,182 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/bls"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// RemoteSignerKeysPath is the path of the remote signer endpoint returning the public keys
+	RemoteSignerKeysPath = "/v1/keys"
+	// RemoteSignerSignPath is the path of the remote signer endpoint signing the SignRequest
+	RemoteSignerSignPath = "/v1/sign"
+
+	defaultRemoteSignerTimeout = 5 * time.Second
+)
+
+// RemoteKeysResponse is the response of the remote signer keys endpoint
+type RemoteKeysResponse struct {
+	Address      types.Address `json:"address"`
+	BLSPublicKey []byte        `json:"bls_public_key"`
+}
+
+// RemoteSignResponse is the response of the remote signer sign endpoint
+type RemoteSignResponse struct {
+	Signature []byte `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+var _ Signer = (*RemoteSigner)(nil)
+
+// RemoteSigner signs with the validator keys held by the remote signer over HTTP.
+// The returned signatures are verified against the keys announced by the signer
+type RemoteSigner struct {
+	url    string
+	token  string
+	client *http.Client
+
+	address      types.Address
+	blsPublicKey *bls.PublicKey
+}
+
+// NewRemoteSigner connects to the remote signer at the given url, the token (if any) is sent as a bearer token
+func NewRemoteSigner(url, token string, timeout time.Duration) (*RemoteSigner, error) {
+	if timeout == 0 {
+		timeout = defaultRemoteSignerTimeout
+	}
+
+	s := &RemoteSigner{
+		url:    strings.TrimSuffix(url, "/"),
+		token:  token,
+		client: &http.Client{Timeout: timeout},
+	}
+
+	var keys RemoteKeysResponse
+	if err := s.call(http.MethodGet, RemoteSignerKeysPath, nil, &keys); err != nil {
+		return nil, fmt.Errorf("failed to get remote signer keys: %w", err)
+	}
+
+	blsPublicKey, err := bls.UnmarshalPublicKey(keys.BLSPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote signer bls public key: %w", err)
+	}
+
+	s.address = keys.Address
+	s.blsPublicKey = blsPublicKey
+
+	return s, nil
+}
+
+// Address returns the address of the validator ECDSA key
+func (s *RemoteSigner) Address() types.Address {
+	return s.address
+}
+
+// Sign requests the signature from the remote signer
+func (s *RemoteSigner) Sign(req *SignRequest) ([]byte, error) {
+	var resp RemoteSignResponse
+	if err := s.call(http.MethodPost, RemoteSignerSignPath, req, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := s.verify(req, resp.Signature); err != nil {
+		return nil, fmt.Errorf("invalid remote signature: %w", err)
+	}
+
+	return resp.Signature, nil
+}
+
+func (s *RemoteSigner) verify(req *SignRequest, signature []byte) error {
+	switch req.Type {
+	case SignTypeIBFTMessage, SignTypeTransaction:
+		hash := crypto.Keccak256(req.Data)
+
+		if req.Type == SignTypeTransaction {
+			txHash, err := TransactionHash(req)
+			if err != nil {
+				return err
+			}
+
+			hash = txHash.Bytes()
+		}
+
+		pub, err := crypto.RecoverPubKey(signature, hash)
+		if err != nil {
+			return err
+		}
+
+		if crypto.PubKeyToAddress(pub) != s.address {
+			return errors.New("signed by another key")
+		}
+
+	case SignTypeCommittedSeal, SignTypeBLS:
+		sig, err := bls.UnmarshalSignature(signature)
+		if err != nil {
+			return err
+		}
+
+		if !sig.Verify(s.blsPublicKey, req.Data, req.Domain) {
+			return errors.New("signed by another key")
+		}
+
+	default:
+		return fmt.Errorf("%w: %s", errUnknownSignType, req.Type)
+	}
+
+	return nil
+}
+
+func (s *RemoteSigner) call(method, path string, in, out interface{}) error {
+	var body io.Reader
+
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	httpReq, err := http.NewRequest(method, s.url+path, body)
+	if err != nil {
+		return err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	if s.token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	httpResp, err := s.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		var resp RemoteSignResponse
+
+		_ = json.NewDecoder(httpResp.Body).Decode(&resp)
+
+		if httpResp.StatusCode == http.StatusConflict {
+			return fmt.Errorf("%w: %s", ErrDoubleSign, resp.Error)
+		}
+
+		return fmt.Errorf("remote signer responded with status %d: %s", httpResp.StatusCode, resp.Error)
+	}
+
+	return json.NewDecoder(httpResp.Body).Decode(out)
+}
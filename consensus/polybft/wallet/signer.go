@@ -0,0 +1,129 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// SignType is the kind of the data signed by the validator keys
+type SignType string
+
+const (
+	// SignTypeIBFTMessage is the IBFT consensus message signed with the ECDSA key,
+	// the data is the protobuf encoded message without the signature
+	SignTypeIBFTMessage SignType = "ibft_message"
+	// SignTypeCommittedSeal is the committed seal of the proposal signed with the BLS key,
+	// the data is the proposal hash
+	SignTypeCommittedSeal SignType = "committed_seal"
+	// SignTypeTransaction is the transaction signed with the ECDSA key,
+	// the data is the RLP encoded unsigned transaction whose signing hash is computed by the signer
+	SignTypeTransaction SignType = "transaction"
+	// SignTypeBLS is the arbitrary digest signed with the BLS key and the given domain
+	SignTypeBLS SignType = "bls"
+)
+
+var (
+	// ErrDoubleSign is returned when the signer refuses to sign the conflicting consensus data
+	ErrDoubleSign = errors.New("refused to sign conflicting data")
+
+	errUnknownSignType = errors.New("unknown sign type")
+	errHashSigning     = errors.New("the validator key doesn't sign arbitrary hashes")
+)
+
+// SignRequest is the request for the signature made by the validator keys
+type SignRequest struct {
+	Type   SignType `json:"type"`
+	Data   []byte   `json:"data"`
+	Domain []byte   `json:"domain,omitempty"`
+
+	// Height and Round are the consensus view of the committed seal
+	Height uint64 `json:"height,omitempty"`
+	Round  uint64 `json:"round,omitempty"`
+
+	// ChainID is the chain of the signed transaction
+	ChainID uint64 `json:"chain_id,omitempty"`
+}
+
+// Signer signs the consensus data with the validator ECDSA and BLS keys,
+// which might be held outside the node process
+type Signer interface {
+	// Address returns the address of the validator ECDSA key
+	Address() types.Address
+	// Sign returns the signature of the requested data
+	Sign(req *SignRequest) ([]byte, error)
+}
+
+var _ Signer = (*LocalSigner)(nil)
+
+// LocalSigner signs with the validator keys loaded into the process memory
+type LocalSigner struct {
+	account *Account
+}
+
+// NewLocalSigner creates the signer using the keys of the given account
+func NewLocalSigner(account *Account) *LocalSigner {
+	return &LocalSigner{account: account}
+}
+
+// Address returns the address of the validator ECDSA key
+func (s *LocalSigner) Address() types.Address {
+	return s.account.Address()
+}
+
+// Sign returns the signature of the requested data
+func (s *LocalSigner) Sign(req *SignRequest) ([]byte, error) {
+	switch req.Type {
+	case SignTypeIBFTMessage:
+		return s.account.Ecdsa.Sign(crypto.Keccak256(req.Data))
+	case SignTypeTransaction:
+		hash, err := TransactionHash(req)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.account.Ecdsa.Sign(hash.Bytes())
+	case SignTypeCommittedSeal, SignTypeBLS:
+		signature, err := s.account.Bls.Sign(req.Data, req.Domain)
+		if err != nil {
+			return nil, err
+		}
+
+		return signature.Marshal()
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownSignType, req.Type)
+	}
+}
+
+// DecodeIBFTMessage decodes the IBFT message of the sign request, which is signed without the signature
+func DecodeIBFTMessage(data []byte) (*proto.IbftMessage, error) {
+	msg := &proto.IbftMessage{}
+	if err := protobuf.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal message: %w", err)
+	}
+
+	if msg.View == nil {
+		return nil, errors.New("message without view")
+	}
+
+	if len(msg.Signature) != 0 {
+		return nil, errors.New("message is already signed")
+	}
+
+	return msg, nil
+}
+
+// TransactionHash decodes the unsigned transaction of the sign request and returns its signing hash
+func TransactionHash(req *SignRequest) (types.Hash, error) {
+	tx := &types.Transaction{}
+	if err := tx.UnmarshalRLP(req.Data); err != nil {
+		return types.ZeroHash, fmt.Errorf("cannot unmarshal transaction: %w", err)
+	}
+
+	return crypto.NewLondonSigner(req.ChainID).Hash(tx), nil
+}
@@ -11,6 +11,13 @@ type Key interface {
 	Sign(hash []byte) ([]byte, error)
 }
 
+// TxKey is the key which signs the whole transaction instead of its hash,
+// so that the holder of the private key can check what it signs
+type TxKey interface {
+	Key
+	SignTx(tx *types.Transaction, chainID uint64) (*types.Transaction, error)
+}
+
 var _ Key = (*ECDSAKey)(nil)
 
 type ECDSAKey struct {
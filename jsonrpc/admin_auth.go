@@ -0,0 +1,108 @@
+package jsonrpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// adminJWTMaxAge is the maximal difference between the issued at time of the JWT and the local time
+const adminJWTMaxAge = 60 * time.Second
+
+var errInvalidAdminJWT = errors.New("invalid admin jwt")
+
+// isAdminAuthorized checks the bearer token from the Authorization header.
+// The token is either the admin token itself or the HS256 JWT signed with it,
+// issued within adminJWTMaxAge of the local time
+func isAdminAuthorized(authorization string, adminToken []byte, now time.Time) bool {
+	if len(adminToken) == 0 {
+		return false
+	}
+
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		return false
+	}
+
+	token = strings.TrimSpace(token)
+
+	if subtle.ConstantTimeCompare([]byte(token), adminToken) == 1 {
+		return true
+	}
+
+	return verifyAdminJWT(token, adminToken, now) == nil
+}
+
+// verifyAdminJWT verifies the signature and the issued at and expiration claims of the HS256 JWT
+func verifyAdminJWT(token string, secret []byte, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("%w: malformed token", errInvalidAdminJWT)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return err
+	}
+
+	if header.Alg != "HS256" {
+		return fmt.Errorf("%w: unsupported algorithm %s", errInvalidAdminJWT, header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidAdminJWT, err)
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return fmt.Errorf("%w: invalid signature", errInvalidAdminJWT)
+	}
+
+	var claims struct {
+		IssuedAt  *int64 `json:"iat"`
+		ExpiresAt *int64 `json:"exp"`
+	}
+
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return err
+	}
+
+	if claims.IssuedAt == nil {
+		return fmt.Errorf("%w: missing issued at claim", errInvalidAdminJWT)
+	}
+
+	if age := now.Sub(time.Unix(*claims.IssuedAt, 0)); age > adminJWTMaxAge || age < -adminJWTMaxAge {
+		return fmt.Errorf("%w: stale token", errInvalidAdminJWT)
+	}
+
+	if claims.ExpiresAt != nil && now.After(time.Unix(*claims.ExpiresAt, 0)) {
+		return fmt.Errorf("%w: expired token", errInvalidAdminJWT)
+	}
+
+	return nil
+}
+
+func decodeJWTSegment(segment string, v interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidAdminJWT, err)
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidAdminJWT, err)
+	}
+
+	return nil
+}
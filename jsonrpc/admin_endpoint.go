@@ -0,0 +1,185 @@
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/versioning"
+	"github.com/hashicorp/go-hclog"
+)
+
+var errEmptyExportPath = errors.New("export path is empty")
+
+// PeerInfo is the connected peer returned by the admin store
+type PeerInfo struct {
+	ID        string
+	Addrs     []string
+	Protocols []string
+}
+
+// NodeInfo is the networking information about the node returned by the admin store
+type NodeInfo struct {
+	ID      string
+	P2PAddr string
+	Addrs   []string
+}
+
+// adminStore interface provides access to the methods needed by admin endpoint
+type adminStore interface {
+	// Header returns the current header of the chain
+	Header() *types.Header
+
+	// Genesis returns the hash of the genesis block
+	Genesis() types.Hash
+
+	// GetPeersInfo returns the currently connected peers
+	GetPeersInfo() ([]*PeerInfo, error)
+
+	// JoinPeer marks the peer with the given multiaddr for dialing
+	JoinPeer(rawPeerMultiaddr string) error
+
+	// DisconnectPeer closes the connection to the peer with the given ID,
+	// returns false if the peer is not connected
+	DisconnectPeer(peerID string) (bool, error)
+
+	// GetNodeInfo returns the networking information about the node
+	GetNodeInfo() (*NodeInfo, error)
+
+	// SetLogLevel changes the log level of the node
+	SetLogLevel(level hclog.Level)
+
+	// ExportChain writes the blocks with the given range to the file at the given path,
+	// returns the range of the written blocks
+	ExportChain(path string, from uint64, to *uint64) (uint64, uint64, error)
+}
+
+// Admin is the admin jsonrpc endpoint, registered only if the admin token is set
+type Admin struct {
+	store     adminStore
+	chainID   uint64
+	chainName string
+}
+
+type adminPeer struct {
+	ID        string   `json:"id"`
+	Addrs     []string `json:"addrs"`
+	Protocols []string `json:"protocols"`
+}
+
+type adminNodeInfo struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	P2PAddr   string     `json:"p2pAddr"`
+	Addrs     []string   `json:"addrs"`
+	ChainID   argUint64  `json:"chainId"`
+	ChainName string     `json:"chainName"`
+	Genesis   types.Hash `json:"genesis"`
+	Head      types.Hash `json:"head"`
+	Number    argUint64  `json:"number"`
+	Peers     argUint64  `json:"peers"`
+}
+
+type adminExportResult struct {
+	From argUint64 `json:"from"`
+	To   argUint64 `json:"to"`
+}
+
+// Peers returns the currently connected peers
+func (a *Admin) Peers() (interface{}, error) {
+	peers, err := a.store.GetPeersInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*adminPeer, len(peers))
+
+	for i, p := range peers {
+		result[i] = &adminPeer{
+			ID:        p.ID,
+			Addrs:     p.Addrs,
+			Protocols: p.Protocols,
+		}
+	}
+
+	return result, nil
+}
+
+// AddPeer marks the peer with the given multiaddr for dialing
+func (a *Admin) AddPeer(url string) (interface{}, error) {
+	if err := a.store.JoinPeer(url); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// RemovePeer disconnects from the peer with the given ID
+func (a *Admin) RemovePeer(id string) (interface{}, error) {
+	return a.store.DisconnectPeer(id)
+}
+
+// NodeInfo returns the information about the node and the chain it follows
+func (a *Admin) NodeInfo() (interface{}, error) {
+	info, err := a.store.GetNodeInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	peers, err := a.store.GetPeersInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	header := a.store.Header()
+
+	return &adminNodeInfo{
+		ID:        info.ID,
+		Name:      fmt.Sprintf("blade/%s", versioning.Version),
+		P2PAddr:   info.P2PAddr,
+		Addrs:     info.Addrs,
+		ChainID:   argUint64(a.chainID),
+		ChainName: a.chainName,
+		Genesis:   a.store.Genesis(),
+		Head:      header.Hash,
+		Number:    argUint64(header.Number),
+		Peers:     argUint64(len(peers)),
+	}, nil
+}
+
+// SetLogLevel changes the log level of the node (trace, debug, info, warn or error)
+func (a *Admin) SetLogLevel(level string) (interface{}, error) {
+	logLevel := hclog.LevelFromString(level)
+	if logLevel == hclog.NoLevel || logLevel == hclog.Off {
+		return false, fmt.Errorf("invalid log level: %s", level)
+	}
+
+	a.store.SetLogLevel(logLevel)
+
+	return true, nil
+}
+
+// ExportChain writes the blocks with the given range to the file at the given path on the node,
+// in the format of the backup archive. The blocks up to the latest one are written if to is omitted
+func (a *Admin) ExportChain(path string, from argUint64, to *argUint64) (interface{}, error) {
+	if path == "" {
+		return nil, errEmptyExportPath
+	}
+
+	var toBlock *uint64
+
+	if to != nil {
+		value := uint64(*to)
+		toBlock = &value
+	}
+
+	exportedFrom, exportedTo, err := a.store.ExportChain(path, uint64(from), toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adminExportResult{
+		From: argUint64(exportedFrom),
+		To:   argUint64(exportedTo),
+	}, nil
+}
@@ -0,0 +1,271 @@
+package jsonrpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockAdminStore struct {
+	*mockStore
+
+	joined       []string
+	logLevel     hclog.Level
+	exportedPath string
+}
+
+func (m *mockAdminStore) Genesis() types.Hash {
+	return types.StringToHash("genesis")
+}
+
+func (m *mockAdminStore) GetPeersInfo() ([]*PeerInfo, error) {
+	return []*PeerInfo{
+		{ID: "peer1", Addrs: []string{"/ip4/127.0.0.1/tcp/30301"}, Protocols: []string{"/syncer/0.2"}},
+	}, nil
+}
+
+func (m *mockAdminStore) JoinPeer(rawPeerMultiaddr string) error {
+	m.joined = append(m.joined, rawPeerMultiaddr)
+
+	return nil
+}
+
+func (m *mockAdminStore) DisconnectPeer(peerID string) (bool, error) {
+	return peerID == "peer1", nil
+}
+
+func (m *mockAdminStore) GetNodeInfo() (*NodeInfo, error) {
+	return &NodeInfo{
+		ID:      "node",
+		P2PAddr: "/ip4/127.0.0.1/tcp/30300/p2p/node",
+		Addrs:   []string{"/ip4/127.0.0.1/tcp/30300"},
+	}, nil
+}
+
+func (m *mockAdminStore) SetLogLevel(level hclog.Level) {
+	m.logLevel = level
+}
+
+func (m *mockAdminStore) ExportChain(path string, from uint64, to *uint64) (uint64, uint64, error) {
+	m.exportedPath = path
+
+	if to == nil {
+		return from, m.header.Number, nil
+	}
+
+	return from, *to, nil
+}
+
+func newTestAdminDispatcher(t *testing.T) (*Dispatcher, *mockAdminStore) {
+	t.Helper()
+
+	store := &mockAdminStore{mockStore: newMockStore()}
+	store.header = &types.Header{Number: 15, Hash: types.StringToHash("15")}
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		store,
+		&dispatcherParams{
+			chainID:      100,
+			chainName:    "test",
+			adminEnabled: true,
+		},
+	)
+
+	return dispatcher, store
+}
+
+func TestAdminEndpoint(t *testing.T) {
+	t.Parallel()
+
+	dispatcher, store := newTestAdminDispatcher(t)
+
+	call := func(method, params string) []byte {
+		data, err := dispatcher.HandleWithOrigin([]byte(fmt.Sprintf(
+			`{"method": "%s", "params": %s, "id": 1}`, method, params,
+		)), RequestOrigin{Authorized: true})
+		require.NoError(t, err)
+
+		return data
+	}
+
+	var peers []*adminPeer
+	require.NoError(t, expectJSONResult(call("admin_peers", "[]"), &peers))
+	require.Len(t, peers, 1)
+	require.Equal(t, "peer1", peers[0].ID)
+
+	var ok bool
+	require.NoError(t, expectJSONResult(call("admin_addPeer", `["/ip4/127.0.0.1/tcp/30302/p2p/peer2"]`), &ok))
+	require.True(t, ok)
+	require.Equal(t, []string{"/ip4/127.0.0.1/tcp/30302/p2p/peer2"}, store.joined)
+
+	require.NoError(t, expectJSONResult(call("admin_removePeer", `["peer1"]`), &ok))
+	require.True(t, ok)
+
+	require.NoError(t, expectJSONResult(call("admin_removePeer", `["peer2"]`), &ok))
+	require.False(t, ok)
+
+	var info adminNodeInfo
+	require.NoError(t, expectJSONResult(call("admin_nodeInfo", "[]"), &info))
+	require.Equal(t, "node", info.ID)
+	require.Equal(t, argUint64(100), info.ChainID)
+	require.Equal(t, "test", info.ChainName)
+	require.Equal(t, types.StringToHash("genesis"), info.Genesis)
+	require.Equal(t, argUint64(15), info.Number)
+	require.Equal(t, argUint64(1), info.Peers)
+
+	require.NoError(t, expectJSONResult(call("admin_setLogLevel", `["debug"]`), &ok))
+	require.True(t, ok)
+	require.Equal(t, hclog.Debug, store.logLevel)
+
+	require.Error(t, expectJSONResult(call("admin_setLogLevel", `["verbose"]`), &ok))
+	require.Equal(t, hclog.Debug, store.logLevel)
+
+	var exported adminExportResult
+	require.NoError(t, expectJSONResult(call("admin_exportChain", `["chain.dat", "0x1"]`), &exported))
+	require.Equal(t, adminExportResult{From: 1, To: 15}, exported)
+	require.Equal(t, "chain.dat", store.exportedPath)
+
+	require.NoError(t, expectJSONResult(call("admin_exportChain", `["chain.dat", "0x1", "0x5"]`), &exported))
+	require.Equal(t, adminExportResult{From: 1, To: 5}, exported)
+
+	require.Error(t, expectJSONResult(call("admin_exportChain", `[""]`), &exported))
+}
+
+func TestAdminEndpoint_Unauthorized(t *testing.T) {
+	t.Parallel()
+
+	dispatcher, store := newTestAdminDispatcher(t)
+	request := []byte(`{"method": "admin_addPeer", "params": ["/ip4/127.0.0.1/tcp/30302/p2p/peer2"], "id": 1}`)
+
+	expectUnauthorized := func(data []byte) {
+		t.Helper()
+
+		errResp := new(ErrorResponse)
+		require.NoError(t, json.Unmarshal(data, errResp))
+		require.NotNil(t, errResp.Error)
+		require.Equal(t, -32001, errResp.Error.Code)
+	}
+
+	data, err := dispatcher.Handle(request)
+	require.NoError(t, err)
+	expectUnauthorized(data)
+
+	// batch requests are checked one by one
+	data, err = dispatcher.Handle([]byte(`[` + string(request) + `]`))
+	require.NoError(t, err)
+
+	var batchResp []*ErrorResponse
+	require.NoError(t, json.Unmarshal(data, &batchResp))
+	require.Len(t, batchResp, 1)
+	require.Equal(t, -32001, batchResp[0].Error.Code)
+
+	mockConnection, _ := newMockWsConnWithMsgCh()
+
+	data, err = dispatcher.HandleWs(request, mockConnection)
+	require.NoError(t, err)
+	expectUnauthorized(data)
+
+	require.Empty(t, store.joined)
+
+	// the namespace is not registered unless enabled
+	disabled := newTestDispatcher(t, hclog.NewNullLogger(), store, &dispatcherParams{})
+
+	data, err = disabled.HandleWithOrigin(request, RequestOrigin{Authorized: true})
+	require.NoError(t, err)
+
+	errResp := new(ErrorResponse)
+	require.NoError(t, json.Unmarshal(data, errResp))
+	require.Equal(t, -32601, errResp.Error.Code)
+}
+
+func newTestAdminJWT(t *testing.T, secret []byte, alg string, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestIsAdminAuthorized(t *testing.T) {
+	t.Parallel()
+
+	token := []byte("secret-token")
+	now := time.Unix(1700000000, 0)
+
+	cases := []struct {
+		name          string
+		authorization string
+		adminToken    []byte
+		authorized    bool
+	}{
+		{"bearer token", "Bearer secret-token", token, true},
+		{"wrong bearer token", "Bearer other-token", token, false},
+		{"missing bearer prefix", "secret-token", token, false},
+		{"admin disabled", "Bearer ", nil, false},
+		{
+			"valid jwt",
+			"Bearer " + newTestAdminJWT(t, token, "HS256", map[string]interface{}{"iat": now.Unix() - 30}),
+			token,
+			true,
+		},
+		{
+			"stale jwt",
+			"Bearer " + newTestAdminJWT(t, token, "HS256", map[string]interface{}{"iat": now.Unix() - 61}),
+			token,
+			false,
+		},
+		{
+			"expired jwt",
+			"Bearer " + newTestAdminJWT(t, token, "HS256", map[string]interface{}{"iat": now.Unix(), "exp": now.Unix() - 1}),
+			token,
+			false,
+		},
+		{
+			"jwt without issued at",
+			"Bearer " + newTestAdminJWT(t, token, "HS256", map[string]interface{}{}),
+			token,
+			false,
+		},
+		{
+			"jwt signed with other secret",
+			"Bearer " + newTestAdminJWT(t, []byte("other"), "HS256", map[string]interface{}{"iat": now.Unix()}),
+			token,
+			false,
+		},
+		{
+			"jwt with unsupported algorithm",
+			"Bearer " + newTestAdminJWT(t, token, "none", map[string]interface{}{"iat": now.Unix()}),
+			token,
+			false,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, c.authorized, isAdminAuthorized(c.authorization, c.adminToken, now))
+		})
+	}
+}
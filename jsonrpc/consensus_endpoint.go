@@ -0,0 +1,65 @@
+package jsonrpc
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var errInvalidHeightRange = errors.New("invalid height range")
+
+// consensusStore interface provides access to the methods needed by consensus endpoint
+type consensusStore interface {
+	// GetEquivocationEvidence returns the evidence of the validators signing conflicting consensus messages
+	GetEquivocationEvidence(fromHeight, toHeight uint64) ([]*types.EquivocationEvidence, error)
+}
+
+// Consensus is the consensus jsonrpc endpoint
+type Consensus struct {
+	store           consensusStore
+	blockRangeLimit atomic.Uint64
+}
+
+type equivocationEvidence struct {
+	Validator     types.Address `json:"validator"`
+	Height        argUint64     `json:"height"`
+	Round         argUint64     `json:"round"`
+	MessageType   string        `json:"messageType"`
+	FirstMessage  argBytes      `json:"firstMessage"`
+	SecondMessage argBytes      `json:"secondMessage"`
+	DetectedAt    argUint64     `json:"detectedAt"`
+}
+
+// GetEquivocationEvidence returns the evidence of the validators signing conflicting PREPARE or COMMIT messages
+// for the same height and round, found in the given height range (inclusive)
+func (c *Consensus) GetEquivocationEvidence(fromHeight, toHeight argUint64) (interface{}, error) {
+	if toHeight < fromHeight {
+		return nil, errInvalidHeightRange
+	}
+
+	if limit := c.blockRangeLimit.Load(); limit != 0 && uint64(toHeight-fromHeight) > limit {
+		return nil, ErrBlockRangeTooHigh
+	}
+
+	evidence, err := c.store.GetEquivocationEvidence(uint64(fromHeight), uint64(toHeight))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*equivocationEvidence, len(evidence))
+
+	for i, e := range evidence {
+		result[i] = &equivocationEvidence{
+			Validator:     e.Validator,
+			Height:        argUint64(e.Height),
+			Round:         argUint64(e.Round),
+			MessageType:   e.MessageType,
+			FirstMessage:  argBytes(e.FirstMessage),
+			SecondMessage: argBytes(e.SecondMessage),
+			DetectedAt:    argUint64(e.DetectedAt),
+		}
+	}
+
+	return result, nil
+}
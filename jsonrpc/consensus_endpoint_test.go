@@ -0,0 +1,56 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestConsensusEndpoint_GetEquivocationEvidence(t *testing.T) {
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+		},
+	)
+
+	mockConnection, _ := newMockWsConnWithMsgCh()
+
+	data, err := dispatcher.HandleWs([]byte(`{
+		"method": "consensus_getEquivocationEvidence",
+		"params": ["0xa", "0x14"],
+		"id": 1
+	}`), mockConnection)
+	require.NoError(t, err)
+
+	resp := new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+
+	var evidence []*equivocationEvidence
+	require.NoError(t, json.Unmarshal(resp.Result, &evidence))
+	require.Len(t, evidence, 1)
+	require.Equal(t, types.StringToAddress("1"), evidence[0].Validator)
+	require.Equal(t, argUint64(10), evidence[0].Height)
+	require.Equal(t, "COMMIT", evidence[0].MessageType)
+	require.Equal(t, argBytes{0x2}, evidence[0].SecondMessage)
+
+	for _, params := range []string{`["0x14", "0xa"]`, `["0x0", "0x3e9"]`} {
+		data, err = dispatcher.HandleWs([]byte(`{
+			"method": "consensus_getEquivocationEvidence",
+			"params": `+params+`,
+			"id": 1
+		}`), mockConnection)
+		require.NoError(t, err)
+
+		errResp := new(ErrorResponse)
+		require.NoError(t, json.Unmarshal(data, errResp))
+		require.NotNil(t, errResp.Error)
+	}
+}
@@ -9,11 +9,17 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/fourbytetracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
-const callTracerName = "callTracer"
+const (
+	callTracerName     = "callTracer"
+	prestateTracerName = "prestateTracer"
+	fourByteTracerName = "4byteTracer"
+)
 
 var (
 	defaultTraceTimeout = 5 * time.Second
@@ -48,8 +54,9 @@ type debugBlockchainStore interface {
 	// TraceTxn traces a transaction in the block, associated with the given hash
 	TraceTxn(*types.Block, types.Hash, tracer.Tracer) (interface{}, error)
 
-	// TraceCall traces a single call at the point when the given header is mined
-	TraceCall(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
+	// TraceCall traces a single call at the point when the given header is mined,
+	// with the given state override applied
+	TraceCall(*types.Transaction, *types.Header, types.StateOverride, tracer.Tracer) (interface{}, error)
 }
 
 type debugTxPoolStore interface {
@@ -80,13 +87,26 @@ func NewDebug(store debugStore, requestsPerSecond uint64) *Debug {
 }
 
 type TraceConfig struct {
-	EnableMemory      bool    `json:"enableMemory"`
-	DisableStack      bool    `json:"disableStack"`
-	DisableStorage    bool    `json:"disableStorage"`
-	EnableReturnData  bool    `json:"enableReturnData"`
-	DisableStructLogs bool    `json:"disableStructLogs"`
-	Timeout           *string `json:"timeout"`
-	Tracer            string  `json:"tracer"`
+	EnableMemory      bool          `json:"enableMemory"`
+	DisableStack      bool          `json:"disableStack"`
+	DisableStorage    bool          `json:"disableStorage"`
+	EnableReturnData  bool          `json:"enableReturnData"`
+	DisableStructLogs bool          `json:"disableStructLogs"`
+	Timeout           *string       `json:"timeout"`
+	Tracer            string        `json:"tracer"`
+	TracerConfig      *TracerConfig `json:"tracerConfig"`
+}
+
+// TraceCallConfig extends the TraceConfig with the overrides applied before the call is traced
+type TraceCallConfig struct {
+	TraceConfig
+	StateOverrides *StateOverride  `json:"stateOverrides"`
+	BlockOverrides *BlockOverrides `json:"blockOverrides"`
+}
+
+// TracerConfig holds the options of the named tracers
+type TracerConfig struct {
+	DiffMode bool `json:"diffMode"`
 }
 
 func (d *Debug) TraceBlockByNumber(
@@ -181,16 +201,24 @@ func (d *Debug) TraceTransaction(
 func (d *Debug) TraceCall(
 	arg *txnArgs,
 	filter BlockNumberOrHash,
-	config *TraceConfig,
+	config *TraceCallConfig,
 ) (interface{}, error) {
 	return d.throttling.AttemptRequest(
 		context.Background(),
 		func() (interface{}, error) {
+			if config == nil {
+				return nil, ErrNoConfig
+			}
+
 			header, err := GetHeaderFromBlockNumberOrHash(filter, d.store)
 			if err != nil {
 				return nil, ErrHeaderNotFound
 			}
 
+			if config.BlockOverrides != nil {
+				header = config.BlockOverrides.Apply(header)
+			}
+
 			tx, err := DecodeTxn(arg, d.store, true)
 			if err != nil {
 				return nil, err
@@ -201,14 +229,19 @@ func (d *Debug) TraceCall(
 				tx.SetGas(header.GasLimit)
 			}
 
-			tracer, cancel, err := newTracer(config)
+			var override types.StateOverride
+			if config.StateOverrides != nil {
+				override = config.StateOverrides.ToType()
+			}
+
+			tracer, cancel, err := newTracer(&config.TraceConfig)
 			if err != nil {
 				return nil, err
 			}
 
 			defer cancel()
 
-			return d.store.TraceCall(tx, header, tracer)
+			return d.store.TraceCall(tx, header, override, tracer)
 		},
 	)
 }
@@ -254,9 +287,16 @@ func newTracer(config *TraceConfig) (
 
 	var tracer tracer.Tracer
 
-	if config.Tracer == callTracerName {
+	switch config.Tracer {
+	case callTracerName:
 		tracer = &calltracer.CallTracer{}
-	} else {
+	case prestateTracerName:
+		tracer = prestatetracer.NewPrestateTracer(prestatetracer.Config{
+			DiffMode: config.TracerConfig != nil && config.TracerConfig.DiffMode,
+		})
+	case fourByteTracerName:
+		tracer = fourbytetracer.NewFourByteTracer()
+	default:
 		tracer = structtracer.NewStructTracer(structtracer.Config{
 			EnableMemory:     config.EnableMemory && !config.DisableStructLogs,
 			EnableStack:      !config.DisableStack && !config.DisableStructLogs,
@@ -266,6 +306,12 @@ func newTracer(config *TraceConfig) (
 		})
 	}
 
+	// cancellation of context is done by caller
+	return tracer, cancelOnTimeout(tracer, timeout), nil
+}
+
+// cancelOnTimeout cancels the tracer if the tracing is not finished before the timeout
+func cancelOnTimeout(tracer tracer.Tracer, timeout time.Duration) context.CancelFunc {
 	timeoutCtx, cancel := context.WithTimeout(context.Background(), timeout)
 
 	go func() {
@@ -276,6 +322,5 @@ func newTracer(config *TraceConfig) (
 		}
 	}()
 
-	// cancellation of context is done by caller
-	return tracer, cancel, nil
+	return cancel
 }
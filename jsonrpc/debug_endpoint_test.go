@@ -11,6 +11,9 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/fourbytetracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -23,7 +26,7 @@ type debugEndpointMockStore struct {
 	getBlockByNumberFn  func(uint64, bool) (*types.Block, bool)
 	traceBlockFn        func(*types.Block, tracer.Tracer) ([]interface{}, error)
 	traceTxnFn          func(*types.Block, types.Hash, tracer.Tracer) (interface{}, error)
-	traceCallFn         func(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
+	traceCallFn         func(*types.Transaction, *types.Header, types.StateOverride, tracer.Tracer) (interface{}, error)
 	getNonceFn          func(types.Address) uint64
 	getAccountFn        func(types.Hash, types.Address) (*Account, error)
 }
@@ -56,8 +59,13 @@ func (s *debugEndpointMockStore) TraceTxn(block *types.Block, targetTx types.Has
 	return s.traceTxnFn(block, targetTx, tracer)
 }
 
-func (s *debugEndpointMockStore) TraceCall(tx *types.Transaction, parent *types.Header, tracer tracer.Tracer) (interface{}, error) {
-	return s.traceCallFn(tx, parent, tracer)
+func (s *debugEndpointMockStore) TraceCall(
+	tx *types.Transaction,
+	parent *types.Header,
+	override types.StateOverride,
+	tracer tracer.Tracer,
+) (interface{}, error) {
+	return s.traceCallFn(tx, parent, override, tracer)
 }
 
 func (s *debugEndpointMockStore) GetNonce(acc types.Address) uint64 {
@@ -171,6 +179,20 @@ func TestDebugTraceConfigDecode(t *testing.T) {
 				DisableStructLogs: true,
 			},
 		},
+		{
+			input: `{
+				"tracer": "prestateTracer",
+				"tracerConfig": {
+					"diffMode": true
+				}
+			}`,
+			expected: TraceConfig{
+				Tracer: prestateTracerName,
+				TracerConfig: &TracerConfig{
+					DiffMode: true,
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -592,6 +614,11 @@ func TestTraceCall(t *testing.T) {
 
 		blockNumber = BlockNumber(testBlock10.Number())
 
+		overrideBalance  = argUint64(100)
+		overrideNumber   = argUint64(1000)
+		overrideTime     = argUint64(2000)
+		overrideCoinbase = types.StringToAddress("3")
+
 		txArg = &txnArgs{
 			From:      &from,
 			To:        &to,
@@ -623,7 +650,7 @@ func TestTraceCall(t *testing.T) {
 		name   string
 		arg    *txnArgs
 		filter BlockNumberOrHash
-		config *TraceConfig
+		config *TraceCallConfig
 		store  *debugEndpointMockStore
 		result interface{}
 		err    bool
@@ -634,16 +661,75 @@ func TestTraceCall(t *testing.T) {
 			filter: BlockNumberOrHash{
 				BlockNumber: &blockNumber,
 			},
-			config: &TraceConfig{},
+			config: &TraceCallConfig{},
 			store: &debugEndpointMockStore{
 				getHeaderByNumberFn: func(num uint64) (*types.Header, bool) {
 					assert.Equal(t, testBlock10.Number(), num)
 
 					return testHeader10, true
 				},
-				traceCallFn: func(tx *types.Transaction, header *types.Header, tracer tracer.Tracer) (interface{}, error) {
+				traceCallFn: func(
+					tx *types.Transaction,
+					header *types.Header,
+					override types.StateOverride,
+					tracer tracer.Tracer,
+				) (interface{}, error) {
 					assert.Equal(t, decodedTx, tx)
 					assert.Equal(t, testHeader10, header)
+					assert.Nil(t, override)
+
+					return testTraceResult, nil
+				},
+				headerFn: func() *types.Header {
+					return testLatestHeader
+				},
+				getAccountFn: func(h types.Hash, a types.Address) (*Account, error) {
+					return &Account{Nonce: 1}, nil
+				},
+			},
+			result: testTraceResult,
+			err:    false,
+		},
+		{
+			name: "should trace the given transaction with overrides",
+			arg:  txArg,
+			filter: BlockNumberOrHash{
+				BlockNumber: &blockNumber,
+			},
+			config: &TraceCallConfig{
+				StateOverrides: &StateOverride{
+					to: OverrideAccount{
+						Balance: &overrideBalance,
+					},
+				},
+				BlockOverrides: &BlockOverrides{
+					Number:   &overrideNumber,
+					Time:     &overrideTime,
+					Coinbase: &overrideCoinbase,
+				},
+			},
+			store: &debugEndpointMockStore{
+				getHeaderByNumberFn: func(num uint64) (*types.Header, bool) {
+					assert.Equal(t, testBlock10.Number(), num)
+
+					return testHeader10, true
+				},
+				traceCallFn: func(
+					tx *types.Transaction,
+					header *types.Header,
+					override types.StateOverride,
+					tracer tracer.Tracer,
+				) (interface{}, error) {
+					assert.Equal(t, decodedTx, tx)
+					assert.Equal(t, uint64(overrideNumber), header.Number)
+					assert.Equal(t, uint64(overrideTime), header.Timestamp)
+					assert.Equal(t, overrideCoinbase.Bytes(), header.Miner)
+					assert.Equal(t, testHeader10.StateRoot, header.StateRoot)
+					assert.Equal(t, types.StateOverride{
+						to: types.OverrideAccount{
+							Balance: new(big.Int).SetUint64(uint64(overrideBalance)),
+						},
+					}, override)
 
 					return testTraceResult, nil
 				},
@@ -657,13 +743,22 @@ func TestTraceCall(t *testing.T) {
 			result: testTraceResult,
 			err:    false,
 		},
+		{
+			name:   "should return error if config is missing",
+			arg:    txArg,
+			filter: BlockNumberOrHash{},
+			config: nil,
+			store:  &debugEndpointMockStore{},
+			result: nil,
+			err:    true,
+		},
 		{
 			name: "should return error if block not found",
 			arg:  txArg,
 			filter: BlockNumberOrHash{
 				BlockHash: &testHeader10.Hash,
 			},
-			config: &TraceConfig{},
+			config: &TraceCallConfig{},
 			store: &debugEndpointMockStore{
 				getBlockByHashFn: func(hash types.Hash, full bool) (*types.Block, bool) {
 					assert.Equal(t, testHeader10.Hash, hash)
@@ -691,7 +786,7 @@ func TestTraceCall(t *testing.T) {
 				Nonce:    &nonce,
 			},
 			filter: BlockNumberOrHash{},
-			config: &TraceConfig{},
+			config: &TraceCallConfig{},
 			store: &debugEndpointMockStore{
 				headerFn: func() *types.Header {
 					return testLatestHeader
@@ -747,6 +842,44 @@ func Test_newTracer(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("should create named tracers", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			config   *TraceConfig
+			expected tracer.Tracer
+		}{
+			{
+				config:   &TraceConfig{Tracer: callTracerName},
+				expected: &calltracer.CallTracer{},
+			},
+			{
+				config:   &TraceConfig{Tracer: prestateTracerName},
+				expected: prestatetracer.NewPrestateTracer(prestatetracer.Config{}),
+			},
+			{
+				config: &TraceConfig{
+					Tracer:       prestateTracerName,
+					TracerConfig: &TracerConfig{DiffMode: true},
+				},
+				expected: prestatetracer.NewPrestateTracer(prestatetracer.Config{DiffMode: true}),
+			},
+			{
+				config:   &TraceConfig{Tracer: fourByteTracerName},
+				expected: fourbytetracer.NewFourByteTracer(),
+			},
+		}
+
+		for _, test := range tests {
+			tracer, cancel, err := newTracer(test.config)
+			require.NoError(t, err)
+
+			cancel()
+
+			require.Equal(t, test.expected, tracer)
+		}
+	})
+
 	t.Run("should return error if arg is nil", func(t *testing.T) {
 		t.Parallel()
 
@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 
@@ -39,13 +40,16 @@ func (f *funcData) numParams() int {
 }
 
 type endpoints struct {
-	Eth      *Eth
-	Web3     *Web3
-	Net      *Net
-	TxPool   *TxPool
-	Bridge   *Bridge
-	Debug    *Debug
-	Personal *Personal
+	Eth       *Eth
+	Web3      *Web3
+	Net       *Net
+	TxPool    *TxPool
+	Bridge    *Bridge
+	Debug     *Debug
+	Personal  *Personal
+	Trace     *Trace
+	Consensus *Consensus
+	Admin     *Admin
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -56,7 +60,20 @@ type Dispatcher struct {
 	filterManager *FilterManager
 	endpoints     endpoints
 
-	params *dispatcherParams
+	params     *dispatcherParams
+	paramsLock sync.RWMutex
+
+	rateLimiter  *rateLimiter
+	methodFilter *methodFilter
+}
+
+// RequestOrigin describes the client the request is received from
+type RequestOrigin struct {
+	// ClientID identifies the client by the rate limits, it is either the API key or the IP address
+	ClientID string
+
+	// Authorized is set if the request is authorized by the admin token
+	Authorized bool
 }
 
 type dispatcherParams struct {
@@ -68,6 +85,13 @@ type dispatcherParams struct {
 	blockRangeLimit         uint64
 
 	concurrentRequestsDebug uint64
+
+	adminEnabled bool
+
+	clientRateLimit  *RateLimit
+	methodRateLimits map[string]*RateLimit
+	allowedMethods   []string
+	deniedMethods    []string
 }
 
 func (dp dispatcherParams) isExceedingBatchLengthLimit(value uint64) bool {
@@ -81,8 +105,10 @@ func newDispatcher(
 	manager accounts.AccountManager,
 ) (*Dispatcher, error) {
 	d := &Dispatcher{
-		logger: logger.Named("dispatcher"),
-		params: params,
+		logger:       logger.Named("dispatcher"),
+		params:       params,
+		rateLimiter:  newRateLimiter(params.clientRateLimit, params.methodRateLimits),
+		methodFilter: newMethodFilter(params.allowedMethods, params.deniedMethods),
 	}
 
 	if store != nil {
@@ -97,15 +123,41 @@ func newDispatcher(
 	return d, nil
 }
 
+// UpdateParams applies the parameters which can be changed while the dispatcher is running
+func (d *Dispatcher) UpdateParams(params *RuntimeParams) {
+	d.paramsLock.Lock()
+	defer d.paramsLock.Unlock()
+
+	d.params.priceLimit = params.PriceLimit
+	d.params.jsonRPCBatchLengthLimit = params.BatchLengthLimit
+	d.params.blockRangeLimit = params.BlockRangeLimit
+
+	d.endpoints.Eth.priceLimit.Store(params.PriceLimit)
+	d.endpoints.Trace.blockRangeLimit.Store(params.BlockRangeLimit)
+	d.endpoints.Consensus.blockRangeLimit.Store(params.BlockRangeLimit)
+
+	if d.filterManager != nil {
+		d.filterManager.blockRangeLimit.Store(params.BlockRangeLimit)
+	}
+}
+
+// isExceedingBatchLengthLimit checks if the batch request is longer than the current limit
+func (d *Dispatcher) isExceedingBatchLengthLimit(value uint64) bool {
+	d.paramsLock.RLock()
+	defer d.paramsLock.RUnlock()
+
+	return d.params.isExceedingBatchLengthLimit(value)
+}
+
 func (d *Dispatcher) registerEndpoints(store JSONRPCStore, manager accounts.AccountManager) error {
 	d.endpoints.Eth = &Eth{
-		d.logger,
-		store,
-		d.params.chainID,
-		d.filterManager,
-		d.params.priceLimit,
-		manager,
+		logger:        d.logger,
+		store:         store,
+		chainID:       d.params.chainID,
+		filterManager: d.filterManager,
+		accManager:    manager,
 	}
+	d.endpoints.Eth.priceLimit.Store(d.params.priceLimit)
 	d.endpoints.Net = &Net{
 		store,
 		d.params.chainID,
@@ -120,7 +172,12 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore, manager accounts.Acco
 		store,
 	}
 	d.endpoints.Debug = NewDebug(store, d.params.concurrentRequestsDebug)
-	d.endpoints.Personal = NewPersonal(manager)
+	d.endpoints.Personal = NewPersonal(manager, store)
+	d.endpoints.Trace = NewTrace(store, d.endpoints.Debug.throttling, d.params.blockRangeLimit)
+	d.endpoints.Consensus = &Consensus{
+		store: store,
+	}
+	d.endpoints.Consensus.blockRangeLimit.Store(d.params.blockRangeLimit)
 
 	var err error
 
@@ -148,7 +205,29 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore, manager accounts.Acco
 		return err
 	}
 
-	return d.registerService("debug", d.endpoints.Debug)
+	if err = d.registerService("debug", d.endpoints.Debug); err != nil {
+		return err
+	}
+
+	if err = d.registerService("trace", d.endpoints.Trace); err != nil {
+		return err
+	}
+
+	if err = d.registerService("consensus", d.endpoints.Consensus); err != nil {
+		return err
+	}
+
+	if !d.params.adminEnabled {
+		return nil
+	}
+
+	d.endpoints.Admin = &Admin{
+		store:     store,
+		chainID:   d.params.chainID,
+		chainName: d.params.chainName,
+	}
+
+	return d.registerService("admin", d.endpoints.Admin)
 }
 
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
@@ -177,6 +256,7 @@ type wsConn interface {
 	WriteMessage(messageType int, data []byte) error
 	GetFilterID() string
 	SetFilterID(string)
+	GetClientID() string
 }
 
 // as per https://www.jsonrpc.org/specification, the `id` in JSON-RPC 2.0
@@ -224,7 +304,19 @@ func (d *Dispatcher) handleSubscribe(req Request, conn wsConn) (string, Error) {
 
 		filterID = d.filterManager.NewLogFilter(logQuery, conn)
 	} else if subscribeMethod == "newPendingTransactions" {
-		filterID = d.filterManager.NewPendingTxFilter(conn)
+		fullTx := false
+
+		if len(params) > 1 {
+			if fullTx, ok = params[1].(bool); !ok {
+				return "", NewInvalidParamsError("Invalid fullTx param")
+			}
+		}
+
+		if fullTx {
+			filterID = d.filterManager.NewPendingFullTxFilter(conn)
+		} else {
+			filterID = d.filterManager.NewPendingTxFilter(conn)
+		}
 	} else {
 		return "", NewSubscriptionNotFoundError(subscribeMethod)
 	}
@@ -274,7 +366,7 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 		}
 
 		// if not disabled, avoid handling long batch requests
-		if d.params.isExceedingBatchLengthLimit(uint64(len(batchReq))) {
+		if d.isExceedingBatchLengthLimit(uint64(len(batchReq))) {
 			return NewRPCResponse(
 				nil,
 				"2.0",
@@ -317,6 +409,10 @@ func (d *Dispatcher) handleSingleWs(req Request, conn wsConn) Response {
 		return NewRPCResponse(nil, "2.0", nil, err)
 	}
 
+	if err = d.checkRequest(req, RequestOrigin{ClientID: conn.GetClientID()}); err != nil {
+		return NewRPCResponse(id, "2.0", nil, err)
+	}
+
 	var response []byte
 
 	switch req.Method {
@@ -341,7 +437,13 @@ func (d *Dispatcher) handleSingleWs(req Request, conn wsConn) Response {
 	return NewRPCResponse(id, "2.0", response, err)
 }
 
+// Handle handles the HTTP request of the unknown origin, the admin namespace is not available to it
 func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
+	return d.HandleWithOrigin(reqBody, RequestOrigin{})
+}
+
+// HandleWithOrigin handles the HTTP request received from the given origin
+func (d *Dispatcher) HandleWithOrigin(reqBody []byte, origin RequestOrigin) ([]byte, error) {
 	x := bytes.TrimLeft(reqBody, " \t\r\n")
 	if len(x) == 0 {
 		return NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
@@ -357,7 +459,7 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 			return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 		}
 
-		resp, err := d.handleReq(req)
+		resp, err := d.handleRequest(req, origin)
 
 		return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
 	}
@@ -374,7 +476,7 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	}
 
 	// if not disabled, avoid handling long batch requests
-	if d.params.isExceedingBatchLengthLimit(uint64(len(requests))) {
+	if d.isExceedingBatchLengthLimit(uint64(len(requests))) {
 		return NewRPCResponse(
 			nil,
 			"2.0",
@@ -386,7 +488,7 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	responses := make([]Response, 0)
 
 	for _, req := range requests {
-		var response, err = d.handleReq(req)
+		var response, err = d.handleRequest(req, origin)
 		if err != nil {
 			errorResponse := NewRPCResponse(req.ID, "2.0", response, err)
 			responses = append(responses, errorResponse)
@@ -406,6 +508,52 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	return respBytes, nil
 }
 
+// handleRequest checks the request received from the given origin and handles it
+func (d *Dispatcher) handleRequest(req Request, origin RequestOrigin) ([]byte, Error) {
+	if err := d.checkRequest(req, origin); err != nil {
+		return nil, err
+	}
+
+	return d.handleReq(req)
+}
+
+// checkRequest refuses the admin requests which are not authorized,
+// the requests of the methods which are not allowed and the requests exceeding the rate limits
+func (d *Dispatcher) checkRequest(req Request, origin RequestOrigin) Error {
+	if !origin.Authorized && strings.HasPrefix(req.Method, "admin_") {
+		return NewUnauthorizedError(req.Method)
+	}
+
+	if !d.methodFilter.isAllowed(req.Method) {
+		metrics.IncrCounter([]string{jsonRPCMetric, d.methodMetricName(req.Method) + "_denied"}, 1)
+
+		return NewMethodNotAllowedError(req.Method)
+	}
+
+	if d.rateLimiter != nil && !d.rateLimiter.allow(origin.ClientID, req.Method, time.Now()) {
+		metrics.IncrCounter([]string{jsonRPCMetric, d.methodMetricName(req.Method) + "_rate_limited"}, 1)
+
+		return NewLimitExceededError(req.Method)
+	}
+
+	return nil
+}
+
+// methodMetricName returns the method name for the metrics of the refused requests,
+// the unknown methods are reported together to keep the number of the metrics bounded
+func (d *Dispatcher) methodMetricName(method string) string {
+	if _, _, err := d.getFnHandler(Request{Method: method}); err != nil {
+		switch method {
+		case "eth_subscribe", "eth_unsubscribe":
+			return method
+		default:
+			return "unknown"
+		}
+	}
+
+	return method
+}
+
 func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 	d.logger.Trace("request", "method", req.Method, "id", req.ID)
 
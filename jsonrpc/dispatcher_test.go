@@ -120,6 +120,37 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 			t.Fatal("\"newPendingTransactions\" event not received in 2 seconds")
 		}
 	})
+	t.Run("clients should be able to receive full pending transactions through eth_subscribe", func(t *testing.T) {
+		t.Parallel()
+
+		tx := createTestTransaction(types.StringToHash("a1"))
+		store.addPendingTx(tx)
+
+		mockConnection, msgCh := newMockWsConnWithMsgCh()
+
+		req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["newPendingTransactions", true]
+	}`)
+		_, err := dispatcher.HandleWs(req, mockConnection)
+		require.NoError(t, err)
+
+		store.emitTxPoolEvent(proto.EventType_ADDED, tx.Hash().String())
+
+		select {
+		case msg := <-msgCh:
+			var notification struct {
+				Params struct {
+					Result *transaction `json:"result"`
+				} `json:"params"`
+			}
+
+			require.NoError(t, json.Unmarshal(msg, &notification))
+			require.Equal(t, tx.Hash(), notification.Params.Result.Hash)
+		case <-time.After(2 * time.Second):
+			t.Fatal("full \"newPendingTransactions\" event not received in 2 seconds")
+		}
+	})
 }
 
 func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
@@ -559,6 +590,49 @@ func TestDispatcher_WebsocketConnection_Unsubscribe(t *testing.T) {
 	assert.Equal(t, "true", string(resp.Result))
 }
 
+func TestDispatcher_UpdateParams(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 3,
+			blockRangeLimit:         1000,
+		},
+	)
+
+	batchReq := []byte(`[
+		{"id":1,"jsonrpc":"2.0","method":"eth_chainId","params":[]},
+		{"id":2,"jsonrpc":"2.0","method":"eth_chainId","params":[]}]`)
+
+	var res []SuccessResponse
+
+	resp, err := dispatcher.Handle(batchReq)
+	require.NoError(t, err)
+	require.NoError(t, expectBatchJSONResult(resp, &res))
+	require.Len(t, res, 2)
+
+	dispatcher.UpdateParams(&RuntimeParams{
+		PriceLimit:       100,
+		BatchLengthLimit: 1,
+		BlockRangeLimit:  10,
+	})
+
+	resp, err = dispatcher.Handle(batchReq)
+	require.NoError(t, err)
+
+	var errResp ErrorResponse
+
+	require.NoError(t, json.Unmarshal(resp, &errResp))
+	require.Equal(t, "Batch request length too long", errResp.Error.Message)
+
+	require.Equal(t, uint64(100), dispatcher.endpoints.Eth.priceLimit.Load())
+	require.Equal(t, uint64(10), dispatcher.endpoints.Trace.blockRangeLimit.Load())
+	require.Equal(t, uint64(10), dispatcher.endpoints.Consensus.blockRangeLimit.Load())
+	require.Equal(t, uint64(10), dispatcher.filterManager.blockRangeLimit.Load())
+}
+
 func newTestDispatcher(tb testing.TB, logger hclog.Logger, store JSONRPCStore, params *dispatcherParams) *Dispatcher {
 	tb.Helper()
 
@@ -10,6 +10,7 @@ import (
 
 var (
 	ErrStateNotFound = errors.New("given root and slot not found in storage")
+	ErrEmptyBundle   = errors.New("bundle has no calls")
 )
 
 type Error interface {
@@ -76,9 +77,58 @@ func (e *methodNotFoundError) ErrorCode() int {
 	return -32601
 }
 
+type unauthorizedError struct {
+	err string
+}
+
+func (e *unauthorizedError) Error() string {
+	return e.err
+}
+
+func (e *unauthorizedError) ErrorCode() int {
+	return -32001
+}
+
+type methodNotAllowedError struct {
+	err string
+}
+
+func (e *methodNotAllowedError) Error() string {
+	return e.err
+}
+
+func (e *methodNotAllowedError) ErrorCode() int {
+	return -32004
+}
+
+type limitExceededError struct {
+	err string
+}
+
+func (e *limitExceededError) Error() string {
+	return e.err
+}
+
+func (e *limitExceededError) ErrorCode() int {
+	return -32005
+}
+
 func NewMethodNotFoundError(method string) *methodNotFoundError {
 	return &methodNotFoundError{fmt.Sprintf("the method %s does not exist/is not available", method)}
 }
+
+func NewUnauthorizedError(method string) *unauthorizedError {
+	return &unauthorizedError{fmt.Sprintf("the method %s requires the admin authorization", method)}
+}
+
+func NewMethodNotAllowedError(method string) *methodNotAllowedError {
+	return &methodNotAllowedError{fmt.Sprintf("the method %s is not allowed", method)}
+}
+
+func NewLimitExceededError(method string) *limitExceededError {
+	return &limitExceededError{fmt.Sprintf("rate limit exceeded for the method %s", method)}
+}
+
 func NewInvalidRequestError(msg string) *invalidRequestError {
 	return &invalidRequestError{msg}
 }
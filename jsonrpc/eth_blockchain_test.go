@@ -14,6 +14,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEth_Block_GetBlockByNumber(t *testing.T) {
@@ -625,6 +626,80 @@ func TestEth_Call(t *testing.T) {
 	})
 }
 
+func TestEth_CallBundle(t *testing.T) {
+	t.Parallel()
+
+	newCall := func(to *types.Address) *BundleCall {
+		return &BundleCall{
+			txnArgs: txnArgs{
+				From:     &addr0,
+				To:       to,
+				Gas:      argUintPtr(100000),
+				GasPrice: argBytesPtr([]byte{0x64}),
+				Value:    argBytesPtr([]byte{0x64}),
+			},
+		}
+	}
+
+	t.Run("returns error if bundle is empty", func(t *testing.T) {
+		t.Parallel()
+
+		eth := newTestEthEndpoint(newMockBlockStore())
+
+		res, err := eth.CallBundle(nil, BlockNumberOrHash{})
+
+		assert.ErrorIs(t, err, ErrEmptyBundle)
+		assert.Nil(t, res)
+	})
+
+	t.Run("returns the results of all the calls", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		store.add(newTestBlock(100, hash1))
+		store.returnValue = []byte{0x1}
+		eth := newTestEthEndpoint(store)
+
+		res, err := eth.CallBundle([]*BundleCall{newCall(&addr1), newCall(&addr2)}, BlockNumberOrHash{})
+		require.NoError(t, err)
+
+		results, ok := res.([]*BundleCallResult)
+		require.True(t, ok)
+		require.Len(t, results, 2)
+
+		for i, result := range results {
+			assert.Equal(t, argBytes{0x1}, result.ReturnData)
+			assert.Equal(t, argUint64(100000), result.GasUsed)
+			assert.Equal(t, argUint64(types.ReceiptSuccess), result.Status)
+			assert.Empty(t, result.Error)
+
+			require.Len(t, result.Logs, 1)
+			assert.Equal(t, argUint64(i), result.Logs[0].LogIndex)
+			assert.Equal(t, argUint64(i), result.Logs[0].TxIndex)
+		}
+
+		assert.Equal(t, addr1, results[0].Logs[0].Address)
+		assert.Equal(t, addr2, results[1].Logs[0].Address)
+	})
+
+	t.Run("returns the error of a failed call", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		store.add(newTestBlock(100, hash1))
+		store.ethCallError = runtime.ErrOutOfGas
+		eth := newTestEthEndpoint(store)
+
+		res, err := eth.CallBundle([]*BundleCall{newCall(&addr1)}, BlockNumberOrHash{})
+		require.NoError(t, err)
+
+		results, ok := res.([]*BundleCallResult)
+		require.True(t, ok)
+		require.Len(t, results, 1)
+		assert.Equal(t, argUint64(types.ReceiptFailed), results[0].Status)
+		assert.Equal(t, runtime.ErrOutOfGas.Error(), results[0].Error)
+	})
+}
 func TestEth_CreateAccessList(t *testing.T) {
 	store := newMockBlockStore()
 	hashs := make([]types.Hash, 10)
@@ -851,6 +926,19 @@ func (m *mockBlockStore) GetBlockByNumber(blockNumber uint64, full bool) (*types
 	return nil, false
 }
 
+func (m *mockBlockStore) GetLogIndexCandidates(
+	from, to uint64,
+	addresses []types.Address,
+	topics [][]types.Hash,
+) []uint64 {
+	candidates := make([]uint64, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		candidates = append(candidates, i)
+	}
+
+	return candidates
+}
+
 func (m *mockBlockStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
 	for _, b := range m.blocks {
 		if b.Hash() == hash {
@@ -912,6 +1000,30 @@ func (m *mockBlockStore) ApplyTxn(_ *types.Header, _ *types.Transaction, _ types
 	}, nil
 }
 
+func (m *mockBlockStore) ApplyTxnBundle(
+	_ *types.Header,
+	txns []*types.Transaction,
+	_ []types.StateOverride,
+	_ bool,
+) ([]*AppliedTxn, error) {
+	applied := make([]*AppliedTxn, len(txns))
+
+	for i, txn := range txns {
+		applied[i] = &AppliedTxn{
+			Result: &runtime.ExecutionResult{
+				Err:         m.ethCallError,
+				ReturnValue: m.returnValue,
+				GasUsed:     txn.Gas(),
+			},
+			Logs: []*types.Log{
+				{Address: *txn.To()},
+			},
+		}
+	}
+
+	return applied, nil
+}
+
 func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
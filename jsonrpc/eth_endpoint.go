@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 
 	"github.com/hashicorp/go-hclog"
 
@@ -14,10 +15,16 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+const (
+	// maxProofStorageKeys is the maximum number of the storage slots proven by one eth_getProof call
+	maxProofStorageKeys = 1024
+)
+
 type ethTxPoolStore interface {
 	// AddTx adds a new transaction to the tx pool
 	AddTx(tx *types.Transaction) error
@@ -37,11 +44,18 @@ type Account struct {
 	Nonce   uint64
 }
 
+// AppliedTxn is the outcome of a transaction applied as a part of a bundle
+type AppliedTxn struct {
+	Result *runtime.ExecutionResult
+	Logs   []*types.Log
+}
+
 type ethStateStore interface {
 	GetAccount(root types.Hash, addr types.Address) (*Account, error)
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
 	GetForksInTime(blockNumber uint64) chain.ForksInTime
 	GetCode(root types.Hash, addr types.Address) ([]byte, error)
+	GetProof(root types.Hash, addr types.Address, keys []types.Hash) (*itrie.AccountProof, error)
 }
 
 type ethBlockchainStore interface {
@@ -77,6 +91,15 @@ type ethBlockchainStore interface {
 		nonPayable bool,
 	) (*runtime.ExecutionResult, error)
 
+	// ApplyTxnBundle applies the transactions one after another on top of the state of the given header.
+	// The state override of each transaction is applied right before the transaction
+	ApplyTxnBundle(
+		header *types.Header,
+		txns []*types.Transaction,
+		overrides []types.StateOverride,
+		nonPayable bool,
+	) ([]*AppliedTxn, error)
+
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
 }
@@ -101,7 +124,7 @@ type Eth struct {
 	store         ethStore
 	chainID       uint64
 	filterManager *FilterManager
-	priceLimit    uint64
+	priceLimit    atomic.Uint64
 	accManager    accounts.AccountManager
 }
 
@@ -390,6 +413,25 @@ func (e *Eth) Sign(from types.Address, buf argBytes) (interface{}, error) {
 	return argBytesPtr(signature), err
 }
 
+// SignTypedData_v4 signs the EIP-712 typed data with the unlocked account, exposed as eth_signTypedData_v4
+func (e *Eth) SignTypedData_v4(from types.Address, typedData accounts.TypedData) (interface{}, error) { //nolint:stylecheck
+	account := accounts.Account{Address: from}
+
+	wallet, err := e.accManager.Find(account)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := wallet.SignTypedData(account, &typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	signature[64] += 27 // Transform V from 0/1 to 27/28 according to the yellow paper
+
+	return argBytesPtr(signature), nil
+}
+
 // GetTransactionByHash returns a transaction by its hash.
 // If the transaction is still pending -> return the txn with some fields omitted
 // If the transaction is sealed into a block -> return the whole txn with all fields
@@ -574,6 +616,29 @@ func (e *Eth) GetStorageAt(
 	return argBytesPtr(result), nil
 }
 
+// GetProof returns the Merkle proof of the account and its storage slots at the referenced block (EIP-1186)
+func (e *Eth) GetProof(
+	address types.Address,
+	keys []types.Hash,
+	filter BlockNumberOrHash,
+) (interface{}, error) {
+	if len(keys) > maxProofStorageKeys {
+		return nil, fmt.Errorf("too many storage keys, the limit is %d", maxProofStorageKeys)
+	}
+
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := e.store.GetProof(header.StateRoot, address, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAccountProofResult(proof), nil
+}
+
 // GasPrice exposes "getGasPrice"'s function logic to public RPC interface
 func (e *Eth) GasPrice() (interface{}, error) {
 	gasPrice, err := e.getGasPrice()
@@ -594,13 +659,13 @@ func (e *Eth) getGasPrice() (uint64, error) {
 			return 0, err
 		}
 
-		return common.Max(e.priceLimit, priorityFee.Uint64()+e.store.GetBaseFee()), nil
+		return common.Max(e.priceLimit.Load(), priorityFee.Uint64()+e.store.GetBaseFee()), nil
 	}
 
 	// Fetch average gas price in uint64
 	avgGasPrice := e.store.GetAvgGasPrice().Uint64()
 
-	return common.Max(e.priceLimit, avgGasPrice), nil
+	return common.Max(e.priceLimit.Load(), avgGasPrice), nil
 }
 
 // fillTransactionGasPrice fills transaction gas price if no provided
@@ -647,10 +712,7 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *StateOve
 
 	var override types.StateOverride
 	if apiOverride != nil {
-		override = types.StateOverride{}
-		for addr, o := range *apiOverride {
-			override[addr] = o.ToType()
-		}
+		override = apiOverride.ToType()
 	}
 
 	// The return value of the execution is saved in the transition (returnValue field)
@@ -671,6 +733,72 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *StateOve
 	return argBytesPtr(result.ReturnValue), nil
 }
 
+// CallBundle executes the given calls in order, each on top of the state changes made by the previous ones
+func (e *Eth) CallBundle(calls []*BundleCall, filter BlockNumberOrHash) (interface{}, error) {
+	if len(calls) == 0 {
+		return nil, ErrEmptyBundle
+	}
+
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	txns := make([]*types.Transaction, len(calls))
+	overrides := make([]types.StateOverride, len(calls))
+
+	for i, call := range calls {
+		if call == nil {
+			return nil, fmt.Errorf("missing call %d", i)
+		}
+
+		// the gas limit left unset is filled with the remaining block gas when the call is applied
+		if txns[i], err = DecodeTxn(&call.txnArgs, e.store, true); err != nil {
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+
+		// Force transaction gas price if empty
+		if err = e.fillTransactionGasPrice(txns[i]); err != nil {
+			return nil, err
+		}
+
+		if call.StateOverrides != nil {
+			overrides[i] = call.StateOverrides.ToType()
+		}
+	}
+
+	applied, err := e.store.ApplyTxnBundle(header, txns, overrides, true)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*BundleCallResult, len(applied))
+	logIdx := uint64(0)
+
+	for i, txn := range applied {
+		result := &BundleCallResult{
+			ReturnData: argBytes(txn.Result.ReturnValue),
+			Logs:       toLogs(txn.Logs, logIdx, uint64(i), header, txns[i].Hash()),
+			GasUsed:    argUint64(txn.Result.GasUsed),
+			Status:     argUint64(types.ReceiptSuccess),
+		}
+
+		switch {
+		case txn.Result.Reverted():
+			result.Status = argUint64(types.ReceiptFailed)
+			result.Error = constructErrorFromRevert(txn.Result).Error()
+		case txn.Result.Failed():
+			result.Status = argUint64(types.ReceiptFailed)
+			result.Error = txn.Result.Err.Error()
+		}
+
+		logIdx += uint64(len(txn.Logs))
+		results[i] = result
+	}
+
+	return results, nil
+}
+
 // EstimateGas estimates the gas needed to execute a transaction
 func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error) {
 	number := LatestBlockNumber
@@ -1069,7 +1197,7 @@ func (e *Eth) FeeHistory(blockCount argUint64, newestBlock BlockNumber,
 
 // signTx signs a transaction with the account's private key if it exists in store
 func (e *Eth) signTx(args *txnArgs) (*types.Transaction, error) {
-	if err := args.setDefaults(e.priceLimit, e); err != nil {
+	if err := args.setDefaults(e.priceLimit.Load(), e); err != nil {
 		return nil, err
 	}
 
@@ -1080,10 +1208,10 @@ func (e *Eth) signTx(args *txnArgs) (*types.Transaction, error) {
 
 	account := accounts.Account{Address: tx.From()}
 
-	keyStore, err := getKeystore(e.accManager)
+	wallet, err := e.accManager.Find(account)
 	if err != nil {
 		return nil, err
 	}
 
-	return keyStore.SignTx(account, tx)
+	return wallet.SignTx(account, tx)
 }
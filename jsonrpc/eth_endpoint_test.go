@@ -288,14 +288,17 @@ func TestEth_TxnType(t *testing.T) {
 
 func newTestEthEndpoint(store testStore) *Eth {
 	return &Eth{
-		hclog.NewNullLogger(), store, 100, nil, 0, nil,
+		logger:  hclog.NewNullLogger(),
+		store:   store,
+		chainID: 100,
 	}
 }
 
 func newTestEthEndpointWithPriceLimit(store testStore, priceLimit uint64) *Eth {
-	return &Eth{
-		hclog.NewNullLogger(), store, 100, nil, priceLimit, nil,
-	}
+	eth := newTestEthEndpoint(store)
+	eth.priceLimit.Store(priceLimit)
+
+	return eth
 }
 
 func TestEth_HeaderResolveBlock(t *testing.T) {
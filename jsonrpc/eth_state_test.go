@@ -8,9 +8,11 @@ import (
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -830,6 +832,86 @@ func TestEth_EstimateGas_ContractCreation(t *testing.T) {
 	assert.Equal(t, state.TxGasContractCreation, uint64(estimateUint64))
 }
 
+func TestEth_State_GetProof(t *testing.T) {
+	t.Parallel()
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+
+	_, root, err := st.NewSnapshot().Commit([]*state.Object{
+		{
+			Address:  addr0,
+			Balance:  big.NewInt(100),
+			Nonce:    5,
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+			Storage: []*state.StorageObject{
+				{Key: hash1.Bytes(), Val: []byte{0x1, 0x2}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	store := &mockProofStore{
+		mockSpecialStore: mockSpecialStore{
+			block: &types.Block{
+				Header: &types.Header{
+					Number:    0,
+					StateRoot: types.BytesToHash(root),
+				},
+			},
+		},
+		state: st,
+	}
+
+	eth := newTestEthEndpoint(store)
+	latest := LatestBlockNumber
+
+	res, err := eth.GetProof(addr0, []types.Hash{hash1, hash2}, BlockNumberOrHash{BlockNumber: &latest})
+	require.NoError(t, err)
+
+	result, ok := res.(*accountProofResult)
+	require.True(t, ok)
+	require.Equal(t, big.NewInt(100), (*big.Int)(&result.Balance))
+	require.Equal(t, argUint64(5), result.Nonce)
+	require.Equal(t, types.EmptyCodeHash, result.CodeHash)
+	require.NotEqual(t, types.EmptyRootHash, result.StorageHash)
+	require.Len(t, result.StorageProof, 2)
+	require.Equal(t, big.NewInt(0x102), (*big.Int)(&result.StorageProof[0].Value))
+	require.Zero(t, (*big.Int)(&result.StorageProof[1].Value).Sign())
+
+	// the returned proofs are verifiable against the state root
+	accountProof := make([][]byte, len(result.AccountProof))
+	for i, node := range result.AccountProof {
+		accountProof[i] = node
+	}
+
+	account, err := itrie.VerifyAccountProof(types.BytesToHash(root), addr0, accountProof)
+	require.NoError(t, err)
+	require.Equal(t, result.StorageHash, account.Root)
+
+	// the missing account has the empty values
+	res, err = eth.GetProof(uninitializedAddress, nil, BlockNumberOrHash{BlockNumber: &latest})
+	require.NoError(t, err)
+
+	result, ok = res.(*accountProofResult)
+	require.True(t, ok)
+	require.Equal(t, types.EmptyRootHash, result.StorageHash)
+	require.Empty(t, result.StorageProof)
+
+	_, err = eth.GetProof(addr0, make([]types.Hash, maxProofStorageKeys+1), BlockNumberOrHash{BlockNumber: &latest})
+	require.Error(t, err)
+}
+
+type mockProofStore struct {
+	mockSpecialStore
+
+	state *itrie.State
+}
+
+func (m *mockProofStore) GetProof(root types.Hash, addr types.Address, keys []types.Hash) (*itrie.AccountProof, error) {
+	return m.state.GetProof(root, addr, keys)
+}
+
 type mockSpecialStore struct {
 	ethStore
 	account *mockAccount
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -244,7 +245,11 @@ type pendingTxFilter struct {
 	filterBase
 	sync.Mutex
 
+	// fullTx indicates the filter returns the whole transactions instead of their hashes
+	fullTx bool
+
 	txHashes []string
+	txs      []*transaction
 }
 
 // appendPendingTxHashes appends new pending tx hash to tx hashes
@@ -255,6 +260,14 @@ func (f *pendingTxFilter) appendPendingTxHashes(txHash string) {
 	f.txHashes = append(f.txHashes, txHash)
 }
 
+// appendPendingTx appends new pending tx to txs
+func (f *pendingTxFilter) appendPendingTx(tx *transaction) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.txs = append(f.txs, tx)
+}
+
 // takePendingTxsUpdates returns all saved pending tx hashes in filter and sets a new slice
 func (f *pendingTxFilter) takePendingTxsUpdates() []string {
 	f.Lock()
@@ -266,24 +279,54 @@ func (f *pendingTxFilter) takePendingTxsUpdates() []string {
 	return txHashes
 }
 
+// takePendingFullTxsUpdates returns all saved pending txs in filter and sets a new slice
+func (f *pendingTxFilter) takePendingFullTxsUpdates() []*transaction {
+	f.Lock()
+	defer f.Unlock()
+
+	txs := f.txs
+	f.txs = []*transaction{}
+
+	return txs
+}
+
 // getSubscriptionType returns the type of the event the filter is subscribed to
 func (f *pendingTxFilter) getSubscriptionType() subscriptionType {
 	return PendingTransactions
 }
 
-// getUpdates returns stored pending tx hashes
+// getUpdates returns stored pending tx hashes, or the pending txs if the filter is for full txs
 func (f *pendingTxFilter) getUpdates() (interface{}, error) {
+	if f.fullTx {
+		return f.takePendingFullTxsUpdates(), nil
+	}
+
 	pendingTxHashes := f.takePendingTxsUpdates()
 
 	return pendingTxHashes, nil
 }
 
-// sendUpdates write the hashes for all pending transactions to web socket stream
+// sendUpdates write the hashes (or the whole txs) for all pending transactions to web socket stream
 func (f *pendingTxFilter) sendUpdates() error {
-	pendingTxHashes := f.takePendingTxsUpdates()
+	var updates []interface{}
 
-	for _, txHash := range pendingTxHashes {
-		if err := f.writeMessageToWs(txHash); err != nil {
+	if f.fullTx {
+		for _, tx := range f.takePendingFullTxsUpdates() {
+			updates = append(updates, tx)
+		}
+	} else {
+		for _, txHash := range f.takePendingTxsUpdates() {
+			updates = append(updates, txHash)
+		}
+	}
+
+	for _, update := range updates {
+		res, err := json.Marshal(update)
+		if err != nil {
+			return err
+		}
+
+		if err := f.writeMessageToWs(string(res)); err != nil {
 			return err
 		}
 	}
@@ -308,8 +351,15 @@ type filterManagerStore interface {
 	// GetBlockByNumber returns a block using the provided number
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
 
+	// GetLogIndexCandidates returns the numbers of the blocks in the given range
+	// which may contain logs matching the given addresses and topics
+	GetLogIndexCandidates(from, to uint64, addresses []types.Address, topics [][]types.Hash) []uint64
+
 	// TxPoolSubscribe subscribes for tx pool events
 	TxPoolSubscribe(request *proto.SubscribeRequest) (<-chan *proto.TxPoolEvent, func(), error)
+
+	// GetPendingTx gets the pending transaction from the transaction pool, if it's present
+	GetPendingTx(txHash types.Hash) (*types.Transaction, bool)
 }
 
 // FilterManager manages all running filters
@@ -323,7 +373,7 @@ type FilterManager struct {
 	store           filterManagerStore
 	subscription    blockchain.Subscription
 	blockStream     *blockStream
-	blockRangeLimit uint64
+	blockRangeLimit atomic.Uint64
 
 	filters  map[string]filter
 	timeouts timeHeapImpl
@@ -334,16 +384,17 @@ type FilterManager struct {
 
 func NewFilterManager(logger hclog.Logger, store filterManagerStore, blockRangeLimit uint64) *FilterManager {
 	m := &FilterManager{
-		logger:          logger.Named("filter"),
-		timeout:         defaultTimeout,
-		store:           store,
-		blockRangeLimit: blockRangeLimit,
-		filters:         make(map[string]filter),
-		timeouts:        timeHeapImpl{},
-		updateCh:        make(chan struct{}),
-		closeCh:         make(chan struct{}),
+		logger:   logger.Named("filter"),
+		timeout:  defaultTimeout,
+		store:    store,
+		filters:  make(map[string]filter),
+		timeouts: timeHeapImpl{},
+		updateCh: make(chan struct{}),
+		closeCh:  make(chan struct{}),
 	}
 
+	m.blockRangeLimit.Store(blockRangeLimit)
+
 	// start blockstream with the current header
 	header := store.Header()
 
@@ -461,9 +512,20 @@ func (f *FilterManager) NewLogFilter(logQuery *LogQuery, ws wsConn) string {
 
 // NewPendingTxFilter adds new PendingTxFilter
 func (f *FilterManager) NewPendingTxFilter(ws wsConn) string {
+	return f.newPendingTxFilter(ws, false)
+}
+
+// NewPendingFullTxFilter adds new PendingTxFilter which returns the whole transactions
+func (f *FilterManager) NewPendingFullTxFilter(ws wsConn) string {
+	return f.newPendingTxFilter(ws, true)
+}
+
+func (f *FilterManager) newPendingTxFilter(ws wsConn, fullTx bool) string {
 	filter := &pendingTxFilter{
 		filterBase: newFilterBase(ws),
+		fullTx:     fullTx,
 		txHashes:   []string{},
+		txs:        []*transaction{},
 	}
 
 	if filter.hasWSConn() {
@@ -527,13 +589,14 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 	}
 
 	// if not disabled, avoid handling large block ranges
-	if f.blockRangeLimit != 0 && to-from > f.blockRangeLimit {
+	if limit := f.blockRangeLimit.Load(); limit != 0 && to-from > limit {
 		return nil, ErrBlockRangeTooHigh
 	}
 
 	logs := make([]*Log, 0)
 
-	for i := from; i <= to; i++ {
+	// skip the blocks whose logs bloom can't match the query
+	for _, i := range f.store.GetLogIndexCandidates(from, to, query.Addresses, query.Topics) {
 		block, ok := f.store.GetBlockByNumber(i, true)
 		if !ok {
 			break
@@ -757,6 +820,26 @@ func (f *FilterManager) processBlockEvent(evnt *blockchain.Event) {
 	f.RLock()
 	defer f.RUnlock()
 
+	// the logs of the blocks reorganized out of the chain are sent again, marked as removed,
+	// starting from the most recent block. Fork events only carry non-canonical blocks
+	// whose logs have never been sent
+	if evnt.Type == blockchain.EventReorg {
+		oldChain := make([]*types.Header, len(evnt.OldChain))
+		copy(oldChain, evnt.OldChain)
+
+		sort.Slice(oldChain, func(i, j int) bool {
+			return oldChain[i].Number > oldChain[j].Number
+		})
+
+		for _, header := range oldChain {
+			block := toBlock(&types.Block{Header: header}, false)
+
+			if processErr := f.appendLogsToFilters(block, true); processErr != nil {
+				f.logger.Error(fmt.Sprintf("Unable to process removed block, %v", processErr))
+			}
+		}
+	}
+
 	for _, header := range evnt.NewChain {
 		block := toBlock(&types.Block{Header: header}, false)
 
@@ -764,14 +847,15 @@ func (f *FilterManager) processBlockEvent(evnt *blockchain.Event) {
 		f.blockStream.push(block)
 
 		// process new chain to include new logs for LogFilter
-		if processErr := f.appendLogsToFilters(block); processErr != nil {
+		if processErr := f.appendLogsToFilters(block, false); processErr != nil {
 			f.logger.Error(fmt.Sprintf("Unable to process block, %v", processErr))
 		}
 	}
 }
 
-// appendLogsToFilters makes each LogFilters append logs in the header
-func (f *FilterManager) appendLogsToFilters(header *block) error {
+// appendLogsToFilters makes each LogFilters append logs in the header,
+// the logs are flagged as removed if the block was reorganized out of the chain
+func (f *FilterManager) appendLogsToFilters(header *block, removed bool) error {
 	receipts, err := f.store.GetReceiptsByHash(header.Hash)
 	if err != nil {
 		return err
@@ -808,7 +892,10 @@ func (f *FilterManager) appendLogsToFilters(header *block) error {
 		for _, log := range receipt.Logs {
 			for _, f := range logFilters {
 				if f.query.Match(log) {
-					f.appendLog(toLog(log, logIndex, uint64(indx), block.Header, receipt.TxHash))
+					filterLog := toLog(log, logIndex, uint64(indx), block.Header, receipt.TxHash)
+					filterLog.Removed = removed
+
+					f.appendLog(filterLog)
 				}
 			}
 
@@ -819,14 +906,42 @@ func (f *FilterManager) appendLogsToFilters(header *block) error {
 	return nil
 }
 
-// processTxEvent makes each filter refresh the pending tx hashes
+// processTxEvent makes each filter refresh the pending tx hashes, or the pending txs
 func (f *FilterManager) processTxEvent(evnt *proto.TxPoolEvent) {
 	f.RLock()
 	defer f.RUnlock()
 
-	for _, f := range f.filters {
-		if txFilter, ok := f.(*pendingTxFilter); ok {
+	var (
+		// the pending tx is only looked up once, if there is any filter for full txs
+		pendingTx       *transaction
+		pendingTxLookup bool
+	)
+
+	for _, filter := range f.filters {
+		txFilter, ok := filter.(*pendingTxFilter)
+		if !ok {
+			continue
+		}
+
+		if !txFilter.fullTx {
 			txFilter.appendPendingTxHashes(evnt.TxHash)
+
+			continue
+		}
+
+		if !pendingTxLookup {
+			pendingTxLookup = true
+
+			if tx, found := f.store.GetPendingTx(types.StringToHash(evnt.TxHash)); found {
+				pendingTx = toPendingTransaction(tx)
+			} else {
+				// the tx might have been already removed from the pool
+				f.logger.Debug("pending tx not found in the pool", "hash", evnt.TxHash)
+			}
+		}
+
+		if pendingTx != nil {
+			txFilter.appendPendingTx(pendingTx)
 		}
 	}
 }
@@ -631,6 +631,10 @@ func (m *mockWsConn) GetFilterID() string {
 	return m.GetFilterIDFn()
 }
 
+func (m *mockWsConn) GetClientID() string {
+	return ""
+}
+
 func (m *mockWsConn) WriteMessage(messageType int, b []byte) error {
 	return m.WriteMessageFn(messageType, b)
 }
@@ -753,6 +757,10 @@ func (m *MockClosedWSConnection) GetFilterID() string {
 	return ""
 }
 
+func (m *MockClosedWSConnection) GetClientID() string {
+	return ""
+}
+
 func (m *MockClosedWSConnection) WriteMessage(_messageType int, _data []byte) error {
 	return websocket.ErrCloseSent
 }
@@ -871,7 +879,7 @@ func Test_appendLogsToFilters(t *testing.T) {
 	}
 
 	b := toBlock(&types.Block{Header: block.Header, Transactions: txs}, false)
-	err := f.appendLogsToFilters(b)
+	err := f.appendLogsToFilters(b, false)
 
 	require.NoError(t, err)
 	require.Len(t, logFilter.logs, numOfLogs)
@@ -880,3 +888,108 @@ func Test_appendLogsToFilters(t *testing.T) {
 		require.Equal(t, uint64(i), uint64(logFilter.logs[i].LogIndex))
 	}
 }
+
+func Test_processBlockEvent_RemovedLogs(t *testing.T) {
+	t.Parallel()
+
+	var (
+		oldBlock = &types.Block{
+			Header:       &types.Header{Hash: types.StringToHash("01"), Number: 1},
+			Transactions: []*types.Transaction{createTestTransaction(types.StringToHash("a1"))},
+		}
+		newBlock = &types.Block{
+			Header:       &types.Header{Hash: types.StringToHash("02"), Number: 1},
+			Transactions: []*types.Transaction{createTestTransaction(types.StringToHash("a2"))},
+		}
+	)
+
+	store := newMockBlockStore()
+	store.appendBlocksToStore([]*types.Block{oldBlock, newBlock})
+
+	for _, block := range []*types.Block{oldBlock, newBlock} {
+		store.receipts[block.Hash()] = []*types.Receipt{
+			{
+				Logs:   []*types.Log{{Topics: []types.Hash{hash1}}},
+				TxHash: block.Transactions[0].Hash(),
+			},
+		}
+	}
+
+	f := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+
+	t.Cleanup(func() {
+		defer f.Close()
+	})
+
+	logFilter := &logFilter{
+		filterBase: newFilterBase(nil),
+		query: &LogQuery{
+			Topics: [][]types.Hash{{hash1}},
+		},
+	}
+
+	f.filters = map[string]filter{
+		"test": logFilter,
+	}
+
+	// the logs of the fork blocks have never been sent, so they are not removed
+	f.processBlockEvent(&blockchain.Event{
+		Type:     blockchain.EventFork,
+		OldChain: []*types.Header{oldBlock.Header},
+	})
+
+	require.Empty(t, logFilter.takeLogUpdates())
+
+	f.processBlockEvent(&blockchain.Event{
+		Type:     blockchain.EventReorg,
+		OldChain: []*types.Header{oldBlock.Header},
+		NewChain: []*types.Header{newBlock.Header},
+	})
+
+	logs := logFilter.takeLogUpdates()
+	require.Len(t, logs, 2)
+
+	require.True(t, logs[0].Removed)
+	require.Equal(t, oldBlock.Hash(), logs[0].BlockHash)
+	require.Equal(t, oldBlock.Transactions[0].Hash(), logs[0].TxHash)
+
+	require.False(t, logs[1].Removed)
+	require.Equal(t, newBlock.Hash(), logs[1].BlockHash)
+	require.Equal(t, newBlock.Transactions[0].Hash(), logs[1].TxHash)
+}
+
+func TestFilterPendingFullTx(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	tx := createTestTransaction(types.StringToHash("a1"))
+	store.addPendingTx(tx)
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	id := m.NewPendingFullTxFilter(nil)
+	hashesID := m.NewPendingTxFilter(nil)
+
+	// the second tx is not in the pool anymore
+	store.emitTxPoolEvent(proto.EventType_ADDED, tx.Hash().String())
+	store.emitTxPoolEvent(proto.EventType_ADDED, types.StringToHash("a2").String())
+
+	// we need to wait for the manager to process the data
+	time.Sleep(500 * time.Millisecond)
+
+	res, err := m.GetFilterChanges(id)
+	require.NoError(t, err)
+
+	txs, ok := res.([]*transaction)
+	require.True(t, ok)
+	require.Len(t, txs, 1)
+	require.Equal(t, toPendingTransaction(tx), txs[0])
+
+	res, err = m.GetFilterChanges(hashesID)
+	require.NoError(t, err)
+	require.Equal(t, []string{tx.Hash().String(), types.StringToHash("a2").String()}, res)
+}
@@ -22,12 +22,20 @@ type JSONRPC struct {
 	logger     hclog.Logger
 	config     *Config
 	dispatcher dispatcher
+
+	// configLock guards the config fields which can be changed while the server is running
+	configLock sync.RWMutex
+
+	// apiKeys are the known API keys identifying the clients by the rate limits
+	apiKeys map[string]struct{}
 }
 
 type dispatcher interface {
 	RemoveFilterByWs(conn wsConn)
 	HandleWs(reqBody []byte, conn wsConn) ([]byte, error)
 	Handle(reqBody []byte) ([]byte, error)
+	HandleWithOrigin(reqBody []byte, origin RequestOrigin) ([]byte, error)
+	UpdateParams(params *RuntimeParams)
 }
 
 // JSONRPCStore defines all the methods required
@@ -39,6 +47,9 @@ type JSONRPCStore interface {
 	filterManagerStore
 	bridgeStore
 	debugStore
+	traceStore
+	consensusStore
+	adminStore
 }
 
 type Config struct {
@@ -57,6 +68,32 @@ type Config struct {
 	TLSCertFile             string
 	TLSKeyFile              string
 	SecretsManager          secrets.SecretsManager
+
+	// AdminToken authorizes the calls to the admin namespace, the namespace is disabled if it is empty
+	AdminToken []byte
+
+	// APIKeyHeader is the HTTP header with the API key identifying the client by the rate limits,
+	// the requests without a known API key are identified by the IP address
+	APIKeyHeader string
+	APIKeys      []string
+
+	// ClientRateLimit limits all the requests of the client,
+	// MethodRateLimits limit the requests of the client to the specific methods
+	ClientRateLimit  *RateLimit
+	MethodRateLimits map[string]*RateLimit
+
+	// AllowedMethods and DeniedMethods are the lists of the namespaces or the methods,
+	// all the methods are allowed if AllowedMethods is empty
+	AllowedMethods []string
+	DeniedMethods  []string
+}
+
+// RuntimeParams are the JSON-RPC parameters which can be changed while the server is running
+type RuntimeParams struct {
+	AccessControlAllowOrigin []string
+	PriceLimit               uint64
+	BatchLengthLimit         uint64
+	BlockRangeLimit          uint64
 }
 
 // NewJSONRPC returns the JSONRPC http server
@@ -71,6 +108,11 @@ func NewJSONRPC(logger hclog.Logger, config *Config, manager accounts.AccountMan
 			jsonRPCBatchLengthLimit: config.BatchLengthLimit,
 			blockRangeLimit:         config.BlockRangeLimit,
 			concurrentRequestsDebug: config.ConcurrentRequestsDebug,
+			adminEnabled:            len(config.AdminToken) > 0,
+			clientRateLimit:         config.ClientRateLimit,
+			methodRateLimits:        config.MethodRateLimits,
+			allowedMethods:          config.AllowedMethods,
+			deniedMethods:           config.DeniedMethods,
 		},
 		manager,
 	)
@@ -79,10 +121,16 @@ func NewJSONRPC(logger hclog.Logger, config *Config, manager accounts.AccountMan
 		return nil, err
 	}
 
+	apiKeys := make(map[string]struct{}, len(config.APIKeys))
+	for _, key := range config.APIKeys {
+		apiKeys[key] = struct{}{}
+	}
+
 	srv := &JSONRPC{
 		logger:     logger.Named("jsonrpc"),
 		config:     config,
 		dispatcher: d,
+		apiKeys:    apiKeys,
 	}
 
 	// start http server
@@ -93,6 +141,26 @@ func NewJSONRPC(logger hclog.Logger, config *Config, manager accounts.AccountMan
 	return srv, nil
 }
 
+// UpdateParams applies the parameters which can be changed while the server is running
+func (j *JSONRPC) UpdateParams(params *RuntimeParams) {
+	j.configLock.Lock()
+	j.config.AccessControlAllowOrigin = params.AccessControlAllowOrigin
+	j.config.PriceLimit = params.PriceLimit
+	j.config.BatchLengthLimit = params.BatchLengthLimit
+	j.config.BlockRangeLimit = params.BlockRangeLimit
+	j.configLock.Unlock()
+
+	j.dispatcher.UpdateParams(params)
+}
+
+// allowedOrigins returns the origins allowed by CORS [Thread safe]
+func (j *JSONRPC) allowedOrigins() []string {
+	j.configLock.RLock()
+	defer j.configLock.RUnlock()
+
+	return j.config.AccessControlAllowOrigin
+}
+
 func (j *JSONRPC) setupHTTP() error {
 	j.logger.Info("http server starting...", "addr", j.config.Addr.String())
 
@@ -108,7 +176,7 @@ func (j *JSONRPC) setupHTTP() error {
 
 	// The middleware factory returns a handler, so we need to wrap the handler function properly.
 	jsonRPCHandler := http.HandlerFunc(j.handle)
-	mux.Handle("/", middlewareFactory(j.config)(jsonRPCHandler))
+	mux.Handle("/", middlewareFactory(j.allowedOrigins)(jsonRPCHandler))
 
 	mux.HandleFunc("/ws", j.handleWs)
 
@@ -186,13 +254,13 @@ func loadTLSCertificate(manager secrets.SecretsManager) (*tls.Certificate, error
 	return nil, secrets.ErrSecretNotFound
 }
 
-// The middlewareFactory builds a middleware which enables CORS using the provided config.
-func middlewareFactory(config *Config) func(http.Handler) http.Handler {
+// The middlewareFactory builds a middleware which enables CORS using the origins provided on each request.
+func middlewareFactory(allowedOrigins func() []string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			origin := r.Header.Get("Origin")
 
-			for _, allowedOrigin := range config.AccessControlAllowOrigin {
+			for _, allowedOrigin := range allowedOrigins() {
 				if allowedOrigin == "*" {
 					w.Header().Set("Access-Control-Allow-Origin", "*")
 
@@ -228,6 +296,7 @@ type wsWrapper struct {
 	ws       *websocket.Conn // the actual WS connection
 	logger   hclog.Logger    // module logger
 	filterID string          // filter ID
+	clientID string          // API key or IP address of the client
 }
 
 func (w *wsWrapper) SetFilterID(filterID string) {
@@ -238,6 +307,10 @@ func (w *wsWrapper) GetFilterID() string {
 	return w.filterID
 }
 
+func (w *wsWrapper) GetClientID() string {
+	return w.clientID
+}
+
 // WriteMessage writes out the message to the WS peer
 func (w *wsWrapper) WriteMessage(messageType int, data []byte) error {
 	w.Lock()
@@ -286,7 +359,7 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 		}
 	}(ws)
 
-	wrapConn := &wsWrapper{ws: ws, logger: j.logger}
+	wrapConn := &wsWrapper{ws: ws, logger: j.logger, clientID: j.clientID(req)}
 
 	j.logger.Info("Websocket connection established")
 	// Run the listen loop
@@ -332,10 +405,12 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-	w.Header().Set(
-		"Access-Control-Allow-Headers",
-		"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization",
-	)
+	allowedHeaders := "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization"
+	if j.config.APIKeyHeader != "" {
+		allowedHeaders += ", " + j.config.APIKeyHeader
+	}
+
+	w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
 
 	switch req.Method {
 	case "POST":
@@ -360,7 +435,10 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 	// log request
 	j.logger.Trace("handle", "request", string(data))
 
-	resp, err := j.dispatcher.Handle(data)
+	resp, err := j.dispatcher.HandleWithOrigin(data, RequestOrigin{
+		ClientID:   j.clientID(req),
+		Authorized: isAdminAuthorized(req.Header.Get("Authorization"), j.config.AdminToken, time.Now()),
+	})
 	if err != nil {
 		_, _ = w.Write([]byte(err.Error()))
 	} else {
@@ -370,6 +448,24 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 	j.logger.Trace("handle", "response", string(resp))
 }
 
+// clientID returns the known API key sent by the client, or the IP address of the client
+func (j *JSONRPC) clientID(req *http.Request) string {
+	if j.config.APIKeyHeader != "" {
+		if key := req.Header.Get(j.config.APIKeyHeader); key != "" {
+			if _, ok := j.apiKeys[key]; ok {
+				return "key:" + key
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+
+	return host
+}
+
 type GetResponse struct {
 	Name    string `json:"name"`
 	ChainID uint64 `json:"chain_id"`
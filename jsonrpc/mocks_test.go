@@ -38,6 +38,7 @@ type mockHeader struct {
 }
 
 type mockEvent struct {
+	Type     blockchain.EventType
 	OldChain []*mockHeader
 	NewChain []*mockHeader
 }
@@ -51,6 +52,8 @@ type mockStore struct {
 	receiptsLock  sync.Mutex
 	receipts      map[types.Hash][]*types.Receipt
 	accounts      map[types.Address]*Account
+	pendingTxLock sync.Mutex
+	pendingTxs    map[types.Hash]*types.Transaction
 
 	// headers is the list of historical headers
 	historicalHeaders []*types.Header
@@ -61,6 +64,7 @@ func newMockStore() *mockStore {
 		header:        &types.Header{Number: 0},
 		subscription:  blockchain.NewMockSubscription(),
 		accounts:      map[types.Address]*Account{},
+		pendingTxs:    map[types.Hash]*types.Transaction{},
 		txPoolChannel: make(chan *proto.TxPoolEvent),
 	}
 	m.addHeader(m.header)
@@ -93,6 +97,7 @@ func (m *mockStore) emitEvent(evnt *mockEvent) {
 	}
 
 	bEvnt := &blockchain.Event{
+		Type:     evnt.Type,
 		NewChain: []*types.Header{},
 		OldChain: []*types.Header{},
 	}
@@ -157,6 +162,22 @@ func (m *mockStore) TxPoolSubscribe(request *proto.SubscribeRequest) (<-chan *pr
 	return m.txPoolChannel, txPoolUnsubscribe, nil
 }
 
+func (m *mockStore) addPendingTx(tx *types.Transaction) {
+	m.pendingTxLock.Lock()
+	defer m.pendingTxLock.Unlock()
+
+	m.pendingTxs[tx.Hash()] = tx
+}
+
+func (m *mockStore) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
+	m.pendingTxLock.Lock()
+	defer m.pendingTxLock.Unlock()
+
+	tx, ok := m.pendingTxs[txHash]
+
+	return tx, ok
+}
+
 func (m *mockStore) GetHeaderByNumber(num uint64) (*types.Header, bool) {
 	header := m.headerLoop(func(header *types.Header) bool {
 		return header.Number == num
@@ -181,6 +202,19 @@ func (m *mockStore) GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
 	return &types.Block{Header: header}, header != nil
 }
 
+func (m *mockStore) GetLogIndexCandidates(
+	from, to uint64,
+	addresses []types.Address,
+	topics [][]types.Hash,
+) []uint64 {
+	candidates := make([]uint64, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		candidates = append(candidates, i)
+	}
+
+	return candidates
+}
+
 func (m *mockStore) GetTxs(inclQueued bool) (
 	map[types.Address][]*types.Transaction,
 	map[types.Address][]*types.Transaction,
@@ -217,6 +251,19 @@ func (m *mockStore) GetStateSyncProof(stateSyncID uint64) (types.Proof, error) {
 	return ssp, nil
 }
 
+func (m *mockStore) GetEquivocationEvidence(fromHeight, toHeight uint64) ([]*types.EquivocationEvidence, error) {
+	return []*types.EquivocationEvidence{
+		{
+			Validator:     types.StringToAddress("1"),
+			Height:        fromHeight,
+			Round:         1,
+			MessageType:   "COMMIT",
+			FirstMessage:  []byte{0x1},
+			SecondMessage: []byte{0x2},
+		},
+	}, nil
+}
+
 func (m *mockStore) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
@@ -3,20 +3,35 @@ package jsonrpc
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/accounts"
+	"github.com/0xPolygon/polygon-edge/accounts/hdwallet"
 	"github.com/0xPolygon/polygon-edge/accounts/keystore"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+// personalStore provides the state of the accounts for the HD wallet account discovery
+type personalStore interface {
+	Header() *types.Header
+	GetAccount(root types.Hash, addr types.Address) (*Account, error)
+}
+
 type Personal struct {
 	accManager accounts.AccountManager
+	store      personalStore
 }
 
-func NewPersonal(manager accounts.AccountManager) *Personal {
-	return &Personal{accManager: manager}
+func NewPersonal(manager accounts.AccountManager, store personalStore) *Personal {
+	return &Personal{accManager: manager, store: store}
+}
+
+// hdWalletResult is the result of the HD wallet import, the mnemonic is never sent back
+type hdWalletResult struct {
+	ID      string        `json:"id"`
+	Address types.Address `json:"address"`
 }
 
 func (p *Personal) ListAccounts() ([]types.Address, Error) {
@@ -78,6 +93,15 @@ func (p *Personal) UnlockAccount(addr types.Address, password string, duration a
 		d = time.Duration(duration) * time.Second
 	}
 
+	// the accounts of the HD wallets are unlocked by opening their wallet
+	if wallet, err := getHDWallet(p.accManager, addr); err == nil {
+		if err := wallet.TimedOpen(password, d); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
 	ks, err := getKeystore(p.accManager)
 	if err != nil {
 		return false, err
@@ -92,6 +116,14 @@ func (p *Personal) UnlockAccount(addr types.Address, password string, duration a
 }
 
 func (p *Personal) LockAccount(addr types.Address) (bool, error) {
+	if wallet, err := getHDWallet(p.accManager, addr); err == nil {
+		if err := wallet.Close(); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
 	ks, err := getKeystore(p.accManager)
 	if err != nil {
 		return false, err
@@ -104,6 +136,94 @@ func (p *Personal) LockAccount(addr types.Address) (bool, error) {
 	return true, nil
 }
 
+// ImportMnemonic creates the HD wallet from the existing mnemonic, encrypted with the passphrase
+func (p *Personal) ImportMnemonic(mnemonic string, passphrase string) (*hdWalletResult, error) {
+	hdStore, err := getHDStore(p.accManager)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := hdStore.ImportMnemonic(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hdWalletResult{
+		ID:      wallet.ID(),
+		Address: wallet.Accounts()[0].Address,
+	}, nil
+}
+
+// DeriveAccount derives the account of the unlocked HD wallet holding the given account.
+// The path is either absolute or relative to the base path of the wallet,
+// the derived account is tracked by the wallet if pin is set
+func (p *Personal) DeriveAccount(addr types.Address, path string, pin *bool) (types.Address, error) {
+	wallet, err := getHDWallet(p.accManager, addr)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	derivationPath, err := hdwallet.ParseDerivationPathFrom(path, wallet.BasePath())
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	account, err := wallet.Derive(derivationPath, pin != nil && *pin)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return account.Address, nil
+}
+
+// SelfDerive discovers the used accounts of the unlocked HD wallet holding the given account,
+// under the given base path or the base path of the wallet, and returns the newly tracked accounts
+func (p *Personal) SelfDerive(addr types.Address, basePath *string) ([]types.Address, error) {
+	wallet, err := getHDWallet(p.accManager, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	base := wallet.BasePath()
+
+	if basePath != nil {
+		if base, err = hdwallet.ParseDerivationPath(*basePath); err != nil {
+			return nil, err
+		}
+	}
+
+	discovered, err := wallet.SelfDerive([]hdwallet.DerivationPath{base}, &chainStateReader{store: p.store})
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]types.Address, len(discovered))
+	for i, account := range discovered {
+		addresses[i] = account.Address
+	}
+
+	return addresses, nil
+}
+
+// SignTypedData signs the EIP-712 typed data with the account unlocked by the passphrase
+func (p *Personal) SignTypedData(typedData accounts.TypedData, addr types.Address, passphrase string) (interface{}, error) {
+	account := accounts.Account{Address: addr}
+
+	wallet, err := p.accManager.Find(account)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := wallet.SignTypedDataWithPassphrase(account, passphrase, &typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	signature[64] += 27 // Transform V from 0/1 to 27/28 according to the yellow paper
+
+	return argBytesPtr(signature), nil
+}
+
 func (p *Personal) Ecrecover(data, sig []byte) (types.Address, error) {
 	addressRaw, err := crypto.Ecrecover(data, sig)
 	if err != nil {
@@ -113,6 +233,41 @@ func (p *Personal) Ecrecover(data, sig []byte) (types.Address, error) {
 	return types.BytesToAddress(addressRaw), nil
 }
 
+// chainStateReader reads the state of the accounts for the HD wallet account discovery
+type chainStateReader struct {
+	store personalStore
+}
+
+// AccountState returns the nonce and the balance of the account at the latest block
+func (c *chainStateReader) AccountState(addr types.Address) (uint64, *big.Int, error) {
+	acc, err := c.store.GetAccount(c.store.Header().StateRoot, addr)
+	if errors.Is(err, ErrStateNotFound) {
+		return 0, big.NewInt(0), nil
+	} else if err != nil {
+		return 0, nil, err
+	}
+
+	return acc.Nonce, acc.Balance, nil
+}
+
+func getHDStore(am accounts.AccountManager) (*hdwallet.HDStore, error) {
+	if hs := am.WalletManagers(hdwallet.HDStoreType); len(hs) > 0 {
+		return hs[0].(*hdwallet.HDStore), nil //nolint:forcetypeassert
+	}
+
+	return nil, errors.New("hd wallets not used")
+}
+
+// getHDWallet returns the HD wallet holding the given account
+func getHDWallet(am accounts.AccountManager, addr types.Address) (*hdwallet.Wallet, error) {
+	hdStore, err := getHDStore(am)
+	if err != nil {
+		return nil, err
+	}
+
+	return hdStore.Wallet(addr)
+}
+
 func getKeystore(am accounts.AccountManager) (*keystore.KeyStore, error) {
 	if ks := am.WalletManagers(keystore.KeyStoreType); len(ks) > 0 {
 		return ks[0].(*keystore.KeyStore), nil //nolint:forcetypeassert
@@ -0,0 +1,103 @@
+package jsonrpc
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/accounts"
+	"github.com/0xPolygon/polygon-edge/accounts/hdwallet"
+	"github.com/0xPolygon/polygon-edge/accounts/keystore"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestPersonalEndpoint_HDWallet(t *testing.T) {
+	t.Parallel()
+
+	const (
+		passphrase = "passphrase"
+		mnemonic   = "test test test test test test test test test test test junk"
+	)
+
+	var (
+		account0 = types.StringToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+		account1 = types.StringToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+		account2 = types.StringToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+		account5 = types.StringToAddress("0x9965507D1a55bcC2695C58ba16FB37d819B0A4dc")
+	)
+
+	ks, err := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	hdStore, err := hdwallet.NewHDStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	manager := accounts.NewManager(nil, ks, hdStore)
+	t.Cleanup(func() {
+		require.NoError(t, manager.Close())
+	})
+
+	store := newMockStore()
+	store.header = &types.Header{}
+	store.SetAccount(account0, &Account{Nonce: 1, Balance: big.NewInt(0)})
+	store.SetAccount(account1, &Account{Balance: big.NewInt(1)})
+
+	dispatcher, err := newDispatcher(hclog.NewNullLogger(), store, &dispatcherParams{chainID: 1}, manager)
+	require.NoError(t, err)
+
+	call := func(method, params string, result interface{}) error {
+		t.Helper()
+
+		data, err := dispatcher.Handle([]byte(fmt.Sprintf(`{"method": "%s", "params": %s, "id": 1}`, method, params)))
+		require.NoError(t, err)
+
+		return expectJSONResult(data, result)
+	}
+
+	var wallet hdWalletResult
+	require.NoError(t, call("personal_importMnemonic", fmt.Sprintf(`["%s", "%s"]`, mnemonic, passphrase), &wallet))
+	require.Equal(t, account0, wallet.Address)
+	require.NotEmpty(t, wallet.ID)
+
+	require.Eventually(t, func() bool {
+		_, err := manager.Find(accounts.Account{Address: account0})
+
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	var address types.Address
+
+	// the wallet is locked
+	require.Error(t, call("personal_deriveAccount", fmt.Sprintf(`["%s", "1", true]`, account0), &address))
+
+	var ok bool
+	require.NoError(t, call("personal_unlockAccount", fmt.Sprintf(`["%s", "%s", 0]`, account0, passphrase), &ok))
+	require.True(t, ok)
+
+	// the relative paths are resolved against the base path of the wallet
+	require.NoError(t, call("personal_deriveAccount", fmt.Sprintf(`["%s", "5"]`, account0), &address))
+	require.Equal(t, account5, address)
+
+	require.NoError(t, call("personal_deriveAccount", fmt.Sprintf(`["%s", "m/44'/60'/0'/0/5", true]`, account0), &address))
+	require.Equal(t, account5, address)
+
+	var discovered []types.Address
+	require.NoError(t, call("personal_selfDerive", fmt.Sprintf(`["%s"]`, account0), &discovered))
+	require.Equal(t, []types.Address{account1, account2}, discovered)
+
+	var listed []types.Address
+	require.NoError(t, call("personal_listAccounts", `[]`, &listed))
+	require.ElementsMatch(t, []types.Address{account0, account5, account1, account2}, listed)
+
+	require.NoError(t, call("personal_lockAccount", fmt.Sprintf(`["%s"]`, account0), &ok))
+	require.True(t, ok)
+
+	require.Error(t, call("personal_selfDerive", fmt.Sprintf(`["%s"]`, account0), &discovered))
+
+	// the mnemonics are not generated by the node
+	require.Error(t, call("personal_newHDWallet", fmt.Sprintf(`["%s"]`, passphrase), &wallet))
+}
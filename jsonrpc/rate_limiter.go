@@ -0,0 +1,206 @@
+package jsonrpc
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// rateLimiterCleanupInterval is the interval at which the idle buckets are removed
+	rateLimiterCleanupInterval = time.Minute
+
+	// rateLimiterIdleTimeout is the time after which the bucket of an inactive client is removed
+	rateLimiterIdleTimeout = 10 * time.Minute
+)
+
+// RateLimit defines the token bucket refilled with Rate tokens per second, holding up to Burst tokens.
+// The limit is disabled if the rate is not positive. Burst defaults to the rate rounded up
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+func (l *RateLimit) isEnabled() bool {
+	return l != nil && l.Rate > 0
+}
+
+func (l *RateLimit) newLimiter() *rate.Limiter {
+	burst := l.Burst
+	if burst < 1 {
+		burst = int(math.Ceil(l.Rate))
+	}
+
+	return rate.NewLimiter(rate.Limit(l.Rate), burst)
+}
+
+type bucketKey struct {
+	clientID string
+	method   string
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// rateLimiter limits the requests of each client, both in total and for the specific methods
+type rateLimiter struct {
+	clientLimit  *RateLimit
+	methodLimits map[string]*RateLimit
+
+	lock        sync.Mutex
+	buckets     map[bucketKey]*bucket
+	lastCleanup time.Time
+}
+
+// newRateLimiter returns nil if none of the limits is enabled
+func newRateLimiter(clientLimit *RateLimit, methodLimits map[string]*RateLimit) *rateLimiter {
+	enabledMethodLimits := make(map[string]*RateLimit, len(methodLimits))
+
+	for method, limit := range methodLimits {
+		if limit.isEnabled() {
+			enabledMethodLimits[method] = limit
+		}
+	}
+
+	if !clientLimit.isEnabled() && len(enabledMethodLimits) == 0 {
+		return nil
+	}
+
+	if !clientLimit.isEnabled() {
+		clientLimit = nil
+	}
+
+	return &rateLimiter{
+		clientLimit:  clientLimit,
+		methodLimits: enabledMethodLimits,
+		buckets:      make(map[bucketKey]*bucket),
+		lastCleanup:  time.Now(),
+	}
+}
+
+// allow takes a token from the client bucket and the bucket of the method,
+// returns false if any of them is empty
+func (r *rateLimiter) allow(clientID, method string, now time.Time) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if now.Sub(r.lastCleanup) > rateLimiterCleanupInterval {
+		r.cleanup(now)
+	}
+
+	var clientBucket, methodBucket *bucket
+
+	if r.clientLimit != nil {
+		clientBucket = r.getBucket(bucketKey{clientID: clientID}, r.clientLimit, now)
+	}
+
+	if limit, ok := r.methodLimits[method]; ok {
+		methodBucket = r.getBucket(bucketKey{clientID: clientID, method: method}, limit, now)
+	}
+
+	methodReservation, ok := methodBucket.take(now)
+	if !ok {
+		return false
+	}
+
+	if _, ok := clientBucket.take(now); !ok {
+		// the request is refused, so the token is returned to the method bucket
+		if methodReservation != nil {
+			methodReservation.CancelAt(now)
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// take takes a token from the bucket, returns false if the bucket is empty.
+// Nil bucket allows all the requests
+func (b *bucket) take(now time.Time) (*rate.Reservation, bool) {
+	if b == nil {
+		return nil, true
+	}
+
+	reservation := b.limiter.ReserveN(now, 1)
+	if !reservation.OK() || reservation.DelayFrom(now) > 0 {
+		reservation.CancelAt(now)
+
+		return nil, false
+	}
+
+	return reservation, true
+}
+
+func (r *rateLimiter) getBucket(key bucketKey, limit *RateLimit, now time.Time) *bucket {
+	b, ok := r.buckets[key]
+	if !ok {
+		b = &bucket{limiter: limit.newLimiter()}
+		r.buckets[key] = b
+	}
+
+	b.lastSeen = now
+
+	return b
+}
+
+// cleanup removes the buckets of the inactive clients
+func (r *rateLimiter) cleanup(now time.Time) {
+	for key, b := range r.buckets {
+		if now.Sub(b.lastSeen) > rateLimiterIdleTimeout {
+			delete(r.buckets, key)
+		}
+	}
+
+	r.lastCleanup = now
+}
+
+// methodFilter allows or denies the methods by the lists of the namespaces (e.g. debug)
+// or the full method names (e.g. eth_sendTransaction)
+type methodFilter struct {
+	allowed map[string]struct{}
+	denied  map[string]struct{}
+}
+
+func newMethodFilter(allowed, denied []string) *methodFilter {
+	toSet := func(entries []string) map[string]struct{} {
+		set := make(map[string]struct{}, len(entries))
+
+		for _, entry := range entries {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				set[entry] = struct{}{}
+			}
+		}
+
+		return set
+	}
+
+	return &methodFilter{
+		allowed: toSet(allowed),
+		denied:  toSet(denied),
+	}
+}
+
+// isAllowed returns false if the method or its namespace is denied, or if the allowlist is set
+// and neither the method nor its namespace is on it
+func (f *methodFilter) isAllowed(method string) bool {
+	namespace, _, _ := strings.Cut(method, "_")
+
+	contains := func(set map[string]struct{}) bool {
+		_, methodFound := set[method]
+		_, namespaceFound := set[namespace]
+
+		return methodFound || namespaceFound
+	}
+
+	if contains(f.denied) {
+		return false
+	}
+
+	return len(f.allowed) == 0 || contains(f.allowed)
+}
@@ -0,0 +1,167 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, newRateLimiter(nil, nil))
+	require.Nil(t, newRateLimiter(&RateLimit{}, map[string]*RateLimit{"eth_call": {Rate: 0, Burst: 5}}))
+
+	limiter := newRateLimiter(
+		&RateLimit{Rate: 1, Burst: 3},
+		map[string]*RateLimit{"eth_getLogs": {Rate: 0.5}},
+	)
+	now := time.Now()
+
+	// the method bucket holds a single token
+	require.True(t, limiter.allow("client1", "eth_getLogs", now))
+	require.False(t, limiter.allow("client1", "eth_getLogs", now))
+
+	// the refused request does not take the token of the client bucket
+	require.True(t, limiter.allow("client1", "eth_blockNumber", now))
+	require.True(t, limiter.allow("client1", "eth_blockNumber", now))
+	require.False(t, limiter.allow("client1", "eth_blockNumber", now))
+
+	// the other clients are limited separately
+	require.True(t, limiter.allow("client2", "eth_getLogs", now))
+
+	// the client bucket is empty, so the token is returned to the method bucket
+	now = now.Add(2 * time.Second)
+
+	require.True(t, limiter.allow("client1", "eth_blockNumber", now))
+	require.True(t, limiter.allow("client1", "eth_blockNumber", now))
+	require.False(t, limiter.allow("client1", "eth_getLogs", now))
+
+	now = now.Add(time.Second)
+
+	require.True(t, limiter.allow("client1", "eth_getLogs", now))
+
+	// the buckets of the inactive clients are removed
+	now = now.Add(rateLimiterIdleTimeout - time.Second)
+
+	require.True(t, limiter.allow("client2", "eth_blockNumber", now))
+
+	now = now.Add(rateLimiterCleanupInterval + time.Second)
+
+	require.True(t, limiter.allow("client2", "eth_blockNumber", now))
+	require.Len(t, limiter.buckets, 1)
+}
+
+func TestMethodFilter(t *testing.T) {
+	t.Parallel()
+
+	filter := newMethodFilter(nil, nil)
+	require.True(t, filter.isAllowed("debug_traceBlockByNumber"))
+
+	filter = newMethodFilter(nil, []string{"debug", "eth_sendTransaction"})
+	require.False(t, filter.isAllowed("debug_traceBlockByNumber"))
+	require.False(t, filter.isAllowed("eth_sendTransaction"))
+	require.True(t, filter.isAllowed("eth_sendRawTransaction"))
+
+	filter = newMethodFilter([]string{"eth", "net_version", " "}, []string{"eth_sendTransaction"})
+	require.True(t, filter.isAllowed("eth_call"))
+	require.True(t, filter.isAllowed("net_version"))
+	require.False(t, filter.isAllowed("net_peerCount"))
+	require.False(t, filter.isAllowed("eth_sendTransaction"))
+	require.False(t, filter.isAllowed("web3_clientVersion"))
+}
+
+func TestDispatcher_AccessChecks(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			chainID:          100,
+			clientRateLimit:  &RateLimit{Rate: 1, Burst: 2},
+			methodRateLimits: map[string]*RateLimit{"net_version": {Rate: 1, Burst: 1}},
+			deniedMethods:    []string{"debug"},
+		},
+	)
+
+	expectErrorCode := func(data []byte, code int) {
+		t.Helper()
+
+		errResp := new(ErrorResponse)
+		require.NoError(t, json.Unmarshal(data, errResp))
+
+		if code == 0 {
+			require.Nil(t, errResp.Error)
+
+			return
+		}
+
+		require.NotNil(t, errResp.Error)
+		require.Equal(t, code, errResp.Error.Code)
+	}
+
+	origin := RequestOrigin{ClientID: "127.0.0.1"}
+
+	data, err := dispatcher.HandleWithOrigin([]byte(`{"method": "debug_traceBlockByNumber", "params": ["0x1"], "id": 1}`), origin)
+	require.NoError(t, err)
+	expectErrorCode(data, -32004)
+
+	data, err = dispatcher.HandleWithOrigin([]byte(`{"method": "net_version", "params": [], "id": 1}`), origin)
+	require.NoError(t, err)
+	expectErrorCode(data, 0)
+
+	data, err = dispatcher.HandleWithOrigin([]byte(`{"method": "net_version", "params": [], "id": 1}`), origin)
+	require.NoError(t, err)
+	expectErrorCode(data, -32005)
+
+	// the requests of the other clients are handled
+	mockConnection := &mockWsConn{
+		SetFilterIDFn:  func(string) {},
+		GetFilterIDFn:  func() string { return "" },
+		WriteMessageFn: func(int, []byte) error { return nil },
+	}
+
+	data, err = dispatcher.HandleWs([]byte(`{"method": "net_version", "params": [], "id": 1}`), mockConnection)
+	require.NoError(t, err)
+	expectErrorCode(data, 0)
+
+	// each request of the batch is checked
+	data, err = dispatcher.HandleWithOrigin([]byte(`[
+		{"method": "web3_clientVersion", "params": [], "id": 1},
+		{"method": "web3_clientVersion", "params": [], "id": 2}
+	]`), origin)
+	require.NoError(t, err)
+
+	var batchResp []*ErrorResponse
+	require.NoError(t, json.Unmarshal(data, &batchResp))
+	require.Len(t, batchResp, 2)
+	require.Nil(t, batchResp[0].Error)
+	require.Equal(t, -32005, batchResp[1].Error.Code)
+}
+
+func TestJSONRPC_ClientID(t *testing.T) {
+	t.Parallel()
+
+	j := &JSONRPC{
+		config:  &Config{APIKeyHeader: "X-API-Key"},
+		apiKeys: map[string]struct{}{"known": {}},
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "/", nil)
+	require.NoError(t, err)
+
+	req.RemoteAddr = "10.0.0.1:40000"
+	require.Equal(t, "10.0.0.1", j.clientID(req))
+
+	// the unknown API keys are ignored
+	req.Header.Set("X-API-Key", "unknown")
+	require.Equal(t, "10.0.0.1", j.clientID(req))
+
+	req.Header.Set("X-API-Key", "known")
+	require.Equal(t, "key:known", j.clientID(req))
+}
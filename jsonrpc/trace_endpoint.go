@@ -0,0 +1,395 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/flattracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	traceTraceType     = "trace"
+	stateDiffTraceType = "stateDiff"
+)
+
+var (
+	// ErrUnsupportedTraceType is an error returned when the requested trace type is not supported
+	ErrUnsupportedTraceType = errors.New("unsupported trace type")
+)
+
+type traceStore interface {
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// ReadTxLookup returns a block hash in which a given txn was mined
+	ReadTxLookup(txnHash types.Hash) (uint64, bool)
+
+	// GetBlockByNumber gets a block using the provided height
+	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// TraceBlock traces all transactions in the given block
+	TraceBlock(*types.Block, tracer.Tracer) ([]interface{}, error)
+
+	// TraceTxn traces a transaction in the block, associated with the given hash
+	TraceTxn(*types.Block, types.Hash, tracer.Tracer) (interface{}, error)
+}
+
+// Trace is the Parity (OpenEthereum) style trace jsonrpc endpoint
+type Trace struct {
+	store           traceStore
+	throttling      *Throttling
+	blockRangeLimit atomic.Uint64
+}
+
+// NewTrace creates the trace endpoint. The throttling is shared with the debug endpoint,
+// so the tracing requests of both namespaces are limited together
+func NewTrace(store traceStore, throttling *Throttling, blockRangeLimit uint64) *Trace {
+	t := &Trace{
+		store:      store,
+		throttling: throttling,
+	}
+
+	t.blockRangeLimit.Store(blockRangeLimit)
+
+	return t
+}
+
+// LocalizedTrace is the flat trace along with the position of its transaction in the chain
+type LocalizedTrace struct {
+	*flattracer.Trace
+	BlockHash           types.Hash `json:"blockHash"`
+	BlockNumber         uint64     `json:"blockNumber"`
+	TransactionHash     types.Hash `json:"transactionHash"`
+	TransactionPosition uint64     `json:"transactionPosition"`
+}
+
+// TraceResults is the result of a replayed transaction, containing the requested trace types
+type TraceResults struct {
+	Output          string               `json:"output"`
+	StateDiff       flattracer.StateDiff `json:"stateDiff"`
+	Trace           []*flattracer.Trace  `json:"trace"`
+	VMTrace         interface{}          `json:"vmTrace"`
+	TransactionHash types.Hash           `json:"transactionHash"`
+}
+
+// TraceFilter is the query of the traces within the block range
+type TraceFilter struct {
+	FromBlock   *BlockNumber    `json:"fromBlock"`
+	ToBlock     *BlockNumber    `json:"toBlock"`
+	FromAddress []types.Address `json:"fromAddress"`
+	ToAddress   []types.Address `json:"toAddress"`
+	After       *argUint64      `json:"after"`
+	Count       *argUint64      `json:"count"`
+}
+
+// match returns true if the trace is made from and to one of the filtered addresses
+func (f *TraceFilter) match(trace *flattracer.Trace) bool {
+	from, to := trace.Action.From, trace.Action.To
+
+	switch {
+	case trace.Action.RefundAddress != nil:
+		from, to = trace.Action.Address, trace.Action.RefundAddress
+	case trace.Result != nil && trace.Result.Address != nil:
+		to = trace.Result.Address
+	}
+
+	return containsAddress(f.FromAddress, from) && containsAddress(f.ToAddress, to)
+}
+
+// Block returns the flat traces of all the transactions in the given block
+func (t *Trace) Block(number BlockNumber) (interface{}, error) {
+	return t.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			block, err := t.getBlock(number)
+			if err != nil {
+				return nil, err
+			}
+
+			results, err := t.replayBlock(block, false)
+			if err != nil {
+				return nil, err
+			}
+
+			traces := make([]*LocalizedTrace, 0)
+			for idx, result := range results {
+				traces = append(traces, localizeTraces(result.Traces, block, idx)...)
+			}
+
+			return traces, nil
+		},
+	)
+}
+
+// Transaction returns the flat traces of the given transaction
+func (t *Trace) Transaction(txHash types.Hash) (interface{}, error) {
+	return t.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			tx, block := GetTxAndBlockByTxHash(txHash, t.store)
+			if tx == nil {
+				return nil, fmt.Errorf("tx %s not found", txHash.String())
+			}
+
+			if block.Number() == 0 {
+				return nil, ErrTraceGenesisBlock
+			}
+
+			tracer := flattracer.NewFlatTracer(flattracer.Config{})
+
+			cancel := cancelOnTimeout(tracer, defaultTraceTimeout)
+			defer cancel()
+
+			res, err := t.store.TraceTxn(block, tx.Hash(), tracer)
+			if err != nil {
+				return nil, err
+			}
+
+			result, ok := res.(*flattracer.TxTrace)
+			if !ok {
+				return nil, fmt.Errorf("unexpected trace result of tx %s", txHash.String())
+			}
+
+			_, idx := types.FindTxByHash(block.Transactions, tx.Hash())
+
+			return localizeTraces(result.Traces, block, idx), nil
+		},
+	)
+}
+
+// ReplayBlockTransactions replays all the transactions in the given block
+// and returns the requested trace types ("trace", "stateDiff") of each one
+func (t *Trace) ReplayBlockTransactions(number BlockNumber, traceTypes []string) (interface{}, error) {
+	return t.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			var withTrace, withStateDiff bool
+
+			for _, traceType := range traceTypes {
+				switch traceType {
+				case traceTraceType:
+					withTrace = true
+				case stateDiffTraceType:
+					withStateDiff = true
+				default:
+					return nil, fmt.Errorf("%w: %s", ErrUnsupportedTraceType, traceType)
+				}
+			}
+
+			block, err := t.getBlock(number)
+			if err != nil {
+				return nil, err
+			}
+
+			results, err := t.replayBlock(block, withStateDiff)
+			if err != nil {
+				return nil, err
+			}
+
+			replayed := make([]*TraceResults, len(results))
+
+			for idx, result := range results {
+				replayed[idx] = &TraceResults{
+					Output:          result.Output,
+					StateDiff:       result.StateDiff,
+					TransactionHash: block.Transactions[idx].Hash(),
+				}
+
+				if withTrace {
+					replayed[idx].Trace = result.Traces
+				}
+			}
+
+			return replayed, nil
+		},
+	)
+}
+
+// Filter returns the flat traces matching the given filter
+func (t *Trace) Filter(filter *TraceFilter) (interface{}, error) {
+	return t.throttling.AttemptRequest(
+		context.Background(),
+		func() (interface{}, error) {
+			if filter == nil {
+				return nil, ErrNoConfig
+			}
+
+			from, to, err := t.getBlockRange(filter)
+			if err != nil {
+				return nil, err
+			}
+
+			var skip, count uint64
+			if filter.After != nil {
+				skip = uint64(*filter.After)
+			}
+
+			if filter.Count != nil {
+				count = uint64(*filter.Count)
+			}
+
+			traces := make([]*LocalizedTrace, 0)
+
+			for num := from; num <= to; num++ {
+				block, ok := t.store.GetBlockByNumber(num, true)
+				if !ok {
+					return nil, fmt.Errorf("block %d not found", num)
+				}
+
+				if len(block.Transactions) == 0 {
+					continue
+				}
+
+				results, err := t.replayBlock(block, false)
+				if err != nil {
+					return nil, err
+				}
+
+				for idx, result := range results {
+					for _, trace := range localizeTraces(result.Traces, block, idx) {
+						if !filter.match(trace.Trace) {
+							continue
+						}
+
+						if skip > 0 {
+							skip--
+
+							continue
+						}
+
+						traces = append(traces, trace)
+
+						if count != 0 && uint64(len(traces)) == count {
+							return traces, nil
+						}
+					}
+				}
+			}
+
+			return traces, nil
+		},
+	)
+}
+
+// getBlockRange returns the block range of the filter, which can't exceed the block range limit
+func (t *Trace) getBlockRange(filter *TraceFilter) (uint64, uint64, error) {
+	fromBlock, toBlock := LatestBlockNumber, LatestBlockNumber
+
+	if filter.FromBlock != nil {
+		fromBlock = *filter.FromBlock
+	}
+
+	if filter.ToBlock != nil {
+		toBlock = *filter.ToBlock
+	}
+
+	from, err := GetNumericBlockNumber(fromBlock, t.store)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	to, err := GetNumericBlockNumber(toBlock, t.store)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if to < from {
+		return 0, 0, ErrIncorrectBlockRange
+	}
+
+	// genesis block can't be traced
+	if from == 0 {
+		from = 1
+	}
+
+	// if not disabled, avoid handling large block ranges
+	if limit := t.blockRangeLimit.Load(); limit != 0 && to-from > limit {
+		return 0, 0, ErrBlockRangeTooHigh
+	}
+
+	return from, to, nil
+}
+
+func (t *Trace) getBlock(number BlockNumber) (*types.Block, error) {
+	num, err := GetNumericBlockNumber(number, t.store)
+	if err != nil {
+		return nil, err
+	}
+
+	if num == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	block, ok := t.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", num)
+	}
+
+	return block, nil
+}
+
+// replayBlock traces all the transactions in the block with the flat tracer
+func (t *Trace) replayBlock(block *types.Block, withStateDiff bool) ([]*flattracer.TxTrace, error) {
+	tracer := flattracer.NewFlatTracer(flattracer.Config{
+		StateDiff: withStateDiff,
+	})
+
+	cancel := cancelOnTimeout(tracer, defaultTraceTimeout)
+	defer cancel()
+
+	res, err := t.store.TraceBlock(block, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*flattracer.TxTrace, len(res))
+
+	for idx, r := range res {
+		result, ok := r.(*flattracer.TxTrace)
+		if !ok {
+			return nil, fmt.Errorf("unexpected trace result of tx %d in block %d", idx, block.Number())
+		}
+
+		results[idx] = result
+	}
+
+	return results, nil
+}
+
+func localizeTraces(traces []*flattracer.Trace, block *types.Block, txIdx int) []*LocalizedTrace {
+	localized := make([]*LocalizedTrace, len(traces))
+
+	for i, trace := range traces {
+		localized[i] = &LocalizedTrace{
+			Trace:               trace,
+			BlockHash:           block.Hash(),
+			BlockNumber:         block.Number(),
+			TransactionHash:     block.Transactions[txIdx].Hash(),
+			TransactionPosition: uint64(txIdx),
+		}
+	}
+
+	return localized
+}
+
+func containsAddress(addrs []types.Address, addr *types.Address) bool {
+	if len(addrs) == 0 {
+		return true
+	}
+
+	if addr == nil {
+		return false
+	}
+
+	for _, a := range addrs {
+		if a == *addr {
+			return true
+		}
+	}
+
+	return false
+}
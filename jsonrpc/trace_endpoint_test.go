@@ -0,0 +1,226 @@
+package jsonrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/flattracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testTraceFrom = types.StringToAddress("1")
+	testTraceTo   = types.StringToAddress("2")
+
+	testFlatTrace = &flattracer.Trace{
+		Action: &flattracer.Action{
+			CallType: "call",
+			From:     &testTraceFrom,
+			To:       &testTraceTo,
+			Gas:      "0x5208",
+			Input:    "0x",
+			Value:    "0x0",
+		},
+		Result:       &flattracer.Result{GasUsed: "0x0", Output: "0x"},
+		TraceAddress: []int{},
+		Type:         "call",
+	}
+
+	testTxTrace = &flattracer.TxTrace{
+		Output: "0x",
+		Traces: []*flattracer.Trace{testFlatTrace},
+	}
+)
+
+func newTraceMockStore(t *testing.T, latest uint64) *debugEndpointMockStore {
+	t.Helper()
+
+	return &debugEndpointMockStore{
+		headerFn: func() *types.Header {
+			return &types.Header{Number: latest}
+		},
+		readTxLookupFn: func(hash types.Hash) (uint64, bool) {
+			return testBlock10.Number(), hash == testTxHash1
+		},
+		getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+			assert.True(t, full)
+
+			if num > latest {
+				return nil, false
+			}
+
+			block := &types.Block{Header: createTestHeader(num, nil)}
+			if num == testBlock10.Number() {
+				block.Transactions = []*types.Transaction{testTx1}
+			}
+
+			return block, true
+		},
+		traceBlockFn: func(block *types.Block, tracer tracer.Tracer) ([]interface{}, error) {
+			assert.IsType(t, &flattracer.FlatTracer{}, tracer)
+
+			results := make([]interface{}, len(block.Transactions))
+			for i := range results {
+				results[i] = testTxTrace
+			}
+
+			return results, nil
+		},
+		traceTxnFn: func(block *types.Block, txHash types.Hash, tracer tracer.Tracer) (interface{}, error) {
+			assert.Equal(t, testTxHash1, txHash)
+			assert.IsType(t, &flattracer.FlatTracer{}, tracer)
+
+			return testTxTrace, nil
+		},
+	}
+}
+
+func TestTraceBlockAndTransaction(t *testing.T) {
+	t.Parallel()
+
+	endpoint := NewTrace(newTraceMockStore(t, 100), NewThrottling(100000, time.Second), 0)
+
+	expected := []*LocalizedTrace{
+		{
+			Trace:               testFlatTrace,
+			BlockHash:           createTestHeader(10, nil).Hash,
+			BlockNumber:         10,
+			TransactionHash:     testTxHash1,
+			TransactionPosition: 0,
+		},
+	}
+
+	res, err := endpoint.Block(BlockNumber(10))
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+
+	res, err = endpoint.Transaction(testTxHash1)
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+
+	_, err = endpoint.Block(BlockNumber(0))
+	require.ErrorIs(t, err, ErrTraceGenesisBlock)
+
+	_, err = endpoint.Transaction(testHash11)
+	require.Error(t, err)
+}
+
+func TestTraceReplayBlockTransactions(t *testing.T) {
+	t.Parallel()
+
+	endpoint := NewTrace(newTraceMockStore(t, 100), NewThrottling(100000, time.Second), 0)
+
+	res, err := endpoint.ReplayBlockTransactions(BlockNumber(10), []string{"trace"})
+	require.NoError(t, err)
+	require.Equal(t, []*TraceResults{
+		{
+			Output:          testTxTrace.Output,
+			Trace:           testTxTrace.Traces,
+			TransactionHash: testTxHash1,
+		},
+	}, res)
+
+	// traces are omitted when only the state diff is requested
+	res, err = endpoint.ReplayBlockTransactions(BlockNumber(10), []string{"stateDiff"})
+	require.NoError(t, err)
+	require.Nil(t, res.([]*TraceResults)[0].Trace)
+
+	_, err = endpoint.ReplayBlockTransactions(BlockNumber(10), []string{"vmTrace"})
+	require.ErrorIs(t, err, ErrUnsupportedTraceType)
+}
+
+func TestTraceFilter(t *testing.T) {
+	t.Parallel()
+
+	blockNumber := func(num BlockNumber) *BlockNumber {
+		return &num
+	}
+
+	count := func(num uint64) *argUint64 {
+		return argUintPtr(num)
+	}
+
+	tests := []struct {
+		name   string
+		filter *TraceFilter
+		limit  uint64
+		count  int
+		err    error
+	}{
+		{
+			name:   "should return the traces in the given range",
+			filter: &TraceFilter{FromBlock: blockNumber(5), ToBlock: blockNumber(15)},
+			count:  1,
+		},
+		{
+			name:   "should trace the latest blocks by default",
+			filter: &TraceFilter{},
+			count:  0,
+		},
+		{
+			name: "should filter the traces by address",
+			filter: &TraceFilter{
+				FromBlock:   blockNumber(EarliestBlockNumber),
+				ToBlock:     blockNumber(10),
+				FromAddress: []types.Address{testTraceFrom},
+				ToAddress:   []types.Address{testTraceFrom},
+			},
+			count: 0,
+		},
+		{
+			name: "should skip the traces before the given offset",
+			filter: &TraceFilter{
+				FromBlock: blockNumber(10),
+				ToBlock:   blockNumber(10),
+				After:     count(1),
+				Count:     count(1),
+			},
+			count: 0,
+		},
+		{
+			name:   "should return an error if the range is incorrect",
+			filter: &TraceFilter{FromBlock: blockNumber(15), ToBlock: blockNumber(5)},
+			err:    ErrIncorrectBlockRange,
+		},
+		{
+			name:   "should return an error if the range exceeds the limit",
+			filter: &TraceFilter{FromBlock: blockNumber(1), ToBlock: blockNumber(20)},
+			limit:  10,
+			err:    ErrBlockRangeTooHigh,
+		},
+		{
+			name:   "should accept the range within the limit",
+			filter: &TraceFilter{FromBlock: blockNumber(EarliestBlockNumber), ToBlock: blockNumber(10)},
+			limit:  10,
+			count:  1,
+		},
+		{
+			name:   "should return an error if the filter is missing",
+			filter: nil,
+			err:    ErrNoConfig,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			endpoint := NewTrace(newTraceMockStore(t, 100), NewThrottling(100000, time.Second), test.limit)
+
+			res, err := endpoint.Filter(test.filter)
+			if test.err != nil {
+				require.ErrorIs(t, err, test.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, res, test.count)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/accounts"
+	"github.com/0xPolygon/polygon-edge/accounts/keystore"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// the example from the EIP-712 specification, signed by the key keccak256("cow")
+const (
+	mailTypedData = `{
+		"types": {
+			"EIP712Domain": [
+				{"name": "name", "type": "string"},
+				{"name": "version", "type": "string"},
+				{"name": "chainId", "type": "uint256"},
+				{"name": "verifyingContract", "type": "address"}
+			],
+			"Person": [{"name": "name", "type": "string"}, {"name": "wallet", "type": "address"}],
+			"Mail": [{"name": "from", "type": "Person"}, {"name": "to", "type": "Person"}, {"name": "contents", "type": "string"}]
+		},
+		"primaryType": "Mail",
+		"domain": {"name": "Ether Mail", "version": "1", "chainId": 1, "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"},
+		"message": {
+			"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+			"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+			"contents": "Hello, Bob!"
+		}
+	}`
+	mailSigner    = "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"
+	mailSignature = "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d" +
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562" +
+		"1c"
+)
+
+func TestSignTypedData(t *testing.T) {
+	t.Parallel()
+
+	const passphrase = "passphrase"
+
+	ks, err := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	key, err := crypto.HexToECDSA(hex.EncodeToString(crypto.Keccak256([]byte("cow"))))
+	require.NoError(t, err)
+
+	manager := accounts.NewManager(nil, ks)
+	t.Cleanup(func() {
+		require.NoError(t, manager.Close())
+	})
+
+	account, err := ks.ImportECDSA(key, passphrase)
+	require.NoError(t, err)
+
+	// the wallet arrives to the manager asynchronously
+	require.Eventually(t, func() bool {
+		_, err := manager.Find(account)
+
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	dispatcher, err := newDispatcher(hclog.NewNullLogger(), newMockStore(), &dispatcherParams{chainID: 1}, manager)
+	require.NoError(t, err)
+
+	call := func(method, params string) []byte {
+		t.Helper()
+
+		data, err := dispatcher.Handle([]byte(fmt.Sprintf(`{"method": "%s", "params": %s, "id": 1}`, method, params)))
+		require.NoError(t, err)
+
+		return data
+	}
+
+	var signature string
+
+	// the typed data is accepted both as the object and as the JSON string
+	encodedTypedData, err := json.Marshal(mailTypedData)
+	require.NoError(t, err)
+
+	// the account is locked
+	require.Error(t, expectJSONResult(
+		call("eth_signTypedData_v4", fmt.Sprintf(`["%s", %s]`, mailSigner, mailTypedData)), &signature))
+
+	require.NoError(t, expectJSONResult(
+		call("personal_signTypedData", fmt.Sprintf(`[%s, "%s", "%s"]`, encodedTypedData, mailSigner, passphrase)),
+		&signature,
+	))
+	require.Equal(t, mailSignature, signature)
+
+	require.Error(t, expectJSONResult(
+		call("personal_signTypedData", fmt.Sprintf(`[%s, "%s", "wrong"]`, mailTypedData, mailSigner)), &signature))
+
+	require.NoError(t, ks.Unlock(account, passphrase))
+
+	require.NoError(t, expectJSONResult(
+		call("eth_signTypedData_v4", fmt.Sprintf(`["%s", %s]`, mailSigner, mailTypedData)), &signature))
+	require.Equal(t, mailSignature, signature)
+
+	require.NoError(t, expectJSONResult(
+		call("eth_signTypedData_v4", fmt.Sprintf(`["%s", %s]`, mailSigner, encodedTypedData)), &signature))
+	require.Equal(t, mailSignature, signature)
+
+	// the signature is recovered to the signer
+	digest, err := accounts.TypedDataHash(mustDecodeTypedData(t, mailTypedData))
+	require.NoError(t, err)
+
+	recoverable, err := hex.DecodeHex(mailSignature)
+	require.NoError(t, err)
+
+	recoverable[64] -= 27
+
+	pub, err := crypto.RecoverPubKey(recoverable, digest)
+	require.NoError(t, err)
+	require.Equal(t, types.StringToAddress(mailSigner), crypto.PubKeyToAddress(pub))
+
+	// the unknown accounts are rejected
+	require.Error(t, expectJSONResult(
+		call("eth_signTypedData_v4", fmt.Sprintf(`["0x0000000000000000000000000000000000000001", %s]`, mailTypedData)),
+		&signature,
+	))
+}
+
+func mustDecodeTypedData(t *testing.T, raw string) *accounts.TypedData {
+	t.Helper()
+
+	typedData := new(accounts.TypedData)
+	require.NoError(t, json.Unmarshal([]byte(raw), typedData))
+
+	return typedData
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/valyala/fastjson"
 )
@@ -643,6 +644,58 @@ func convertToArgUint64SliceSlice(slice [][]uint64) [][]argUint64 {
 	return argSlice
 }
 
+type storageProofResult struct {
+	Key   types.Hash `json:"key"`
+	Value argBig     `json:"value"`
+	Proof []argBytes `json:"proof"`
+}
+
+type accountProofResult struct {
+	Address      types.Address         `json:"address"`
+	AccountProof []argBytes            `json:"accountProof"`
+	Balance      argBig                `json:"balance"`
+	CodeHash     types.Hash            `json:"codeHash"`
+	Nonce        argUint64             `json:"nonce"`
+	StorageHash  types.Hash            `json:"storageHash"`
+	StorageProof []*storageProofResult `json:"storageProof"`
+}
+
+func toAccountProofResult(proof *itrie.AccountProof) *accountProofResult {
+	result := &accountProofResult{
+		Address:      proof.Address,
+		AccountProof: convertToArgBytesSlice(proof.Proof),
+		CodeHash:     types.EmptyCodeHash,
+		StorageHash:  types.EmptyRootHash,
+		StorageProof: make([]*storageProofResult, len(proof.StorageProofs)),
+	}
+
+	if proof.Account != nil {
+		result.Balance = argBig(*proof.Account.Balance)
+		result.CodeHash = types.BytesToHash(proof.Account.CodeHash)
+		result.Nonce = argUint64(proof.Account.Nonce)
+		result.StorageHash = proof.Account.Root
+	}
+
+	for i, storageProof := range proof.StorageProofs {
+		result.StorageProof[i] = &storageProofResult{
+			Key:   storageProof.Key,
+			Value: argBig(*new(big.Int).SetBytes(storageProof.Value)),
+			Proof: convertToArgBytesSlice(storageProof.Proof),
+		}
+	}
+
+	return result
+}
+
+func convertToArgBytesSlice(slice [][]byte) []argBytes {
+	argSlice := make([]argBytes, len(slice))
+	for i, value := range slice {
+		argSlice[i] = argBytes(value)
+	}
+
+	return argSlice
+}
+
 type OverrideAccount struct {
 	Nonce     *argUint64                 `json:"nonce"`
 	Code      *argBytes                  `json:"code"`
@@ -729,6 +782,73 @@ func (s StateOverride) MarshalJSON() ([]byte, error) {
 	return res, nil
 }
 
+// ToType converts the StateOverride to the state override used by the executor
+func (s StateOverride) ToType() types.StateOverride {
+	res := types.StateOverride{}
+
+	for addr, o := range s {
+		res[addr] = o.ToType()
+	}
+
+	return res
+}
+
+// BundleCall is a call of the bundle, executed with the given state override
+type BundleCall struct {
+	txnArgs
+	StateOverrides *StateOverride `json:"stateOverrides"`
+}
+
+// BundleCallResult is the result of a single call of the bundle
+type BundleCallResult struct {
+	ReturnData argBytes  `json:"returnData"`
+	Logs       []*Log    `json:"logs"`
+	GasUsed    argUint64 `json:"gasUsed"`
+	Status     argUint64 `json:"status"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// BlockOverrides is the set of header fields overridden for the call execution
+type BlockOverrides struct {
+	Number     *argUint64     `json:"number"`
+	Difficulty *argUint64     `json:"difficulty"`
+	Time       *argUint64     `json:"time"`
+	GasLimit   *argUint64     `json:"gasLimit"`
+	Coinbase   *types.Address `json:"coinbase"`
+	BaseFee    *argUint64     `json:"baseFee"`
+}
+
+// Apply returns a copy of the given header with the overridden fields
+func (b *BlockOverrides) Apply(header *types.Header) *types.Header {
+	res := header.Copy()
+
+	if b.Number != nil {
+		res.Number = uint64(*b.Number)
+	}
+
+	if b.Difficulty != nil {
+		res.Difficulty = uint64(*b.Difficulty)
+	}
+
+	if b.Time != nil {
+		res.Timestamp = uint64(*b.Time)
+	}
+
+	if b.GasLimit != nil {
+		res.GasLimit = uint64(*b.GasLimit)
+	}
+
+	if b.Coinbase != nil {
+		res.Miner = b.Coinbase.Bytes()
+	}
+
+	if b.BaseFee != nil {
+		res.BaseFee = uint64(*b.BaseFee)
+	}
+
+	return res
+}
+
 // CallMsg contains parameters for contract calls
 type CallMsg struct {
 	From       types.Address  // the sender of the 'transaction'
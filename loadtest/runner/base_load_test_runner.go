@@ -297,21 +297,7 @@ func (r *BaseLoadTestRunner) waitForReceiptsParallel() {
 
 			sequentialEmptyBlocks = 0
 
-			gasUsed := new(big.Int).SetUint64(block.Header.GasUsed)
-			gasLimit := new(big.Int).SetUint64(block.Header.GasLimit)
-			gasUtilization := new(big.Int).Mul(gasUsed, big.NewInt(10000))
-			gasUtilization = gasUtilization.Div(gasUtilization, gasLimit).Div(gasUtilization, big.NewInt(100))
-
-			gu, _ := gasUtilization.Float64()
-
-			blockInfoMap[block.Number()] = &BlockInfo{
-				Number:         block.Number(),
-				CreatedAt:      block.Header.Timestamp,
-				NumTxs:         len(block.Transactions),
-				GasUsed:        new(big.Int).SetUint64(block.Header.GasUsed),
-				GasLimit:       new(big.Int).SetUint64(block.Header.GasLimit),
-				GasUtilization: gu,
-			}
+			blockInfoMap[block.Number()] = newBlockInfo(block.Header, len(block.Transactions))
 
 			totalTxsExecuted += len(block.Transactions)
 			currentBlock++
@@ -371,22 +357,8 @@ func (r *BaseLoadTestRunner) waitForReceipts(txHashes []types.Hash) (map[uint64]
 				continue
 			}
 
-			gasUsed := new(big.Int).SetUint64(block.Header.GasUsed)
-			gasLimit := new(big.Int).SetUint64(block.Header.GasLimit)
-			gasUtilization := new(big.Int).Mul(gasUsed, big.NewInt(10000))
-			gasUtilization = gasUtilization.Div(gasUtilization, gasLimit).Div(gasUtilization, big.NewInt(100))
-
-			gu, _ := gasUtilization.Float64()
-
 			lock.Lock()
-			blockInfoMap[receipt.BlockNumber] = &BlockInfo{
-				Number:         receipt.BlockNumber,
-				CreatedAt:      block.Header.Timestamp,
-				NumTxs:         len(block.Transactions),
-				GasUsed:        new(big.Int).SetUint64(block.Header.GasUsed),
-				GasLimit:       new(big.Int).SetUint64(block.Header.GasLimit),
-				GasUtilization: gu,
-			}
+			blockInfoMap[receipt.BlockNumber] = newBlockInfo(block.Header, len(block.Transactions))
 
 			for _, txn := range block.Transactions {
 				txToBlockMap[txn.Hash()] = receipt.BlockNumber
@@ -876,6 +848,25 @@ func (r *BaseLoadTestRunner) sendTransactionsForUserInBatches(account *account,
 	return txHashes, sendErrs, nil
 }
 
+// newBlockInfo creates the block info from the block header and the number of block transactions
+func newBlockInfo(header *types.Header, numTxs int) *BlockInfo {
+	gasUsed := new(big.Int).SetUint64(header.GasUsed)
+	gasLimit := new(big.Int).SetUint64(header.GasLimit)
+	gasUtilization := new(big.Int).Mul(gasUsed, big.NewInt(10000))
+	gasUtilization = gasUtilization.Div(gasUtilization, gasLimit).Div(gasUtilization, big.NewInt(100))
+
+	gu, _ := gasUtilization.Float64()
+
+	return &BlockInfo{
+		Number:         header.Number,
+		CreatedAt:      header.Timestamp,
+		NumTxs:         numTxs,
+		GasUsed:        gasUsed,
+		GasLimit:       gasLimit,
+		GasUtilization: gu,
+	}
+}
+
 // getFeeData retrieves fee data based on the provided JSON-RPC Ethereum client and dynamicTxs flag.
 // If dynamicTxs is true, it calculates the gasTipCap and gasFeeCap based on the MaxPriorityFeePerGas,
 // FeeHistory, and BaseFee values obtained from the client. If dynamicTxs is false, it calculates the
@@ -15,6 +15,8 @@ const (
 	ERC20TestType  = "erc20"
 	ERC721TestType = "erc721"
 	MixedTestType  = "mixed"
+
+	ScenarioTestType = "scenario"
 )
 
 var receiverAddr = types.StringToAddress("0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF")
@@ -22,7 +24,8 @@ var receiverAddr = types.StringToAddress("0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaD
 func IsLoadTestSupported(loadTestType string) bool {
 	ltp := strings.ToLower(loadTestType)
 
-	return ltp == EOATestType || ltp == ERC20TestType || ltp == ERC721TestType || ltp == MixedTestType
+	return ltp == EOATestType || ltp == ERC20TestType || ltp == ERC721TestType || ltp == MixedTestType ||
+		ltp == ScenarioTestType
 }
 
 type account struct {
@@ -49,6 +52,7 @@ type LoadTestConfig struct {
 
 	LoadTestType string // LoadTestType is the type of load test.
 	LoadTestName string // LoadTestName is the name of the load test.
+	ScenarioFile string // ScenarioFile is the path to the scenario file of the scenario load test.
 
 	JSONRPCUrl      string        // JSONRPCUrl is the URL of the JSON-RPC server.
 	ReceiptsTimeout time.Duration // ReceiptsTimeout is the timeout for waiting for transaction receipts.
@@ -101,6 +105,13 @@ func (r *LoadTestRunner) Run(cfg LoadTestConfig) error {
 		}
 
 		return mixedTxRunner.Run()
+	case ScenarioTestType:
+		scenarioRunner, err := NewScenarioRunner(cfg)
+		if err != nil {
+			return err
+		}
+
+		return scenarioRunner.Run()
 	default:
 		return fmt.Errorf("unknown load test type %s", cfg.LoadTestType)
 	}
@@ -0,0 +1,558 @@
+package runner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Ethernal-Tech/ethgo/abi"
+	"gopkg.in/yaml.v3"
+
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// vuPlaceholder is the argument placeholder resolved to the address of the virtual user
+	vuPlaceholder = "$vu"
+	// placeholderPrefix is the prefix of the argument placeholders,
+	// "$<name>" is resolved to the address of the deployed scenario contract
+	placeholderPrefix = "$"
+)
+
+var (
+	errNoScenarioPhases   = errors.New("scenario has no phases")
+	errNoScenarioCalls    = errors.New("scenario has no calls")
+	errInvalidPhaseTPS    = errors.New("phase tps must be greater than 0")
+	errNoContractCode     = errors.New("contract has neither the artifact nor the bytecode")
+	errUnknownContract    = errors.New("unknown scenario contract")
+	errUnknownMethod      = errors.New("unknown contract method")
+	errUnresolvedArgument = errors.New("unresolved argument placeholder")
+)
+
+// Scenario is the user defined load test, which deploys the contracts
+// and sends the weighted mix of calls at the target TPS of each phase
+type Scenario struct {
+	Name      string              `json:"name" yaml:"name"`
+	Contracts []*ScenarioContract `json:"contracts" yaml:"contracts"`
+	Calls     []*ScenarioCall     `json:"calls" yaml:"calls"`
+	Phases    []*ScenarioPhase    `json:"phases" yaml:"phases"`
+}
+
+// ScenarioContract is the contract deployed by the load test account before the phases start
+type ScenarioContract struct {
+	Name string `json:"name" yaml:"name"`
+	// Artifact is the path to the compiled contract artifact containing the ABI and the bytecode
+	Artifact string `json:"artifact" yaml:"artifact"`
+	// ABI is the path to the ABI file, used together with the bytecode
+	ABI string `json:"abi" yaml:"abi"`
+	// Bytecode is the hex encoded contract creation code
+	Bytecode string `json:"bytecode" yaml:"bytecode"`
+	// ConstructorArgs are the constructor arguments, either by name or in order
+	ConstructorArgs interface{} `json:"constructor_args" yaml:"constructor_args"`
+	Value           string      `json:"value" yaml:"value"`
+	// Setup are the calls sent by the load test account once per each virtual user
+	// after the deployment, "$vu" is resolved to the address of the virtual user
+	Setup []*ScenarioCall `json:"setup" yaml:"setup"`
+
+	abi      *abi.ABI
+	bytecode []byte
+	value    *big.Int
+	address  types.Address
+}
+
+// ScenarioCall is the transaction sent by the virtual users,
+// either the contract call or the native transfer if no contract is set
+type ScenarioCall struct {
+	Name     string `json:"name" yaml:"name"`
+	Contract string `json:"contract" yaml:"contract"`
+	// Method is the method name from the contract ABI or the method signature
+	Method string `json:"method" yaml:"method"`
+	// Args are the method arguments, either by name or in order,
+	// "$vu" is resolved to the address of the sender and "$<name>" to the address of the contract
+	Args interface{} `json:"args" yaml:"args"`
+	// To is the receiver of the native transfer
+	To     string `json:"to" yaml:"to"`
+	Value  string `json:"value" yaml:"value"`
+	Gas    uint64 `json:"gas" yaml:"gas"`
+	Weight uint64 `json:"weight" yaml:"weight"`
+
+	contract *ScenarioContract
+	method   *abi.Method
+	to       types.Address
+	value    *big.Int
+	perVU    bool
+	input    []byte
+}
+
+// ScenarioPhase sends the calls for the given duration,
+// with the target TPS ramping linearly from TPS to EndTPS
+type ScenarioPhase struct {
+	Name     string   `json:"name" yaml:"name"`
+	Duration string   `json:"duration" yaml:"duration"`
+	TPS      float64  `json:"tps" yaml:"tps"`
+	EndTPS   *float64 `json:"end_tps" yaml:"end_tps"`
+
+	duration time.Duration
+}
+
+// LoadScenario reads the scenario from the YAML or JSON file and validates it.
+// The paths of the contract files are relative to the scenario file.
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario file: %w", err)
+	}
+
+	var unmarshalFunc func([]byte, interface{}) error
+
+	switch {
+	case strings.HasSuffix(path, ".json"):
+		unmarshalFunc = json.Unmarshal
+	case strings.HasSuffix(path, ".yaml"), strings.HasSuffix(path, ".yml"):
+		unmarshalFunc = yaml.Unmarshal
+	default:
+		return nil, fmt.Errorf("suffix of %s is neither json, yaml nor yml", path)
+	}
+
+	scenario := &Scenario{}
+	if err := unmarshalFunc(data, scenario); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario file: %w", err)
+	}
+
+	if err := scenario.init(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+
+	return scenario, nil
+}
+
+// init validates the scenario and prepares the contracts, calls and phases for the load test
+func (s *Scenario) init(baseDir string) error {
+	if len(s.Phases) == 0 {
+		return errNoScenarioPhases
+	}
+
+	if len(s.Calls) == 0 {
+		return errNoScenarioCalls
+	}
+
+	deployed := make(map[string]*ScenarioContract, len(s.Contracts))
+
+	for i, contract := range s.Contracts {
+		if contract.Name == "" {
+			contract.Name = fmt.Sprintf("contract%d", i)
+		}
+
+		if _, exists := deployed[contract.Name]; exists || placeholderPrefix+contract.Name == vuPlaceholder {
+			return fmt.Errorf("duplicate or reserved contract name %s", contract.Name)
+		}
+
+		if err := contract.init(baseDir); err != nil {
+			return fmt.Errorf("invalid contract %s: %w", contract.Name, err)
+		}
+
+		deployed[contract.Name] = contract
+	}
+
+	for _, contract := range s.Contracts {
+		for i, call := range contract.Setup {
+			if err := call.init(fmt.Sprintf("%s setup %d", contract.Name, i), deployed); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, call := range s.Calls {
+		if err := call.init(fmt.Sprintf("call%d", i), deployed); err != nil {
+			return err
+		}
+	}
+
+	for i, phase := range s.Phases {
+		if err := phase.init(fmt.Sprintf("phase%d", i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ScenarioContract) init(baseDir string) error {
+	switch {
+	case c.Artifact != "":
+		artifact, err := contracts.LoadArtifactFromFile(resolvePath(baseDir, c.Artifact))
+		if err != nil {
+			return err
+		}
+
+		c.abi = artifact.Abi
+		c.bytecode = artifact.Bytecode
+	case c.Bytecode != "":
+		bytecode, err := hex.DecodeHex(c.Bytecode)
+		if err != nil {
+			return fmt.Errorf("invalid bytecode: %w", err)
+		}
+
+		c.bytecode = bytecode
+
+		if c.ABI != "" {
+			raw, err := os.ReadFile(filepath.Clean(resolvePath(baseDir, c.ABI)))
+			if err != nil {
+				return fmt.Errorf("failed to read ABI file: %w", err)
+			}
+
+			if c.abi, err = abi.NewABI(string(raw)); err != nil {
+				return fmt.Errorf("failed to parse ABI file: %w", err)
+			}
+		}
+	}
+
+	if len(c.bytecode) == 0 {
+		return errNoContractCode
+	}
+
+	if c.ConstructorArgs != nil && (c.abi == nil || c.abi.Constructor == nil) {
+		return errors.New("constructor arguments require the ABI with the constructor")
+	}
+
+	value, err := parseValue(c.Value)
+	if err != nil {
+		return err
+	}
+
+	c.value = value
+
+	return nil
+}
+
+// deploymentInput returns the contract creation code with the encoded constructor arguments
+func (c *ScenarioContract) deploymentInput(deployed map[string]*ScenarioContract) ([]byte, error) {
+	if c.ConstructorArgs == nil {
+		return c.bytecode, nil
+	}
+
+	args, err := resolveArgs(c.ConstructorArgs, deployed, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := c.abi.Constructor.Inputs.Encode(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode constructor arguments of %s: %w", c.Name, err)
+	}
+
+	return append(append([]byte{}, c.bytecode...), input...), nil
+}
+
+func (c *ScenarioCall) init(defaultName string, deployed map[string]*ScenarioContract) error {
+	if c.Name == "" {
+		c.Name = defaultName
+	}
+
+	if c.Weight == 0 {
+		c.Weight = 1
+	}
+
+	value, err := parseValue(c.Value)
+	if err != nil {
+		return fmt.Errorf("invalid call %s: %w", c.Name, err)
+	}
+
+	c.value = value
+	c.perVU = hasPlaceholder(c.Args, vuPlaceholder)
+
+	if c.Contract == "" {
+		// native transfer
+		c.to = receiverAddr
+
+		if c.To != "" {
+			if c.to, err = types.IsValidAddress(c.To, true); err != nil {
+				return fmt.Errorf("invalid call %s: %w", c.Name, err)
+			}
+		}
+
+		if c.Gas == 0 {
+			c.Gas = 21000
+		}
+
+		return nil
+	}
+
+	contract, exists := deployed[c.Contract]
+	if !exists {
+		return fmt.Errorf("invalid call %s: %w %s", c.Name, errUnknownContract, c.Contract)
+	}
+
+	c.contract = contract
+
+	if c.method, err = contract.getMethod(c.Method); err != nil {
+		return fmt.Errorf("invalid call %s: %w", c.Name, err)
+	}
+
+	return nil
+}
+
+// prepare resolves the contract addresses in the arguments and encodes the input
+// of the calls which do not depend on the sender
+func (c *ScenarioCall) prepare(deployed map[string]*ScenarioContract) error {
+	if c.contract != nil {
+		c.to = c.contract.address
+	}
+
+	if c.method == nil || c.perVU {
+		return nil
+	}
+
+	input, err := c.encodeInput(deployed, nil)
+	if err != nil {
+		return err
+	}
+
+	c.input = input
+
+	return nil
+}
+
+// getInput returns the input of the call sent by the given virtual user
+func (c *ScenarioCall) getInput(deployed map[string]*ScenarioContract, vu *types.Address) ([]byte, error) {
+	if c.method == nil || !c.perVU {
+		return c.input, nil
+	}
+
+	return c.encodeInput(deployed, vu)
+}
+
+func (c *ScenarioCall) encodeInput(deployed map[string]*ScenarioContract, vu *types.Address) ([]byte, error) {
+	args, err := resolveArgs(c.Args, deployed, vu)
+	if err != nil {
+		return nil, fmt.Errorf("call %s: %w", c.Name, err)
+	}
+
+	input, err := c.method.Encode(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode arguments of the call %s: %w", c.Name, err)
+	}
+
+	return input, nil
+}
+
+// getMethod returns the method by its name or signature from the contract ABI,
+// or parses the signature if the contract has no ABI
+func (c *ScenarioContract) getMethod(nameOrSignature string) (*abi.Method, error) {
+	if c.abi != nil {
+		if method := c.abi.GetMethod(nameOrSignature); method != nil {
+			return method, nil
+		}
+
+		if method := c.abi.GetMethodBySignature(nameOrSignature); method != nil {
+			return method, nil
+		}
+	}
+
+	if strings.Contains(nameOrSignature, "(") {
+		return abi.NewMethod(nameOrSignature)
+	}
+
+	return nil, fmt.Errorf("%w %s of the contract %s", errUnknownMethod, nameOrSignature, c.Name)
+}
+
+func (p *ScenarioPhase) init(defaultName string) error {
+	if p.Name == "" {
+		p.Name = defaultName
+	}
+
+	duration, err := time.ParseDuration(p.Duration)
+	if err != nil {
+		return fmt.Errorf("invalid duration of phase %s: %w", p.Name, err)
+	}
+
+	if duration <= 0 {
+		return fmt.Errorf("duration of phase %s must be greater than 0", p.Name)
+	}
+
+	p.duration = duration
+
+	if p.TPS <= 0 || (p.EndTPS != nil && *p.EndTPS <= 0) {
+		return fmt.Errorf("invalid phase %s: %w", p.Name, errInvalidPhaseTPS)
+	}
+
+	return nil
+}
+
+// endTPS returns the target TPS at the end of the phase
+func (p *ScenarioPhase) endTPS() float64 {
+	if p.EndTPS == nil {
+		return p.TPS
+	}
+
+	return *p.EndTPS
+}
+
+// targetTxs returns the number of transactions which should be sent until the elapsed time
+// of the phase, which is the integral of the linearly ramping TPS
+func (p *ScenarioPhase) targetTxs(elapsed time.Duration) int {
+	if elapsed > p.duration {
+		elapsed = p.duration
+	}
+
+	t := elapsed.Seconds()
+	rampPerSecond := (p.endTPS() - p.TPS) / p.duration.Seconds()
+
+	return int(p.TPS*t + rampPerSecond*t*t/2)
+}
+
+// callPicker picks the scenario calls by their weights, using the smooth weighted round-robin,
+// so the mix of the calls follows the weights even in the short intervals
+type callPicker struct {
+	calls   []*ScenarioCall
+	current []int64
+	total   int64
+}
+
+func newCallPicker(calls []*ScenarioCall) *callPicker {
+	p := &callPicker{calls: calls, current: make([]int64, len(calls))}
+
+	for _, call := range calls {
+		p.total += int64(call.Weight)
+	}
+
+	return p
+}
+
+func (p *callPicker) next() *ScenarioCall {
+	best := 0
+
+	for i, call := range p.calls {
+		p.current[i] += int64(call.Weight)
+
+		if p.current[i] > p.current[best] {
+			best = i
+		}
+	}
+
+	p.current[best] -= p.total
+
+	return p.calls[best]
+}
+
+// resolveArgs replaces the placeholders in the arguments with the addresses,
+// arrays and named arguments are resolved recursively
+func resolveArgs(args interface{}, deployed map[string]*ScenarioContract, vu *types.Address) (interface{}, error) {
+	switch v := args.(type) {
+	case nil:
+		return []interface{}{}, nil
+	case string:
+		if !strings.HasPrefix(v, placeholderPrefix) {
+			return v, nil
+		}
+
+		if v == vuPlaceholder && vu != nil {
+			return vu.String(), nil
+		}
+
+		if contract, exists := deployed[strings.TrimPrefix(v, placeholderPrefix)]; exists {
+			return contract.address.String(), nil
+		}
+
+		return nil, fmt.Errorf("%w %s", errUnresolvedArgument, v)
+	case []interface{}:
+		res := make([]interface{}, len(v))
+
+		for i, elem := range v {
+			resolved, err := resolveArgs(elem, deployed, vu)
+			if err != nil {
+				return nil, err
+			}
+
+			res[i] = resolved
+		}
+
+		return res, nil
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+
+		for name, elem := range v {
+			resolved, err := resolveArgs(elem, deployed, vu)
+			if err != nil {
+				return nil, err
+			}
+
+			res[name] = resolved
+		}
+
+		return res, nil
+	default:
+		return v, nil
+	}
+}
+
+// hasPlaceholder checks if the arguments contain the given placeholder
+func hasPlaceholder(args interface{}, placeholder string) bool {
+	switch v := args.(type) {
+	case string:
+		return v == placeholder
+	case []interface{}:
+		for _, elem := range v {
+			if hasPlaceholder(elem, placeholder) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		for _, elem := range v {
+			if hasPlaceholder(elem, placeholder) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// parseValue parses the decimal or hex encoded value in wei
+func parseValue(raw string) (*big.Int, error) {
+	if raw == "" {
+		return big.NewInt(0), nil
+	}
+
+	value, err := common.ParseUint256orHex(&raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %s: %w", raw, err)
+	}
+
+	return value, nil
+}
+
+func resolvePath(baseDir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(baseDir, path)
+}
+
+// percentile returns the nearest-rank percentile of the durations
+func percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(float64(len(sorted))*p/100)) - 1
+	if rank < 0 {
+		rank = 0
+	}
+
+	return sorted[rank]
+}
@@ -0,0 +1,851 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/schollz/progressbar/v3"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
+	"github.com/0xPolygon/polygon-edge/txrelayer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// maxScenarioWorkers is the maximum number of routines sending the scenario transactions
+	maxScenarioWorkers = 64
+	// dispatchInterval is the interval in which the transactions due by the target TPS are dispatched
+	dispatchInterval = 50 * time.Millisecond
+	// feeDataRefreshInterval is the interval in which the fee data of the transactions is refreshed
+	feeDataRefreshInterval = 5 * time.Second
+	// blockPollInterval is the interval in which the new blocks are checked for the sent transactions
+	blockPollInterval = 500 * time.Millisecond
+
+	failedReasonReverted    = "execution reverted"
+	failedReasonNotIncluded = "not included before the receipts timeout"
+)
+
+// ScenarioRunner runs the user defined load test scenario
+type ScenarioRunner struct {
+	*BaseLoadTestRunner
+
+	scenario *Scenario
+	// deployed are the deployed scenario contracts by their names
+	deployed map[string]*ScenarioContract
+
+	chainID *big.Int
+	signer  *crypto.LondonSigner
+	feeData atomic.Pointer[feeData]
+
+	tracker *txTracker
+}
+
+// scenarioJob is the call of the scenario sent by the virtual user in the given phase
+type scenarioJob struct {
+	phase int
+	vu    *account
+	call  *ScenarioCall
+}
+
+// NewScenarioRunner creates a new ScenarioRunner instance with the given LoadTestConfig,
+// loading the scenario from the configured scenario file
+func NewScenarioRunner(cfg LoadTestConfig) (*ScenarioRunner, error) {
+	scenario, err := LoadScenario(cfg.ScenarioFile)
+	if err != nil {
+		return nil, err
+	}
+
+	runner, err := NewBaseLoadTestRunner(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScenarioRunner{
+		BaseLoadTestRunner: runner,
+		scenario:           scenario,
+		deployed:           make(map[string]*ScenarioContract, len(scenario.Contracts)),
+		tracker:            newTxTracker(len(scenario.Phases)),
+	}, nil
+}
+
+// Run executes the scenario load test.
+// It performs the following steps:
+// 1. Creates virtual users (VUs).
+// 2. Funds the VUs with native tokens.
+// 3. Deploys the scenario contracts and sends their setup calls for each VU.
+// 4. Estimates the gas of the scenario calls.
+// 5. Runs the phases, sending the weighted mix of calls at the target TPS of each phase,
+// while the new blocks are checked for the sent transactions.
+// 6. Calculates the per phase results and the transactions per second (TPS) based on block information.
+// Returns an error if any of the steps fail.
+func (s *ScenarioRunner) Run() error {
+	fmt.Println("Running scenario load test", s.cfg.LoadTestName, s.scenario.Name)
+
+	if err := s.createVUs(); err != nil {
+		return err
+	}
+
+	if err := s.fundVUs(); err != nil {
+		return err
+	}
+
+	if err := s.deployContracts(); err != nil {
+		return err
+	}
+
+	if err := s.sendSetupCalls(); err != nil {
+		return err
+	}
+
+	if err := s.prepareCalls(); err != nil {
+		return err
+	}
+
+	startBlock, err := s.client.BlockNumber()
+	if err != nil {
+		return err
+	}
+
+	sendingDoneCh := make(chan struct{})
+	watchDoneCh := make(chan struct{})
+
+	go func() {
+		defer close(watchDoneCh)
+
+		s.watchBlocks(startBlock+1, sendingDoneCh)
+	}()
+
+	s.runPhases()
+	close(sendingDoneCh)
+
+	fmt.Println("=============================================================")
+	fmt.Println("Waiting for the sent transactions to be included...")
+
+	<-watchDoneCh
+
+	if err := s.reportPhases(); err != nil {
+		return err
+	}
+
+	blockInfos, totalTxs := s.tracker.blockResults()
+
+	return s.calculateResults(blockInfos, totalTxs)
+}
+
+// deployContracts deploys the scenario contracts in the declared order,
+// so the constructor arguments can refer to the previously deployed contracts
+func (s *ScenarioRunner) deployContracts() error {
+	if len(s.scenario.Contracts) == 0 {
+		return nil
+	}
+
+	fmt.Println("=============================================================")
+	fmt.Println("Deploying scenario contracts")
+
+	start := time.Now().UTC()
+
+	txRelayer, err := txrelayer.NewTxRelayer(
+		txrelayer.WithClient(s.client),
+		txrelayer.WithReceiptsTimeout(s.cfg.ReceiptsTimeout))
+	if err != nil {
+		return err
+	}
+
+	for _, contract := range s.scenario.Contracts {
+		input, err := contract.deploymentInput(s.deployed)
+		if err != nil {
+			return err
+		}
+
+		txn := types.NewTx(types.NewLegacyTx(
+			types.WithTo(nil),
+			types.WithInput(input),
+			types.WithValue(contract.value),
+			types.WithFrom(s.loadTestAccount.key.Address()),
+		))
+
+		receipt, err := txRelayer.SendTransaction(txn, s.loadTestAccount.key)
+		if err != nil {
+			return fmt.Errorf("failed to deploy contract %s: %w", contract.Name, err)
+		}
+
+		if receipt == nil || receipt.Status != uint64(types.ReceiptSuccess) {
+			return fmt.Errorf("failed to deploy contract %s", contract.Name)
+		}
+
+		contract.address = types.Address(receipt.ContractAddress)
+		s.deployed[contract.Name] = contract
+
+		fmt.Printf("Contract %s deployed at %s\n", contract.Name, contract.address)
+	}
+
+	fmt.Printf("Deploying scenario contracts took %s\n", time.Since(start))
+
+	return nil
+}
+
+// sendSetupCalls sends the setup calls of the scenario contracts for each virtual user
+// from the load test account, e.g. to mint the tokens used in the scenario calls
+func (s *ScenarioRunner) sendSetupCalls() error {
+	setupCalls := make([]*ScenarioCall, 0)
+
+	for _, contract := range s.scenario.Contracts {
+		for _, call := range contract.Setup {
+			if err := call.prepare(s.deployed); err != nil {
+				return err
+			}
+
+			setupCalls = append(setupCalls, call)
+		}
+	}
+
+	if len(setupCalls) == 0 {
+		return nil
+	}
+
+	fmt.Println("=============================================================")
+
+	start := time.Now().UTC()
+	bar := progressbar.Default(int64(len(s.vus)*len(setupCalls)), "Sending setup calls for VUs")
+
+	defer func() {
+		_ = bar.Close()
+
+		fmt.Printf("Sending setup calls took %s\n", time.Since(start))
+	}()
+
+	txRelayer, err := txrelayer.NewTxRelayer(
+		txrelayer.WithClient(s.client),
+		txrelayer.WithoutNonceGet(),
+		txrelayer.WithReceiptsTimeout(s.cfg.ReceiptsTimeout),
+	)
+	if err != nil {
+		return err
+	}
+
+	nonce, err := s.client.GetNonce(s.loadTestAccount.key.Address(), jsonrpc.PendingBlockNumberOrHash)
+	if err != nil {
+		return err
+	}
+
+	g, ctx := errgroup.WithContext(context.Background())
+
+	for i, vu := range s.vus {
+		i := i
+		vu := vu
+
+		g.Go(func() error {
+			vuAddress := vu.key.Address()
+
+			for j, call := range setupCalls {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				default:
+				}
+
+				input, err := call.getInput(s.deployed, &vuAddress)
+				if err != nil {
+					return err
+				}
+
+				tx := types.NewTx(types.NewLegacyTx(
+					types.WithTo(&call.to),
+					types.WithInput(input),
+					types.WithValue(call.value),
+					types.WithGas(call.Gas),
+					types.WithNonce(nonce+uint64(i*len(setupCalls)+j)),
+					types.WithFrom(s.loadTestAccount.key.Address()),
+				))
+
+				receipt, err := txRelayer.SendTransaction(tx, s.loadTestAccount.key)
+				if err != nil {
+					return fmt.Errorf("failed to send setup call %s for %s: %w", call.Name, vuAddress, err)
+				}
+
+				if receipt == nil || receipt.Status != uint64(types.ReceiptSuccess) {
+					return fmt.Errorf("failed to send setup call %s for %s", call.Name, vuAddress)
+				}
+
+				_ = bar.Add(1)
+			}
+
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+// prepareCalls encodes the scenario calls and estimates the gas of the calls without the gas limit
+func (s *ScenarioRunner) prepareCalls() error {
+	chainID, err := s.client.ChainID()
+	if err != nil {
+		return err
+	}
+
+	s.chainID = chainID
+	s.signer = crypto.NewLondonSigner(chainID.Uint64())
+
+	if err := s.refreshFeeData(); err != nil {
+		return err
+	}
+
+	vu := s.vus[0]
+	vuAddress := vu.key.Address()
+
+	for _, call := range s.scenario.Calls {
+		if err := call.prepare(s.deployed); err != nil {
+			return err
+		}
+
+		if call.Gas != 0 {
+			continue
+		}
+
+		input, err := call.getInput(s.deployed, &vuAddress)
+		if err != nil {
+			return err
+		}
+
+		gasLimit, err := s.client.EstimateGas(txrelayer.ConvertTxnToCallMsg(s.createTransaction(vu, call, input)))
+		if err != nil {
+			fmt.Printf("Estimating gas of the call %s failed, using the default gas limit: %v\n", call.Name, err)
+
+			gasLimit = txrelayer.DefaultGasLimit
+		} else {
+			gasLimit *= 2 // double it just in case
+		}
+
+		call.Gas = gasLimit
+	}
+
+	return nil
+}
+
+// runPhases runs the scenario phases one after another, dispatching the calls due by the target TPS
+// to the workers sending them, where the calls of each virtual user are sent by the same worker
+// to keep their nonces in order
+func (s *ScenarioRunner) runPhases() {
+	numWorkers := len(s.vus)
+	if numWorkers > maxScenarioWorkers {
+		numWorkers = maxScenarioWorkers
+	}
+
+	jobChs := make([]chan *scenarioJob, numWorkers)
+
+	var wg sync.WaitGroup
+
+	for i := range jobChs {
+		jobChs[i] = make(chan *scenarioJob, 100)
+
+		wg.Add(1)
+
+		go func(jobCh <-chan *scenarioJob) {
+			defer wg.Done()
+
+			for job := range jobCh {
+				s.sendCall(job)
+			}
+		}(jobChs[i])
+	}
+
+	picker := newCallPicker(s.scenario.Calls)
+	nextVU := 0
+
+	for i, phase := range s.scenario.Phases {
+		fmt.Println("=============================================================")
+		fmt.Printf("Running phase %s for %s (target TPS %.2f -> %.2f)\n",
+			phase.Name, phase.duration, phase.TPS, phase.endTPS())
+
+		start := time.Now()
+		lastFeeDataRefresh := start
+		dispatched := 0
+
+		ticker := time.NewTicker(dispatchInterval)
+
+		for {
+			elapsed := time.Since(start)
+
+			for target := phase.targetTxs(elapsed); dispatched < target; dispatched++ {
+				jobChs[nextVU%numWorkers] <- &scenarioJob{phase: i, vu: s.vus[nextVU], call: picker.next()}
+				nextVU = (nextVU + 1) % len(s.vus)
+			}
+
+			if elapsed >= phase.duration {
+				break
+			}
+
+			if time.Since(lastFeeDataRefresh) >= feeDataRefreshInterval {
+				if err := s.refreshFeeData(); err != nil {
+					fmt.Println("Error refreshing fee data:", err)
+				}
+
+				lastFeeDataRefresh = time.Now()
+			}
+
+			<-ticker.C
+		}
+
+		ticker.Stop()
+		s.tracker.phaseFinished(i, time.Since(start))
+
+		fmt.Printf("Phase %s dispatched %d transactions\n", phase.Name, dispatched)
+	}
+
+	for _, jobCh := range jobChs {
+		close(jobCh)
+	}
+
+	wg.Wait()
+}
+
+// sendCall signs and sends the scenario call of the virtual user
+func (s *ScenarioRunner) sendCall(job *scenarioJob) {
+	vuAddress := job.vu.key.Address()
+
+	input, err := job.call.getInput(s.deployed, &vuAddress)
+	if err != nil {
+		s.tracker.sendFailed(job.phase, job.call.Name, err)
+
+		return
+	}
+
+	txn, err := s.signer.SignTxWithCallback(s.createTransaction(job.vu, job.call, input),
+		func(hash types.Hash) (sig []byte, err error) {
+			return job.vu.key.Sign(hash.Bytes())
+		})
+	if err != nil {
+		s.tracker.sendFailed(job.phase, job.call.Name, err)
+
+		return
+	}
+
+	txn.ComputeHash()
+
+	// the transaction is tracked before sending it, so its inclusion is not missed
+	start := time.Now()
+	s.tracker.sending(job.phase, job.call.Name, txn.Hash(), start)
+
+	if _, err := s.client.SendRawTransaction(txn.MarshalRLP()); err != nil {
+		s.tracker.sendFailed(job.phase, job.call.Name, err)
+		s.tracker.remove(txn.Hash())
+
+		return
+	}
+
+	s.tracker.sent(job.phase, time.Since(start))
+
+	// the nonce is used only by the sent transaction, the worker of the user sends its calls sequentially
+	job.vu.nonce++
+}
+
+// createTransaction creates the transaction of the scenario call
+func (s *ScenarioRunner) createTransaction(account *account, call *ScenarioCall, input []byte) *types.Transaction {
+	feeData := s.feeData.Load()
+
+	if s.cfg.DynamicTxs {
+		return types.NewTx(types.NewDynamicFeeTx(
+			types.WithNonce(account.nonce),
+			types.WithTo(&call.to),
+			types.WithValue(call.value),
+			types.WithGas(call.Gas),
+			types.WithFrom(account.key.Address()),
+			types.WithGasFeeCap(feeData.gasFeeCap),
+			types.WithGasTipCap(feeData.gasTipCap),
+			types.WithChainID(s.chainID),
+			types.WithInput(input),
+		))
+	}
+
+	return types.NewTx(types.NewLegacyTx(
+		types.WithNonce(account.nonce),
+		types.WithTo(&call.to),
+		types.WithValue(call.value),
+		types.WithGas(call.Gas),
+		types.WithGasPrice(feeData.gasPrice),
+		types.WithFrom(account.key.Address()),
+		types.WithInput(input),
+	))
+}
+
+func (s *ScenarioRunner) refreshFeeData() error {
+	feeData, err := getFeeData(s.client, s.cfg.DynamicTxs)
+	if err != nil {
+		return err
+	}
+
+	s.feeData.Store(feeData)
+
+	return nil
+}
+
+// watchBlocks checks the new blocks starting from the given one for the sent transactions.
+// Once the sending is done, it waits until all the sent transactions are included,
+// or the receipts timeout passes.
+func (s *ScenarioRunner) watchBlocks(nextBlock uint64, sendingDoneCh <-chan struct{}) {
+	ticker := time.NewTicker(blockPollInterval)
+	defer ticker.Stop()
+
+	var deadline time.Time
+
+	for {
+		select {
+		case <-sendingDoneCh:
+			deadline = time.Now().Add(s.cfg.ReceiptsTimeout)
+			sendingDoneCh = nil
+		case <-ticker.C:
+			head, err := s.client.BlockNumber()
+			if err != nil {
+				fmt.Println("Error getting the latest block number:", err)
+
+				continue
+			}
+
+			for ; nextBlock <= head; nextBlock++ {
+				if err := s.processBlock(nextBlock); err != nil {
+					fmt.Println("Error processing block", nextBlock, err)
+
+					break
+				}
+			}
+
+			if !deadline.IsZero() && (s.tracker.pendingCount() == 0 || time.Now().After(deadline)) {
+				s.tracker.expirePending()
+
+				return
+			}
+		}
+	}
+}
+
+// processBlock marks the sent transactions included in the block
+func (s *ScenarioRunner) processBlock(number uint64) error {
+	receipts, err := s.client.GetBlockReceipts(jsonrpc.BlockNumber(number))
+	if err != nil {
+		return err
+	}
+
+	observedAt := time.Now()
+	included := 0
+
+	for _, receipt := range receipts {
+		if s.tracker.included(types.Hash(receipt.TransactionHash),
+			receipt.Status == uint64(types.ReceiptSuccess), observedAt) {
+			included++
+		}
+	}
+
+	if included == 0 {
+		return nil
+	}
+
+	header, err := s.client.GetHeaderByNumber(jsonrpc.BlockNumber(number))
+	if err != nil {
+		return err
+	}
+
+	s.tracker.addBlock(newBlockInfo(header, len(receipts)))
+
+	return nil
+}
+
+// phaseResult is the result of the scenario phase
+type phaseResult struct {
+	Name           string         `json:"name"`
+	Duration       float64        `json:"duration"`
+	TargetTPS      float64        `json:"targetTps"`
+	TargetEndTPS   float64        `json:"targetEndTps"`
+	Sent           int            `json:"sent"`
+	SendTPS        float64        `json:"sendTps"`
+	Included       int            `json:"included"`
+	Failed         int            `json:"failed"`
+	SendLatencyP50 float64        `json:"sendLatencyP50"`
+	SendLatencyP90 float64        `json:"sendLatencyP90"`
+	SendLatencyP99 float64        `json:"sendLatencyP99"`
+	InclusionP50   float64        `json:"inclusionTimeP50"`
+	InclusionP90   float64        `json:"inclusionTimeP90"`
+	InclusionP99   float64        `json:"inclusionTimeP99"`
+	InclusionMax   float64        `json:"inclusionTimeMax"`
+	FailedReasons  map[string]int `json:"failedReasons"`
+}
+
+// reportPhases prints the results of the scenario phases,
+// or saves them to the JSON file if configured so
+func (s *ScenarioRunner) reportPhases() error {
+	fmt.Println("=============================================================")
+	fmt.Println("Calculating phase results...")
+
+	results := s.tracker.phaseResults(s.scenario.Phases)
+
+	if !s.cfg.ResultsToJSON {
+		printPhaseResults(results)
+
+		return nil
+	}
+
+	jsonData, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+
+	fileName := fmt.Sprintf("./%s_%s_phases.json", s.cfg.LoadTestName, s.cfg.LoadTestType)
+
+	if err := common.SaveFileSafe(fileName, jsonData, 0600); err != nil {
+		return err
+	}
+
+	fmt.Println("Phase results saved to JSON file", fileName)
+
+	return nil
+}
+
+// printPhaseResults prints the phase results and their failed transaction reasons
+// to stdout in a form of a table
+func printPhaseResults(results []*phaseResult) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{
+		"Phase",
+		"Duration (s)",
+		"Target TPS",
+		"Sent",
+		"Send TPS",
+		"Included",
+		"Failed",
+		"Send Latency p50/p90/p99 (ms)",
+		"Inclusion Time p50/p90/p99/max (s)",
+	})
+
+	for _, r := range results {
+		table.Append([]string{
+			r.Name,
+			fmt.Sprintf("%.2f", r.Duration),
+			fmt.Sprintf("%.2f -> %.2f", r.TargetTPS, r.TargetEndTPS),
+			fmt.Sprintf("%d", r.Sent),
+			fmt.Sprintf("%.2f", r.SendTPS),
+			fmt.Sprintf("%d", r.Included),
+			fmt.Sprintf("%d", r.Failed),
+			fmt.Sprintf("%.0f/%.0f/%.0f", r.SendLatencyP50, r.SendLatencyP90, r.SendLatencyP99),
+			fmt.Sprintf("%.2f/%.2f/%.2f/%.2f", r.InclusionP50, r.InclusionP90, r.InclusionP99, r.InclusionMax),
+		})
+	}
+
+	table.Render()
+
+	table = tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Phase", "Failed Reason", "Count"})
+
+	for _, r := range results {
+		reasons := make([]string, 0, len(r.FailedReasons))
+		for reason := range r.FailedReasons {
+			reasons = append(reasons, reason)
+		}
+
+		sort.Strings(reasons)
+
+		for _, reason := range reasons {
+			table.Append([]string{r.Name, reason, fmt.Sprintf("%d", r.FailedReasons[reason])})
+		}
+	}
+
+	if table.NumLines() > 0 {
+		table.Render()
+	}
+}
+
+// trackedTx is the scenario transaction waiting to be included
+type trackedTx struct {
+	phase  int
+	call   string
+	sentAt time.Time
+}
+
+// phaseStats are the statistics of the transactions sent in the scenario phase
+type phaseStats struct {
+	duration       time.Duration
+	sent           int
+	included       int
+	sendLatencies  []time.Duration
+	inclusionTimes []time.Duration
+	failedReasons  map[string]int
+}
+
+func (p *phaseStats) fail(call, reason string) {
+	p.failedReasons[fmt.Sprintf("%s: %s", call, reason)]++
+}
+
+// txTracker tracks the sent scenario transactions until they are included
+type txTracker struct {
+	lock sync.Mutex
+
+	pending    map[types.Hash]*trackedTx
+	phases     []*phaseStats
+	blockInfos map[uint64]*BlockInfo
+	totalTxs   int
+}
+
+func newTxTracker(numPhases int) *txTracker {
+	t := &txTracker{
+		pending:    make(map[types.Hash]*trackedTx),
+		phases:     make([]*phaseStats, numPhases),
+		blockInfos: make(map[uint64]*BlockInfo),
+	}
+
+	for i := range t.phases {
+		t.phases[i] = &phaseStats{failedReasons: make(map[string]int)}
+	}
+
+	return t
+}
+
+func (t *txTracker) sending(phase int, call string, hash types.Hash, sentAt time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.pending[hash] = &trackedTx{phase: phase, call: call, sentAt: sentAt}
+}
+
+func (t *txTracker) sent(phase int, latency time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.phases[phase].sent++
+	t.phases[phase].sendLatencies = append(t.phases[phase].sendLatencies, latency)
+}
+
+func (t *txTracker) sendFailed(phase int, call string, err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.phases[phase].fail(call, err.Error())
+}
+
+func (t *txTracker) remove(hash types.Hash) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.pending, hash)
+}
+
+// included marks the transaction included at the given time,
+// it returns false if the transaction is not tracked
+func (t *txTracker) included(hash types.Hash, success bool, at time.Time) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	tx, exists := t.pending[hash]
+	if !exists {
+		return false
+	}
+
+	delete(t.pending, hash)
+
+	stats := t.phases[tx.phase]
+	stats.included++
+	stats.inclusionTimes = append(stats.inclusionTimes, at.Sub(tx.sentAt))
+	t.totalTxs++
+
+	if !success {
+		stats.fail(tx.call, failedReasonReverted)
+	}
+
+	return true
+}
+
+// expirePending marks all the pending transactions as failed
+func (t *txTracker) expirePending() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for hash, tx := range t.pending {
+		t.phases[tx.phase].fail(tx.call, failedReasonNotIncluded)
+		delete(t.pending, hash)
+	}
+}
+
+func (t *txTracker) pendingCount() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return len(t.pending)
+}
+
+func (t *txTracker) phaseFinished(phase int, duration time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.phases[phase].duration = duration
+}
+
+func (t *txTracker) addBlock(info *BlockInfo) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.blockInfos[info.Number] = info
+}
+
+// blockResults returns the infos of the blocks including the scenario transactions
+// and the number of the included transactions
+func (t *txTracker) blockResults() (map[uint64]*BlockInfo, int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.blockInfos, t.totalTxs
+}
+
+func (t *txTracker) phaseResults(phases []*ScenarioPhase) []*phaseResult {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	results := make([]*phaseResult, len(phases))
+
+	for i, phase := range phases {
+		stats := t.phases[i]
+		failed := 0
+
+		for _, count := range stats.failedReasons {
+			failed += count
+		}
+
+		result := &phaseResult{
+			Name:           phase.Name,
+			Duration:       stats.duration.Seconds(),
+			TargetTPS:      phase.TPS,
+			TargetEndTPS:   phase.endTPS(),
+			Sent:           stats.sent,
+			Included:       stats.included,
+			Failed:         failed,
+			SendLatencyP50: toMilliseconds(percentile(stats.sendLatencies, 50)),
+			SendLatencyP90: toMilliseconds(percentile(stats.sendLatencies, 90)),
+			SendLatencyP99: toMilliseconds(percentile(stats.sendLatencies, 99)),
+			InclusionP50:   percentile(stats.inclusionTimes, 50).Seconds(),
+			InclusionP90:   percentile(stats.inclusionTimes, 90).Seconds(),
+			InclusionP99:   percentile(stats.inclusionTimes, 99).Seconds(),
+			InclusionMax:   percentile(stats.inclusionTimes, 100).Seconds(),
+			FailedReasons:  stats.failedReasons,
+		}
+
+		if stats.duration > 0 {
+			result.SendTPS = float64(stats.sent) / stats.duration.Seconds()
+		}
+
+		results[i] = result
+	}
+
+	return results
+}
+
+func toMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
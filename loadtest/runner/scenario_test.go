@@ -0,0 +1,324 @@
+package runner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const testScenarioYAML = `
+name: token transfers
+contracts:
+  - name: token
+    artifact: Token.json
+    constructor_args:
+      supply: 1000000
+    setup:
+      - method: transfer
+        args: ["$vu", 1000]
+  - name: registry
+    bytecode: "0x6000"
+calls:
+  - name: transfer
+    contract: token
+    method: transfer
+    args:
+      to: "$registry"
+      amount: 1
+    weight: 3
+  - name: register
+    contract: registry
+    method: register(address)
+    args: ["$vu"]
+  - name: native
+    to: "0x0000000000000000000000000000000000000010"
+    value: "0x10"
+phases:
+  - name: ramp up
+    duration: 10s
+    tps: 10
+    end_tps: 30
+  - duration: 1m
+    tps: 30
+`
+
+const testTokenArtifact = `{
+	"abi": [
+		{"type":"constructor","inputs":[{"name":"supply","type":"uint256"}]},
+		{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],
+			"outputs":[{"name":"","type":"bool"}]}
+	],
+	"bytecode": "0x6001",
+	"deployedBytecode": "0x6002"
+}`
+
+func writeTestScenario(t *testing.T, name, content string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Token.json"), []byte(testTokenArtifact), 0600))
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestLoadScenario(t *testing.T) {
+	t.Parallel()
+
+	scenario, err := LoadScenario(writeTestScenario(t, "scenario.yaml", testScenarioYAML))
+	require.NoError(t, err)
+
+	require.Len(t, scenario.Contracts, 2)
+	require.Equal(t, []byte{0x60, 0x01}, scenario.Contracts[0].bytecode)
+	require.NotNil(t, scenario.Contracts[0].abi)
+
+	transfer, register, native := scenario.Calls[0], scenario.Calls[1], scenario.Calls[2]
+	require.Equal(t, uint64(3), transfer.Weight)
+	require.False(t, transfer.perVU)
+	require.Equal(t, "register", register.method.Name)
+	require.True(t, register.perVU)
+	require.Equal(t, uint64(1), register.Weight)
+	require.Equal(t, types.StringToAddress("0x10"), native.to)
+	require.Equal(t, uint64(16), native.value.Uint64())
+	require.Equal(t, uint64(21000), native.Gas)
+
+	require.Equal(t, "phase1", scenario.Phases[1].Name)
+	require.Equal(t, time.Minute, scenario.Phases[1].duration)
+
+	// the contract addresses are resolved once the contracts are deployed
+	deployed := map[string]*ScenarioContract{}
+	for i, contract := range scenario.Contracts {
+		contract.address = types.BytesToAddress([]byte{byte(i + 1)})
+		deployed[contract.Name] = contract
+	}
+
+	input, err := scenario.Contracts[0].deploymentInput(deployed)
+	require.NoError(t, err)
+	require.Len(t, input, 2+32)
+
+	for _, call := range scenario.Calls {
+		require.NoError(t, call.prepare(deployed))
+	}
+
+	require.Equal(t, deployed["token"].address, transfer.to)
+
+	expected, err := transfer.method.Encode([]interface{}{deployed["registry"].address, 1})
+	require.NoError(t, err)
+
+	input, err = transfer.getInput(deployed, nil)
+	require.NoError(t, err)
+	require.Equal(t, expected, input)
+
+	vu := types.StringToAddress("0x20")
+
+	expected, err = register.method.Encode([]interface{}{vu})
+	require.NoError(t, err)
+
+	input, err = register.getInput(deployed, &vu)
+	require.NoError(t, err)
+	require.Equal(t, expected, input)
+
+	// the same scenario in the JSON format
+	jsonScenario, err := LoadScenario(writeTestScenario(t, "scenario.json", `{
+		"contracts": [{"name": "token", "artifact": "Token.json", "constructor_args": [1]}],
+		"calls": [{"contract": "token", "method": "transfer(address,uint256)", "args": ["$vu", 1]}],
+		"phases": [{"duration": "5s", "tps": 1}]
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, "call0", jsonScenario.Calls[0].Name)
+	require.Equal(t, "transfer", jsonScenario.Calls[0].method.Name)
+}
+
+func TestLoadScenario_Invalid(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		scenario string
+		err      error
+		errMsg   string
+	}{
+		{
+			name:     "no phases",
+			scenario: `{"calls": [{}]}`,
+			err:      errNoScenarioPhases,
+		},
+		{
+			name:     "no calls",
+			scenario: `{"phases": [{"duration": "1s", "tps": 1}]}`,
+			err:      errNoScenarioCalls,
+		},
+		{
+			name:     "no contract code",
+			scenario: `{"contracts": [{"name": "c"}], "calls": [{}], "phases": [{"duration": "1s", "tps": 1}]}`,
+			err:      errNoContractCode,
+		},
+		{
+			name:     "unknown contract",
+			scenario: `{"calls": [{"contract": "c", "method": "foo()"}], "phases": [{"duration": "1s", "tps": 1}]}`,
+			err:      errUnknownContract,
+		},
+		{
+			name: "unknown method",
+			scenario: `{"contracts": [{"name": "token", "artifact": "Token.json"}],
+				"calls": [{"contract": "token", "method": "mint"}], "phases": [{"duration": "1s", "tps": 1}]}`,
+			err: errUnknownMethod,
+		},
+		{
+			name:     "invalid tps",
+			scenario: `{"calls": [{}], "phases": [{"duration": "1s", "tps": 1, "end_tps": 0}]}`,
+			err:      errInvalidPhaseTPS,
+		},
+		{
+			name:     "invalid duration",
+			scenario: `{"calls": [{}], "phases": [{"duration": "1 minute", "tps": 1}]}`,
+			errMsg:   "invalid duration of phase phase0",
+		},
+		{
+			name:     "reserved contract name",
+			scenario: `{"contracts": [{"name": "vu", "bytecode": "0x00"}], "calls": [{}], "phases": [{"duration": "1s", "tps": 1}]}`,
+			errMsg:   "duplicate or reserved contract name vu",
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := LoadScenario(writeTestScenario(t, "scenario.json", c.scenario))
+			if c.err != nil {
+				require.ErrorIs(t, err, c.err)
+			} else {
+				require.ErrorContains(t, err, c.errMsg)
+			}
+		})
+	}
+
+	_, err := LoadScenario(writeTestScenario(t, "scenario.toml", ""))
+	require.ErrorContains(t, err, "neither json, yaml nor yml")
+}
+
+func TestScenarioPhase_TargetTxs(t *testing.T) {
+	t.Parallel()
+
+	endTPS := float64(30)
+	ramp := &ScenarioPhase{TPS: 10, EndTPS: &endTPS, duration: 10 * time.Second}
+
+	require.Equal(t, 0, ramp.targetTxs(0))
+	// 10 TPS ramping to 20 TPS in the first 5 seconds
+	require.Equal(t, 75, ramp.targetTxs(5*time.Second))
+	require.Equal(t, 200, ramp.targetTxs(10*time.Second))
+	require.Equal(t, 200, ramp.targetTxs(time.Minute))
+
+	constant := &ScenarioPhase{TPS: 2.5, duration: 4 * time.Second}
+	require.Equal(t, 5, constant.targetTxs(2*time.Second))
+	require.Equal(t, 10, constant.targetTxs(4*time.Second))
+}
+
+func TestCallPicker(t *testing.T) {
+	t.Parallel()
+
+	a, b, c := &ScenarioCall{Name: "a", Weight: 5}, &ScenarioCall{Name: "b", Weight: 1}, &ScenarioCall{Name: "c", Weight: 1}
+	picker := newCallPicker([]*ScenarioCall{a, b, c})
+
+	picked := make([]string, 0, 7)
+	for i := 0; i < 7; i++ {
+		picked = append(picked, picker.next().Name)
+	}
+
+	// the calls are picked by their weights and spread over the round
+	require.Equal(t, []string{"a", "a", "b", "a", "c", "a", "a"}, picked)
+}
+
+func TestResolveArgs(t *testing.T) {
+	t.Parallel()
+
+	vu := types.StringToAddress("0x1")
+	deployed := map[string]*ScenarioContract{"token": {address: types.StringToAddress("0x2")}}
+
+	resolved, err := resolveArgs(map[string]interface{}{
+		"owner":  "$vu",
+		"tokens": []interface{}{"$token", "0x3"},
+		"amount": 10,
+	}, deployed, &vu)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"owner":  vu.String(),
+		"tokens": []interface{}{deployed["token"].address.String(), "0x3"},
+		"amount": 10,
+	}, resolved)
+
+	// the virtual user is not known in the constructor arguments
+	_, err = resolveArgs([]interface{}{"$vu"}, deployed, nil)
+	require.ErrorIs(t, err, errUnresolvedArgument)
+
+	_, err = resolveArgs([]interface{}{"$unknown"}, deployed, &vu)
+	require.ErrorIs(t, err, errUnresolvedArgument)
+}
+
+func TestPercentile(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, time.Duration(0), percentile(nil, 50))
+
+	durations := make([]time.Duration, 0, 100)
+	for i := 100; i > 0; i-- {
+		durations = append(durations, time.Duration(i)*time.Millisecond)
+	}
+
+	require.Equal(t, 50*time.Millisecond, percentile(durations, 50))
+	require.Equal(t, 99*time.Millisecond, percentile(durations, 99))
+	require.Equal(t, 100*time.Millisecond, percentile(durations, 100))
+	require.Equal(t, time.Millisecond, percentile(durations, 0))
+}
+
+func TestTxTracker(t *testing.T) {
+	t.Parallel()
+
+	phases := []*ScenarioPhase{{Name: "first", TPS: 1}, {Name: "second", TPS: 2}}
+	tracker := newTxTracker(len(phases))
+	start := time.Now()
+
+	for i, hash := range []types.Hash{{1}, {2}, {3}} {
+		tracker.sending(0, "transfer", hash, start)
+		tracker.sent(0, time.Duration(i+1)*time.Millisecond)
+	}
+
+	tracker.sending(1, "transfer", types.Hash{4}, start)
+	tracker.sendFailed(1, "transfer", errors.New("nonce too low"))
+	tracker.remove(types.Hash{4})
+	tracker.phaseFinished(0, 2*time.Second)
+
+	require.True(t, tracker.included(types.Hash{1}, true, start.Add(time.Second)))
+	require.True(t, tracker.included(types.Hash{2}, false, start.Add(2*time.Second)))
+	require.False(t, tracker.included(types.Hash{5}, true, start))
+	require.Equal(t, 1, tracker.pendingCount())
+
+	tracker.expirePending()
+	require.Equal(t, 0, tracker.pendingCount())
+
+	results := tracker.phaseResults(phases)
+	require.Equal(t, 3, results[0].Sent)
+	require.Equal(t, 1.5, results[0].SendTPS)
+	require.Equal(t, 2, results[0].Included)
+	require.Equal(t, 2, results[0].Failed)
+	require.Equal(t, 2.0, results[0].SendLatencyP50)
+	require.Equal(t, 2.0, results[0].InclusionMax)
+	require.Equal(t, map[string]int{
+		"transfer: " + failedReasonReverted:    1,
+		"transfer: " + failedReasonNotIncluded: 1,
+	}, results[0].FailedReasons)
+
+	require.Equal(t, 0, results[1].Sent)
+	require.Equal(t, map[string]int{"transfer: nonce too low": 1}, results[1].FailedReasons)
+}
@@ -56,6 +56,10 @@ const (
 var (
 	ErrNoBootnodes  = errors.New("no bootnodes specified")
 	ErrMinBootnodes = errors.New("minimum 1 bootnode is required")
+
+	ErrInvalidPeerLimits       = errors.New("peer limits must not be negative")
+	ErrOutboundPeersAboveStart = errors.New(
+		"max outbound peers can't be raised above the value the server was started with")
 )
 
 type Server struct {
@@ -177,6 +181,22 @@ func (s *Server) HasFreeConnectionSlot(direction network.Direction) bool {
 	return s.connectionCounts.HasFreeConnectionSlot(direction)
 }
 
+// SetMaxPeers changes the maximum number of inbound and outbound peer connections.
+// The limits apply to the new connections, the connected peers are kept [Thread safe]
+func (s *Server) SetMaxPeers(maxInboundPeers, maxOutboundPeers int64) error {
+	if maxInboundPeers < 0 || maxOutboundPeers < 0 {
+		return ErrInvalidPeerLimits
+	}
+
+	if maxOutboundPeers > s.config.MaxOutboundPeers {
+		return ErrOutboundPeersAboveStart
+	}
+
+	s.connectionCounts.setMaxConnCounts(maxInboundPeers, maxOutboundPeers)
+
+	return nil
+}
+
 // PeerConnInfo holds the connection information about the peer
 type PeerConnInfo struct {
 	Info peer.AddrInfo
@@ -355,7 +375,8 @@ func (s *Server) keepAliveMinimumPeerConnections() {
 // Essentially, the networking server monitors for any open connection slots
 // and attempts to fill them as soon as they open up
 func (s *Server) runDial() {
-	slots := NewSlots(s.connectionCounts.maxOutboundConnectionCount)
+	// the dial slots are allocated once, SetMaxPeers doesn't allow more outbound peers than this
+	slots := NewSlots(s.config.MaxOutboundPeers)
 	ctx, cancel := context.WithCancel(context.Background())
 
 	defer cancel()
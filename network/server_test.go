@@ -134,6 +134,39 @@ func TestConnLimit_Outbound(t *testing.T) {
 	}
 }
 
+func TestSetMaxPeers(t *testing.T) {
+	servers, createErr := createServers(1, map[int]*CreateServerParams{
+		0: {
+			ConfigCallback: func(c *Config) {
+				c.MaxInboundPeers = 2
+				c.MaxOutboundPeers = 2
+				c.NoDiscover = true
+			},
+		},
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	server := servers[0]
+
+	assert.ErrorIs(t, server.SetMaxPeers(-1, 1), ErrInvalidPeerLimits)
+	assert.ErrorIs(t, server.SetMaxPeers(2, 3), ErrOutboundPeersAboveStart)
+
+	assert.NoError(t, server.SetMaxPeers(0, 0))
+	assert.False(t, server.HasFreeConnectionSlot(network.DirInbound))
+	assert.False(t, server.HasFreeConnectionSlot(network.DirOutbound))
+
+	// the inbound peers can be raised above the startup value
+	assert.NoError(t, server.SetMaxPeers(4, 2))
+	assert.True(t, server.HasFreeConnectionSlot(network.DirInbound))
+	assert.True(t, server.HasFreeConnectionSlot(network.DirOutbound))
+}
+
 func TestPeerEvent_EmitAndSubscribe(t *testing.T) {
 	server, createErr := CreateServer(&CreateServerParams{ConfigCallback: func(c *Config) {
 		c.NoDiscover = true
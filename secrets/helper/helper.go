@@ -10,11 +10,14 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
 	libp2pCrypto "github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/term"
 
 	"github.com/0xPolygon/polygon-edge/bls"
 	"github.com/0xPolygon/polygon-edge/crypto"
@@ -29,6 +32,9 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+// ErrEmptyPassphrase is returned when the passphrase of the encrypted local secrets is empty
+var ErrEmptyPassphrase = errors.New("empty secrets passphrase")
+
 // SetupLocalSecretsManager is a helper method for boilerplate local secrets manager setup
 func SetupLocalSecretsManager(dataDir string) (secrets.SecretsManager, error) {
 	return local.SecretsManagerFactory(
@@ -42,6 +48,100 @@ func SetupLocalSecretsManager(dataDir string) (secrets.SecretsManager, error) {
 	)
 }
 
+// SetupEncryptedLocalSecretsManager is a helper method for boilerplate encrypted local secrets manager setup,
+// the passphrase is resolved by ReadPassphrase
+func SetupEncryptedLocalSecretsManager(dataDir, passphraseFile string, confirm bool) (secrets.SecretsManager, error) {
+	passphrase, err := ReadPassphrase(passphraseFile, confirm)
+	if err != nil {
+		return nil, err
+	}
+
+	return local.EncryptedSecretsManagerFactory(
+		nil,
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+			Extra: map[string]interface{}{
+				secrets.Path:       dataDir,
+				secrets.Passphrase: passphrase,
+			},
+		},
+	)
+}
+
+// OpenLocalSecretsManager sets up the encrypted local secrets manager if the secrets in the data directory
+// are encrypted, otherwise the plaintext local secrets manager
+func OpenLocalSecretsManager(dataDir, passphraseFile string) (secrets.SecretsManager, error) {
+	if local.IsEncryptedStore(dataDir) {
+		return SetupEncryptedLocalSecretsManager(dataDir, passphraseFile, false)
+	}
+
+	return SetupLocalSecretsManager(dataDir)
+}
+
+// ReadPassphrase reads the passphrase of the encrypted local secrets from the given file,
+// from the environment variable if the file is not specified or from the terminal prompt as the last resort.
+// The prompted passphrase is asked twice if confirm is set
+func ReadPassphrase(passphraseFile string, confirm bool) (string, error) {
+	if passphraseFile != "" {
+		raw, err := os.ReadFile(passphraseFile)
+		if err != nil {
+			return "", fmt.Errorf("unable to read passphrase file: %w", err)
+		}
+
+		passphrase := strings.TrimRight(string(raw), "\r\n")
+		if passphrase == "" {
+			return "", ErrEmptyPassphrase
+		}
+
+		return passphrase, nil
+	}
+
+	if passphrase := os.Getenv(secrets.PassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("no passphrase provided, use the passphrase file or the %s environment variable",
+			secrets.PassphraseEnv)
+	}
+
+	passphrase, err := promptPassphrase("Secrets passphrase: ")
+	if err != nil {
+		return "", err
+	}
+
+	if confirm {
+		repeated, err := promptPassphrase("Repeat secrets passphrase: ")
+		if err != nil {
+			return "", err
+		}
+
+		if repeated != passphrase {
+			return "", errors.New("passphrases do not match")
+		}
+	}
+
+	return passphrase, nil
+}
+
+func promptPassphrase(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+
+	raw, err := term.ReadPassword(int(os.Stdin.Fd()))
+
+	fmt.Fprintln(os.Stderr)
+
+	if err != nil {
+		return "", fmt.Errorf("unable to read passphrase: %w", err)
+	}
+
+	if len(raw) == 0 {
+		return "", ErrEmptyPassphrase
+	}
+
+	return string(raw), nil
+}
+
 // setupHashicorpVault is a helper method for boilerplate hashicorp vault secrets manager setup
 func setupHashicorpVault(
 	secretsConfig *secrets.SecretsManagerConfig,
@@ -207,6 +307,27 @@ func InitJSONTLSCert(secretsManager secrets.SecretsManager) error {
 	return nil
 }
 
+// InitJSONRPCAdminToken generates the random token authorizing the JSON-RPC admin calls
+// and writes it to the secrets manager storage
+func InitJSONRPCAdminToken(secretsManager secrets.SecretsManager) ([]byte, error) {
+	if secretsManager.HasSecret(secrets.JSONRPCAdminToken) {
+		return nil, fmt.Errorf(`secrets "%s" has been already initialized`, secrets.JSONRPCAdminToken)
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+
+	token := []byte(hex.EncodeToString(raw))
+
+	if err := secretsManager.SetSecret(secrets.JSONRPCAdminToken, token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
 // LoadValidatorAddress loads ECDSA key by SecretsManager and returns validator address
 func LoadValidatorAddress(secretsManager secrets.SecretsManager) (types.Address, error) {
 	if !secretsManager.HasSecret(secrets.ValidatorKey) {
@@ -302,6 +423,20 @@ func InitCloudSecretsManager(secretsConfig *secrets.SecretsManagerConfig) (secre
 		}
 
 		secretsManager = alibabaSSM
+	case secrets.EncryptedLocal:
+		path, _ := secretsConfig.Extra[secrets.Path].(string)
+		passphraseFile, _ := secretsConfig.Extra[secrets.PassphraseFile].(string)
+
+		if path == "" {
+			return secretsManager, errors.New("no path specified for encrypted local secrets manager")
+		}
+
+		encryptedLocal, err := SetupEncryptedLocalSecretsManager(path, passphraseFile, false)
+		if err != nil {
+			return secretsManager, err
+		}
+
+		secretsManager = encryptedLocal
 	default:
 		return secretsManager, errors.New("unsupported secrets manager")
 	}
@@ -0,0 +1,216 @@
+package local
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/accounts/keystore"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/secrets"
+)
+
+// encryptedSecretVersion is the version of the encrypted secret file format
+const encryptedSecretVersion = 1
+
+var (
+	// ErrSecretNotEncrypted is returned when the encrypted secrets manager finds the plaintext secret
+	ErrSecretNotEncrypted = errors.New("secret is not encrypted, run the secrets migrate command to encrypt it")
+
+	errEmptyPassphrase = errors.New("no passphrase specified for encrypted local secrets manager")
+)
+
+// encryptedSecret is the file format of the encrypted secret,
+// the crypto section follows the format of the accounts keystore
+type encryptedSecret struct {
+	Crypto  keystore.Crypto `json:"crypto"`
+	Version int             `json:"version"`
+}
+
+// EncryptedLocalSecretsManager is a SecretsManager that stores secrets locally on disk,
+// each of them encrypted with the key derived from the passphrase by scrypt
+type EncryptedLocalSecretsManager struct {
+	*LocalSecretsManager
+
+	passphrase []byte
+
+	// scrypt parameters used for the encryption
+	scryptN int
+	scryptP int
+}
+
+// EncryptedSecretsManagerFactory implements the factory method
+func EncryptedSecretsManagerFactory(
+	config *secrets.SecretsManagerConfig,
+	params *secrets.SecretsManagerParams,
+) (secrets.SecretsManager, error) {
+	passphrase, ok := params.Extra[secrets.Passphrase].(string)
+	if !ok || passphrase == "" {
+		return nil, errEmptyPassphrase
+	}
+
+	localManager, err := SecretsManagerFactory(config, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedLocalSecretsManager{
+		LocalSecretsManager: localManager.(*LocalSecretsManager), //nolint:forcetypeassert
+		passphrase:          []byte(passphrase),
+		scryptN:             keystore.StandardScryptN,
+		scryptP:             keystore.StandardScryptP,
+	}, nil
+}
+
+// GetSecret gets the secret from disk and decrypts it
+func (e *EncryptedLocalSecretsManager) GetSecret(name string) ([]byte, error) {
+	raw, err := e.LocalSecretsManager.GetSecret(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.decrypt(raw)
+}
+
+// SetSecret encrypts the secret and saves it to disk
+func (e *EncryptedLocalSecretsManager) SetSecret(name string, value []byte) error {
+	raw, err := e.encrypt(value)
+	if err != nil {
+		return err
+	}
+
+	return e.LocalSecretsManager.SetSecret(name, raw)
+}
+
+// HasSecret checks if the secret is present on disk and can be decrypted
+func (e *EncryptedLocalSecretsManager) HasSecret(name string) bool {
+	_, err := e.GetSecret(name)
+
+	return err == nil
+}
+
+// EncryptExisting encrypts the plaintext secrets already stored on disk in place
+// and returns the names of the encrypted secrets.
+// The secrets which are already encrypted must be encrypted with the same passphrase
+func (e *EncryptedLocalSecretsManager) EncryptExisting() ([]string, error) {
+	e.secretPathMapLock.RLock()
+	defer e.secretPathMapLock.RUnlock()
+
+	names := make([]string, 0, len(e.secretPathMap))
+	for name := range e.secretPathMap {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	encrypted := make([]string, 0, len(names))
+
+	for _, name := range names {
+		secretPath := e.secretPathMap[name]
+
+		raw, err := os.ReadFile(secretPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return encrypted, fmt.Errorf("unable to read secret from disk (%s), %w", secretPath, err)
+		}
+
+		if IsEncryptedSecret(raw) {
+			if _, err := e.decrypt(raw); err != nil {
+				return encrypted, fmt.Errorf("unable to decrypt secret (%s), %w", secretPath, err)
+			}
+
+			continue
+		}
+
+		if raw, err = e.encrypt(raw); err != nil {
+			return encrypted, err
+		}
+
+		// the secret is replaced by rename, so it is never left partially written
+		tmpPath := secretPath + ".tmp"
+		if err := common.SaveFileSafe(tmpPath, raw, 0440); err != nil {
+			return encrypted, fmt.Errorf("unable to write secret to disk (%s), %w", tmpPath, err)
+		}
+
+		if err := os.Rename(tmpPath, secretPath); err != nil {
+			return encrypted, fmt.Errorf("unable to replace secret (%s), %w", secretPath, err)
+		}
+
+		encrypted = append(encrypted, name)
+	}
+
+	return encrypted, nil
+}
+
+func (e *EncryptedLocalSecretsManager) encrypt(value []byte) ([]byte, error) {
+	cryptoJSON, err := keystore.EncryptData(value, e.passphrase, e.scryptN, e.scryptP)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encrypt secret, %w", err)
+	}
+
+	return json.Marshal(&encryptedSecret{Crypto: cryptoJSON, Version: encryptedSecretVersion})
+}
+
+func (e *EncryptedLocalSecretsManager) decrypt(raw []byte) ([]byte, error) {
+	if !IsEncryptedSecret(raw) {
+		return nil, ErrSecretNotEncrypted
+	}
+
+	var secret encryptedSecret
+	if err := json.Unmarshal(raw, &secret); err != nil {
+		return nil, fmt.Errorf("invalid encrypted secret, %w", err)
+	}
+
+	if secret.Version != encryptedSecretVersion {
+		return nil, fmt.Errorf("encrypted secret version not supported: %d", secret.Version)
+	}
+
+	value, err := keystore.DecryptData(secret.Crypto, string(e.passphrase))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt secret, %w", err)
+	}
+
+	return value, nil
+}
+
+// IsEncryptedSecret checks if the raw secret read from disk is in the encrypted format
+func IsEncryptedSecret(raw []byte) bool {
+	var secret encryptedSecret
+
+	return json.Unmarshal(raw, &secret) == nil && secret.Version != 0 && secret.Crypto.CipherText != ""
+}
+
+// IsEncryptedStore checks if the local secrets in the given directory are encrypted
+func IsEncryptedStore(path string) bool {
+	found, encrypted := inspectStore(path)
+
+	return found && encrypted
+}
+
+// IsPlaintextStore checks if the local secrets in the given directory are stored in plaintext
+func IsPlaintextStore(path string) bool {
+	found, encrypted := inspectStore(path)
+
+	return found && !encrypted
+}
+
+// inspectStore decides whether the local secrets are encrypted by the first of the validator and network keys found
+func inspectStore(path string) (bool, bool) {
+	for _, secretPath := range []string{
+		filepath.Join(path, secrets.ConsensusFolderLocal, secrets.ValidatorKeyLocal),
+		filepath.Join(path, secrets.ConsensusFolderLocal, secrets.ValidatorBLSKeyLocal),
+		filepath.Join(path, secrets.NetworkFolderLocal, secrets.NetworkKeyLocal),
+	} {
+		if raw, err := os.ReadFile(secretPath); err == nil {
+			return true, IsEncryptedSecret(raw)
+		}
+	}
+
+	return false, false
+}
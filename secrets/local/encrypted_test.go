@@ -0,0 +1,118 @@
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/accounts/keystore"
+	"github.com/0xPolygon/polygon-edge/secrets"
+)
+
+func newTestEncryptedSecretsManager(t *testing.T, path, passphrase string) *EncryptedLocalSecretsManager {
+	t.Helper()
+
+	manager, err := EncryptedSecretsManagerFactory(nil, &secrets.SecretsManagerParams{
+		Logger: hclog.NewNullLogger(),
+		Extra: map[string]interface{}{
+			secrets.Path:       path,
+			secrets.Passphrase: passphrase,
+		},
+	})
+	require.NoError(t, err)
+
+	encryptedManager, ok := manager.(*EncryptedLocalSecretsManager)
+	require.True(t, ok)
+
+	// the standard scrypt parameters are too slow for the tests
+	encryptedManager.scryptN, encryptedManager.scryptP = keystore.LightScryptN, keystore.LightScryptP
+
+	return encryptedManager
+}
+
+func TestEncryptedLocalSecretsManager(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+	secret := []byte("validator secret")
+
+	_, err := EncryptedSecretsManagerFactory(nil, &secrets.SecretsManagerParams{
+		Logger: hclog.NewNullLogger(),
+		Extra:  map[string]interface{}{secrets.Path: path},
+	})
+	require.ErrorIs(t, err, errEmptyPassphrase)
+
+	manager := newTestEncryptedSecretsManager(t, path, "passphrase")
+	require.False(t, IsEncryptedStore(path))
+
+	require.NoError(t, manager.SetSecret(secrets.ValidatorKey, secret))
+	require.True(t, manager.HasSecret(secrets.ValidatorKey))
+	require.True(t, IsEncryptedStore(path))
+	require.False(t, IsPlaintextStore(path))
+
+	// the secret isn't stored in plaintext
+	raw, err := os.ReadFile(filepath.Join(path, secrets.ConsensusFolderLocal, secrets.ValidatorKeyLocal))
+	require.NoError(t, err)
+	require.NotContains(t, string(raw), string(secret))
+
+	value, err := manager.GetSecret(secrets.ValidatorKey)
+	require.NoError(t, err)
+	require.Equal(t, secret, value)
+
+	// wrong passphrase
+	wrong := newTestEncryptedSecretsManager(t, path, "wrong")
+	require.False(t, wrong.HasSecret(secrets.ValidatorKey))
+
+	_, err = wrong.GetSecret(secrets.ValidatorKey)
+	require.ErrorContains(t, err, "unable to decrypt secret")
+}
+
+func TestEncryptedLocalSecretsManager_EncryptExisting(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+	plaintext := map[string][]byte{
+		secrets.ValidatorKey: []byte("validator key"),
+		secrets.NetworkKey:   []byte("network key"),
+	}
+
+	plainManager, err := SecretsManagerFactory(nil, &secrets.SecretsManagerParams{
+		Logger: hclog.NewNullLogger(),
+		Extra:  map[string]interface{}{secrets.Path: path},
+	})
+	require.NoError(t, err)
+
+	for name, value := range plaintext {
+		require.NoError(t, plainManager.SetSecret(name, value))
+	}
+
+	require.True(t, IsPlaintextStore(path))
+
+	manager := newTestEncryptedSecretsManager(t, path, "passphrase")
+
+	_, err = manager.GetSecret(secrets.ValidatorKey)
+	require.ErrorIs(t, err, ErrSecretNotEncrypted)
+
+	encrypted, err := manager.EncryptExisting()
+	require.NoError(t, err)
+	require.Equal(t, []string{secrets.NetworkKey, secrets.ValidatorKey}, encrypted)
+	require.True(t, IsEncryptedStore(path))
+
+	for name, value := range plaintext {
+		secret, err := manager.GetSecret(name)
+		require.NoError(t, err)
+		require.Equal(t, value, secret)
+	}
+
+	// the second migration doesn't change anything
+	encrypted, err = manager.EncryptExisting()
+	require.NoError(t, err)
+	require.Empty(t, encrypted)
+
+	// the secrets encrypted with another passphrase are refused
+	_, err = newTestEncryptedSecretsManager(t, path, "wrong").EncryptExisting()
+	require.ErrorContains(t, err, "unable to decrypt secret")
+}
@@ -63,7 +63,12 @@ func (l *LocalSecretsManager) Setup() error {
 	l.secretPathMapLock.Lock()
 	defer l.secretPathMapLock.Unlock()
 
-	subDirectories := []string{secrets.ConsensusFolderLocal, secrets.NetworkFolderLocal, secrets.JSONTLSFolderLocal}
+	subDirectories := []string{
+		secrets.ConsensusFolderLocal,
+		secrets.NetworkFolderLocal,
+		secrets.JSONTLSFolderLocal,
+		secrets.JSONRPCFolderLocal,
+	}
 
 	// Set up the local directories
 	if err := common.SetupDataDir(l.path, subDirectories, 0770); err != nil {
@@ -105,6 +110,13 @@ func (l *LocalSecretsManager) Setup() error {
 		secrets.JSONTLSKeyLocal,
 	)
 
+	// baseDir/jsonrpc/admin.token
+	l.secretPathMap[secrets.JSONRPCAdminToken] = filepath.Join(
+		l.path,
+		secrets.JSONRPCFolderLocal,
+		secrets.JSONRPCAdminTokenLocal,
+	)
+
 	return nil
 }
 
@@ -19,8 +19,18 @@ const (
 
 	// Name is the name of the current node
 	Name = "name"
+
+	// Passphrase is the passphrase the encrypted local secrets are encrypted with
+	Passphrase = "passphrase"
+
+	// PassphraseFile is the path to the file holding the passphrase of the encrypted local secrets
+	PassphraseFile = "passphrase_file"
 )
 
+// PassphraseEnv is the environment variable holding the passphrase of the encrypted local secrets,
+// used when no passphrase file is provided
+const PassphraseEnv = "BLADE_SECRETS_PASSPHRASE"
+
 // Define constant names for available secrets
 const (
 	// ValidatorKey is the private key secret of the validator node
@@ -37,15 +47,19 @@ const (
 
 	// JSONTLSCert is the tls certificate used for json rpc https endpoint
 	JSONTLSCert = "jsontls-pem"
+
+	// JSONRPCAdminToken is the token authorizing the calls to the json rpc admin namespace
+	JSONRPCAdminToken = "jsonrpc-admin-token"
 )
 
 // Define constant file names for the local StorageManager
 const (
-	ValidatorKeyLocal    = "validator.key"
-	ValidatorBLSKeyLocal = "validator-bls.key"
-	NetworkKeyLocal      = "libp2p.key"
-	JSONTLSKeyLocal      = "jsontls.key"
-	JSONTLSCertLocal     = "jsontls.pem"
+	ValidatorKeyLocal      = "validator.key"
+	ValidatorBLSKeyLocal   = "validator-bls.key"
+	NetworkKeyLocal        = "libp2p.key"
+	JSONTLSKeyLocal        = "jsontls.key"
+	JSONTLSCertLocal       = "jsontls.pem"
+	JSONRPCAdminTokenLocal = "admin.token"
 )
 
 // Define constant folder names for the local StorageManager
@@ -53,6 +67,7 @@ const (
 	ConsensusFolderLocal = "consensus"
 	NetworkFolderLocal   = "libp2p"
 	JSONTLSFolderLocal   = "jsontls"
+	JSONRPCFolderLocal   = "jsonrpc"
 )
 
 var (
@@ -66,6 +81,9 @@ const (
 	// Local pertains to the local FS [Default]
 	Local SecretsManagerType = "local"
 
+	// EncryptedLocal pertains to the local FS with the secrets encrypted by the passphrase
+	EncryptedLocal SecretsManagerType = "encrypted-local"
+
 	// HashicorpVault pertains to the Hashicorp Vault server
 	HashicorpVault SecretsManagerType = "hashicorp-vault"
 
@@ -122,5 +140,5 @@ type SecretsManagerFactory func(
 // SupportedServiceManager checks if the passed in service manager type is supported
 func SupportedServiceManager(service SecretsManagerType) bool {
 	return service == HashicorpVault || service == AWSSSM ||
-		service == Local || service == GCPSSM || service == AlibabaSSM
+		service == Local || service == EncryptedLocal || service == GCPSSM || service == AlibabaSSM
 }
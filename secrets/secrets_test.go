@@ -17,6 +17,11 @@ func TestSupportedServiceManager(t *testing.T) {
 			Local,
 			true,
 		},
+		{
+			"Valid encrypted local secrets manager",
+			EncryptedLocal,
+			true,
+		},
 		{
 			"Valid Hashicorp Vault secrets manager",
 			HashicorpVault,
@@ -42,6 +42,7 @@ var consensusBackends = map[ConsensusType]consensus.Factory{
 // secret management solutions
 var secretsManagerBackends = map[secrets.SecretsManagerType]secrets.SecretsManagerFactory{
 	secrets.Local:          local.SecretsManagerFactory,
+	secrets.EncryptedLocal: local.EncryptedSecretsManagerFactory,
 	secrets.HashicorpVault: hashicorpvault.SecretsManagerFactory,
 	secrets.AWSSSM:         awsssm.SecretsManagerFactory,
 	secrets.GCPSSM:         gcpssm.SecretsManagerFactory,
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 )
@@ -26,6 +27,10 @@ type Config struct {
 	MaxAccountEnqueued uint64
 	MaxSlots           uint64
 
+	TxPoolJournal         bool
+	TxPoolJournalRotation time.Duration
+	TxPoolLifetime        time.Duration
+
 	Telemetry *Telemetry
 	Network   *network.Config
 
@@ -50,6 +55,19 @@ type Config struct {
 
 	Relayer bool
 
+	SnapSync bool
+
+	// StatePruningRetention is the number of the latest blocks whose state is kept by the online pruning, 0 disables it
+	StatePruningRetention uint64
+
+	// StateStorageBackend is the database backend of the state storage
+	StateStorageBackend string
+
+	// RemoteSignerURL is the url of the remote signer of the validator keys, empty if the local keys are used
+	RemoteSignerURL string
+	// RemoteSignerTokenFile is the file with the bearer token sent to the remote signer
+	RemoteSignerTokenFile string
+
 	MetricsInterval time.Duration
 
 	EventTracker *EventTracker
@@ -68,6 +86,14 @@ type JSONRPC struct {
 	BlockRangeLimit          uint64
 	ConcurrentRequestsDebug  uint64
 	WebSocketReadLimit       uint64
+	AdminEnabled             bool
+
+	APIKeyHeader     string
+	APIKeys          []string
+	ClientRateLimit  *jsonrpc.RateLimit
+	MethodRateLimits map[string]*jsonrpc.RateLimit
+	AllowedMethods   []string
+	DeniedMethods    []string
 }
 
 type EventTracker struct {
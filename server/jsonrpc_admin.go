@@ -0,0 +1,108 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/0xPolygon/polygon-edge/secrets"
+)
+
+var errEmptyAdminToken = errors.New("json rpc admin token is empty")
+
+// loadJSONRPCAdminToken reads the token authorizing the JSON-RPC admin calls from the secrets manager
+func loadJSONRPCAdminToken(manager secrets.SecretsManager) ([]byte, error) {
+	token, err := manager.GetSecret(secrets.JSONRPCAdminToken)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the %s secret: %w", secrets.JSONRPCAdminToken, err)
+	}
+
+	token = bytes.TrimSpace(token)
+	if len(token) == 0 {
+		return nil, errEmptyAdminToken
+	}
+
+	return token, nil
+}
+
+// GetPeersInfo returns the currently connected peers
+func (j *jsonRPCHub) GetPeersInfo() ([]*jsonrpc.PeerInfo, error) {
+	peers := j.Server.Peers()
+	result := make([]*jsonrpc.PeerInfo, 0, len(peers))
+
+	for _, p := range peers {
+		protocols, err := j.Server.GetProtocols(p.Info.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		addrs := make([]string, len(p.Info.Addrs))
+		for i, addr := range p.Info.Addrs {
+			addrs[i] = addr.String()
+		}
+
+		result = append(result, &jsonrpc.PeerInfo{
+			ID:        p.Info.ID.String(),
+			Addrs:     addrs,
+			Protocols: protocols,
+		})
+	}
+
+	return result, nil
+}
+
+// DisconnectPeer closes the connection to the peer with the given ID
+func (j *jsonRPCHub) DisconnectPeer(rawPeerID string) (bool, error) {
+	peerID, err := peer.Decode(rawPeerID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range j.Server.Peers() {
+		if p.Info.ID == peerID {
+			j.Server.DisconnectFromPeer(peerID, "requested by admin")
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// GetNodeInfo returns the networking information about the node
+func (j *jsonRPCHub) GetNodeInfo() (*jsonrpc.NodeInfo, error) {
+	addrInfo := j.Server.AddrInfo()
+
+	p2pAddr, err := common.AddrInfoToString(addrInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]string, len(addrInfo.Addrs))
+	for i, addr := range addrInfo.Addrs {
+		addrs[i] = addr.String()
+	}
+
+	return &jsonrpc.NodeInfo{
+		ID:      addrInfo.ID.String(),
+		P2PAddr: p2pAddr,
+		Addrs:   addrs,
+	}, nil
+}
+
+// SetLogLevel changes the log level of the node, all the loggers share the level of the root one
+func (j *jsonRPCHub) SetLogLevel(level hclog.Level) {
+	j.logger.SetLevel(level)
+	j.logger.Info("log level changed by admin", "level", level.String())
+}
+
+// ExportChain writes the blocks with the given range to the file at the given path
+func (j *jsonRPCHub) ExportChain(path string, from uint64, to *uint64) (uint64, uint64, error) {
+	return archive.ExportChain(j.Blockchain, j.logger, from, to, path)
+}
@@ -1320,6 +1320,108 @@ var _ interface {
 	ErrorName() string
 } = ExportEventValidationError{}
 
+// Validate checks the field values on ReloadConfigResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReloadConfigResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReloadConfigResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReloadConfigResponseMultiError, or nil if none found.
+func (m *ReloadConfigResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReloadConfigResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ReloadConfigResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReloadConfigResponseMultiError is an error wrapping multiple validation
+// errors returned by ReloadConfigResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ReloadConfigResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReloadConfigResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReloadConfigResponseMultiError) AllErrors() []error { return m }
+
+// ReloadConfigResponseValidationError is the validation error returned by
+// ReloadConfigResponse.Validate if the designated constraints aren't met.
+type ReloadConfigResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReloadConfigResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReloadConfigResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReloadConfigResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReloadConfigResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReloadConfigResponseValidationError) ErrorName() string {
+	return "ReloadConfigResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReloadConfigResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReloadConfigResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReloadConfigResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReloadConfigResponseValidationError{}
+
 // Validate checks the field values on BlockchainEvent_Header with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
@@ -37,6 +37,8 @@ type SystemClient interface {
 	BlockByNumber(ctx context.Context, in *BlockByNumberRequest, opts ...grpc.CallOption) (*BlockResponse, error)
 	// Export returns blockchain data
 	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (System_ExportClient, error)
+	// ReloadConfig re-reads the config file and applies the settings which can be changed at runtime
+	ReloadConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ReloadConfigResponse, error)
 }
 
 type systemClient struct {
@@ -156,6 +158,15 @@ func (x *systemExportClient) Recv() (*ExportEvent, error) {
 	return m, nil
 }
 
+func (c *systemClient) ReloadConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ReloadConfigResponse, error) {
+	out := new(ReloadConfigResponse)
+	err := c.cc.Invoke(ctx, "/v1.System/ReloadConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SystemServer is the server API for System service.
 // All implementations must embed UnimplementedSystemServer
 // for forward compatibility
@@ -174,6 +185,8 @@ type SystemServer interface {
 	BlockByNumber(context.Context, *BlockByNumberRequest) (*BlockResponse, error)
 	// Export returns blockchain data
 	Export(*ExportRequest, System_ExportServer) error
+	// ReloadConfig re-reads the config file and applies the settings which can be changed at runtime
+	ReloadConfig(context.Context, *emptypb.Empty) (*ReloadConfigResponse, error)
 	mustEmbedUnimplementedSystemServer()
 }
 
@@ -202,6 +215,9 @@ func (UnimplementedSystemServer) BlockByNumber(context.Context, *BlockByNumberRe
 func (UnimplementedSystemServer) Export(*ExportRequest, System_ExportServer) error {
 	return status.Errorf(codes.Unimplemented, "method Export not implemented")
 }
+func (UnimplementedSystemServer) ReloadConfig(context.Context, *emptypb.Empty) (*ReloadConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
 func (UnimplementedSystemServer) mustEmbedUnimplementedSystemServer() {}
 
 // UnsafeSystemServer may be embedded to opt out of forward compatibility for this service.
@@ -347,6 +363,24 @@ func (x *systemExportServer) Send(m *ExportEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _System_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.System/ReloadConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).ReloadConfig(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // System_ServiceDesc is the grpc.ServiceDesc for System service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -374,6 +408,10 @@ var System_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BlockByNumber",
 			Handler:    _System_BlockByNumber_Handler,
 		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _System_ReloadConfig_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
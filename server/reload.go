@@ -0,0 +1,254 @@
+package server
+
+import (
+	"errors"
+	"net"
+	"reflect"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
+	"github.com/0xPolygon/polygon-edge/network"
+)
+
+var (
+	// ErrConfigReloadUnavailable is returned when the server has no config loader set
+	ErrConfigReloadUnavailable = errors.New("configuration reload is not available, the server is not started from a config file")
+
+	errInvalidLogLevel = errors.New("invalid log level")
+)
+
+// ConfigLoader reads and validates the configuration from the source the server was started with
+type ConfigLoader func() (*Config, error)
+
+// ReloadResult holds the settings changed by the configuration reload
+type ReloadResult struct {
+	// Applied are the settings applied while the server is running
+	Applied []string
+	// RestartRequired are the changed settings which take effect only after the server restart
+	RestartRequired []string
+}
+
+// restartRequiredSettings are the settings the server reads only on the startup,
+// named after the keys of the config file
+var restartRequiredSettings = []struct {
+	name  string
+	value func(c *Config) interface{}
+}{
+	{"chain_config", func(c *Config) interface{} { return []interface{}{c.Chain.Name, c.Chain.Params.ChainID} }},
+	{"secrets_config", func(c *Config) interface{} { return c.SecretsManager }},
+	{"data_dir", func(c *Config) interface{} { return c.DataDir }},
+	{"grpc_addr", func(c *Config) interface{} { return addrString(c.GRPCAddr) }},
+	{"jsonrpc_addr", func(c *Config) interface{} { return addrString(c.JSONRPC.JSONRPCAddr) }},
+	{"telemetry.prometheus_addr", func(c *Config) interface{} { return addrString(c.Telemetry.PrometheusAddr) }},
+	{"network.no_discover", func(c *Config) interface{} { return c.Network.NoDiscover }},
+	{"network.libp2p_addr", func(c *Config) interface{} { return addrString(c.LibP2PAddr) }},
+	{"network.nat_addr", func(c *Config) interface{} { return c.Network.NatAddr.String() }},
+	{"network.dns_addr", func(c *Config) interface{} { return c.Network.DNS }},
+	{"network.gossip_msg_size", func(c *Config) interface{} { return c.Network.GossipMessageSize }},
+	{"seal", func(c *Config) interface{} { return c.Seal }},
+	{"tx_pool.max_slots", func(c *Config) interface{} { return c.MaxSlots }},
+	{"tx_pool.max_account_enqueued", func(c *Config) interface{} { return c.MaxAccountEnqueued }},
+	{"tx_pool.journal", func(c *Config) interface{} { return c.TxPoolJournal }},
+	{"tx_pool.journal_rotation", func(c *Config) interface{} { return c.TxPoolJournalRotation }},
+	{"tx_pool.lifetime", func(c *Config) interface{} { return c.TxPoolLifetime }},
+	{"log_to", func(c *Config) interface{} { return c.LogFilePath }},
+	{"json_log_format", func(c *Config) interface{} { return c.JSONLogFormat }},
+	{"use_tls", func(c *Config) interface{} { return c.UseTLS }},
+	{"tls_cert_file", func(c *Config) interface{} { return c.TLSCertFile }},
+	{"tls_key_file", func(c *Config) interface{} { return c.TLSKeyFile }},
+	{"relayer", func(c *Config) interface{} { return c.Relayer }},
+	{"snap_sync", func(c *Config) interface{} { return c.SnapSync }},
+	{"state_pruning_retention", func(c *Config) interface{} { return c.StatePruningRetention }},
+	{"state_storage_backend", func(c *Config) interface{} { return c.StateStorageBackend }},
+	{"remote_signer_url", func(c *Config) interface{} { return c.RemoteSignerURL }},
+	{"remote_signer_token_file", func(c *Config) interface{} { return c.RemoteSignerTokenFile }},
+	{"concurrent_requests_debug", func(c *Config) interface{} { return c.JSONRPC.ConcurrentRequestsDebug }},
+	{"web_socket_read_limit", func(c *Config) interface{} { return c.JSONRPC.WebSocketReadLimit }},
+	{"json_rpc_admin", func(c *Config) interface{} { return c.JSONRPC.AdminEnabled }},
+	{"json_rpc_access", func(c *Config) interface{} {
+		return []interface{}{
+			c.JSONRPC.APIKeyHeader, c.JSONRPC.APIKeys, c.JSONRPC.ClientRateLimit,
+			c.JSONRPC.MethodRateLimits, c.JSONRPC.AllowedMethods, c.JSONRPC.DeniedMethods,
+		}
+	}},
+	{"metrics_interval", func(c *Config) interface{} { return c.MetricsInterval }},
+	{"event_tracker", func(c *Config) interface{} { return c.EventTracker }},
+}
+
+// SetConfigLoader sets the loader used by ReloadConfig
+func (s *Server) SetConfigLoader(loader ConfigLoader) {
+	s.reloadLock.Lock()
+	defer s.reloadLock.Unlock()
+
+	s.configLoader = loader
+}
+
+// ReloadConfig reads the configuration using the config loader and applies it by Reload
+func (s *Server) ReloadConfig() (*ReloadResult, error) {
+	s.reloadLock.Lock()
+	loader := s.configLoader
+	s.reloadLock.Unlock()
+
+	if loader == nil {
+		return nil, ErrConfigReloadUnavailable
+	}
+
+	config, err := loader()
+	if err != nil {
+		s.logger.Error("failed to read the configuration", "err", err)
+
+		return nil, err
+	}
+
+	result, err := s.Reload(config)
+	if err != nil {
+		s.logger.Error("failed to reload the configuration", "err", err)
+
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Reload applies the settings which can be changed while the server is running:
+// the txpool price limit, the JSON-RPC batch and block range limits, the CORS origins,
+// the log level and the peer limits. The other changed settings are reported as requiring the restart
+func (s *Server) Reload(config *Config) (*ReloadResult, error) {
+	if config.LogLevel == hclog.NoLevel {
+		return nil, errInvalidLogLevel
+	}
+
+	if config.Network.MaxInboundPeers < 0 || config.Network.MaxOutboundPeers < 0 {
+		return nil, network.ErrInvalidPeerLimits
+	}
+
+	s.reloadLock.Lock()
+	defer s.reloadLock.Unlock()
+
+	result := getReloadResult(s.config, config)
+
+	// the current config is copied on change, because the components keep the references to its parts
+	current := *s.config
+
+	applied := make([]string, 0, len(result.Applied))
+
+	for _, setting := range result.Applied {
+		switch setting {
+		case "tx_pool.price_limit":
+			s.txpool.SetPriceLimit(config.PriceLimit)
+			current.PriceLimit = config.PriceLimit
+
+		case "log_level":
+			s.logger.SetLevel(config.LogLevel)
+			current.LogLevel = config.LogLevel
+
+		case "network.max_peers":
+			// the dial slots are allocated on the startup, raising the outbound peers above it requires the restart
+			if err := s.network.SetMaxPeers(
+				config.Network.MaxInboundPeers,
+				config.Network.MaxOutboundPeers,
+			); errors.Is(err, network.ErrOutboundPeersAboveStart) {
+				result.RestartRequired = append(result.RestartRequired, setting)
+
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			networkConfig := *current.Network
+			networkConfig.MaxPeers = config.Network.MaxPeers
+			networkConfig.MaxInboundPeers = config.Network.MaxInboundPeers
+			networkConfig.MaxOutboundPeers = config.Network.MaxOutboundPeers
+			current.Network = &networkConfig
+		}
+
+		applied = append(applied, setting)
+	}
+
+	result.Applied = applied
+
+	// the price limit is also the minimal gas price suggested by the JSON-RPC
+	if isJSONRPCChanged(s.config, config) {
+		jsonRPCConfig := *current.JSONRPC
+		jsonRPCConfig.AccessControlAllowOrigin = config.JSONRPC.AccessControlAllowOrigin
+		jsonRPCConfig.BatchLengthLimit = config.JSONRPC.BatchLengthLimit
+		jsonRPCConfig.BlockRangeLimit = config.JSONRPC.BlockRangeLimit
+		current.JSONRPC = &jsonRPCConfig
+
+		s.jsonrpcServer.UpdateParams(&jsonrpc.RuntimeParams{
+			AccessControlAllowOrigin: config.JSONRPC.AccessControlAllowOrigin,
+			PriceLimit:               config.PriceLimit,
+			BatchLengthLimit:         config.JSONRPC.BatchLengthLimit,
+			BlockRangeLimit:          config.JSONRPC.BlockRangeLimit,
+		})
+	}
+
+	s.config = &current
+
+	s.logger.Info("configuration reloaded", "applied", result.Applied)
+
+	if len(result.RestartRequired) > 0 {
+		s.logger.Warn("changed settings take effect after the restart", "settings", result.RestartRequired)
+	}
+
+	return result, nil
+}
+
+// getReloadResult compares the current and the reloaded configuration
+// and sorts the changed settings into the applied and the restart required ones
+func getReloadResult(current, reloaded *Config) *ReloadResult {
+	result := &ReloadResult{
+		Applied:         []string{},
+		RestartRequired: []string{},
+	}
+
+	if current.PriceLimit != reloaded.PriceLimit {
+		result.Applied = append(result.Applied, "tx_pool.price_limit")
+	}
+
+	if current.JSONRPC.BatchLengthLimit != reloaded.JSONRPC.BatchLengthLimit {
+		result.Applied = append(result.Applied, "json_rpc_batch_request_limit")
+	}
+
+	if current.JSONRPC.BlockRangeLimit != reloaded.JSONRPC.BlockRangeLimit {
+		result.Applied = append(result.Applied, "json_rpc_block_range_limit")
+	}
+
+	if !reflect.DeepEqual(current.JSONRPC.AccessControlAllowOrigin, reloaded.JSONRPC.AccessControlAllowOrigin) {
+		result.Applied = append(result.Applied, "cors_allowed_origins")
+	}
+
+	if current.LogLevel != reloaded.LogLevel {
+		result.Applied = append(result.Applied, "log_level")
+	}
+
+	if current.Network.MaxInboundPeers != reloaded.Network.MaxInboundPeers ||
+		current.Network.MaxOutboundPeers != reloaded.Network.MaxOutboundPeers {
+		result.Applied = append(result.Applied, "network.max_peers")
+	}
+
+	for _, setting := range restartRequiredSettings {
+		if !reflect.DeepEqual(setting.value(current), setting.value(reloaded)) {
+			result.RestartRequired = append(result.RestartRequired, setting.name)
+		}
+	}
+
+	return result
+}
+
+// isJSONRPCChanged checks if any of the settings applied to the JSON-RPC server are changed
+func isJSONRPCChanged(current, reloaded *Config) bool {
+	return current.PriceLimit != reloaded.PriceLimit ||
+		current.JSONRPC.BatchLengthLimit != reloaded.JSONRPC.BatchLengthLimit ||
+		current.JSONRPC.BlockRangeLimit != reloaded.JSONRPC.BlockRangeLimit ||
+		!reflect.DeepEqual(current.JSONRPC.AccessControlAllowOrigin, reloaded.JSONRPC.AccessControlAllowOrigin)
+}
+
+func addrString(addr *net.TCPAddr) string {
+	if addr == nil {
+		return ""
+	}
+
+	return addr.String()
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/network"
+)
+
+func newTestReloadConfig() *Config {
+	return &Config{
+		Chain: &chain.Chain{Name: "test", Params: &chain.Params{ChainID: 100}},
+		JSONRPC: &JSONRPC{
+			JSONRPCAddr:              &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: DefaultJSONRPCPort},
+			AccessControlAllowOrigin: []string{"*"},
+			BatchLengthLimit:         20,
+			BlockRangeLimit:          1000,
+		},
+		GRPCAddr:   &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: DefaultGRPCPort},
+		LibP2PAddr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: network.DefaultLibp2pPort},
+		PriceLimit: 1,
+		Telemetry:  &Telemetry{},
+		Network:    network.DefaultConfig(),
+		DataDir:    "data",
+		LogLevel:   hclog.Info,
+	}
+}
+
+func TestGetReloadResult(t *testing.T) {
+	t.Parallel()
+
+	current := newTestReloadConfig()
+
+	result := getReloadResult(current, newTestReloadConfig())
+	require.Empty(t, result.Applied)
+	require.Empty(t, result.RestartRequired)
+
+	reloaded := newTestReloadConfig()
+	reloaded.PriceLimit = 10
+	reloaded.JSONRPC.BatchLengthLimit = 30
+	reloaded.JSONRPC.BlockRangeLimit = 500
+	reloaded.JSONRPC.AccessControlAllowOrigin = []string{"http://localhost"}
+	reloaded.LogLevel = hclog.Debug
+	reloaded.Network.MaxInboundPeers = 10
+	reloaded.DataDir = "other"
+	reloaded.GRPCAddr = &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: DefaultGRPCPort}
+	reloaded.Chain = &chain.Chain{Name: "test", Params: &chain.Params{ChainID: 200}}
+
+	result = getReloadResult(current, reloaded)
+	require.Equal(t, []string{
+		"tx_pool.price_limit",
+		"json_rpc_batch_request_limit",
+		"json_rpc_block_range_limit",
+		"cors_allowed_origins",
+		"log_level",
+		"network.max_peers",
+	}, result.Applied)
+	require.Equal(t, []string{"chain_config", "data_dir", "grpc_addr"}, result.RestartRequired)
+}
+
+func TestServer_ReloadConfig(t *testing.T) {
+	t.Parallel()
+
+	logger := hclog.New(&hclog.LoggerOptions{Level: hclog.Info, Output: io.Discard})
+	server := &Server{
+		logger: logger.Named("server"),
+		config: newTestReloadConfig(),
+	}
+
+	_, err := server.ReloadConfig()
+	require.ErrorIs(t, err, ErrConfigReloadUnavailable)
+
+	loadErr := errors.New("invalid config file")
+	server.SetConfigLoader(func() (*Config, error) { return nil, loadErr })
+
+	_, err = server.ReloadConfig()
+	require.ErrorIs(t, err, loadErr)
+
+	reloaded := newTestReloadConfig()
+	server.SetConfigLoader(func() (*Config, error) { return reloaded, nil })
+
+	// invalid settings are refused
+	reloaded.LogLevel = hclog.NoLevel
+
+	_, err = server.ReloadConfig()
+	require.ErrorIs(t, err, errInvalidLogLevel)
+
+	reloaded.LogLevel = hclog.Info
+	reloaded.Network.MaxOutboundPeers = -1
+
+	_, err = server.ReloadConfig()
+	require.ErrorIs(t, err, network.ErrInvalidPeerLimits)
+
+	// the log level of all the loggers is changed
+	reloaded.Network.MaxOutboundPeers = network.DefaultConfig().MaxOutboundPeers
+	reloaded.LogLevel = hclog.Debug
+	reloaded.DataDir = "other"
+
+	result, err := server.ReloadConfig()
+	require.NoError(t, err)
+	require.Equal(t, []string{"log_level"}, result.Applied)
+	require.Equal(t, []string{"data_dir"}, result.RestartRequired)
+
+	require.True(t, logger.IsDebug())
+	require.Equal(t, hclog.Debug, server.config.LogLevel)
+	require.Equal(t, "data", server.config.DataDir)
+}
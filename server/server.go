@@ -10,6 +10,8 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
@@ -18,6 +20,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/0xPolygon/polygon-edge/accounts"
+	"github.com/0xPolygon/polygon-edge/accounts/hdwallet"
 	"github.com/0xPolygon/polygon-edge/accounts/keystore"
 	"github.com/0xPolygon/polygon-edge/archive"
 	"github.com/0xPolygon/polygon-edge/blockchain"
@@ -36,6 +39,8 @@ import (
 	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
+	secretsHelper "github.com/0xPolygon/polygon-edge/secrets/helper"
+	"github.com/0xPolygon/polygon-edge/secrets/local"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 	"github.com/0xPolygon/polygon-edge/state"
 	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
@@ -93,6 +98,10 @@ type Server struct {
 
 	// gasHelper is providing functions regarding gas and fees
 	gasHelper *gasprice.GasHelper
+
+	// configLoader reads the configuration on reload, reloadLock serializes the reloads
+	configLoader ConfigLoader
+	reloadLock   sync.Mutex
 }
 
 // newFileLogger returns logger instance that writes all logs to a specified file.
@@ -212,7 +221,11 @@ func NewServer(config *Config) (*Server, error) {
 	}
 
 	// start blockchain object
-	stateStorage, err := itrie.NewLevelDBStorage(filepath.Join(m.config.DataDir, "trie"), logger)
+	stateStorage, err := itrie.NewStorage(
+		m.config.StateStorageBackend,
+		filepath.Join(m.config.DataDir, itrie.StorageDir(m.config.StateStorageBackend)),
+		logger,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -347,7 +360,7 @@ func NewServer(config *Config) (*Server, error) {
 
 	// setup account manager
 	{
-		keystore, err := keystore.NewKeyStore(
+		keyStore, err := keystore.NewKeyStore(
 			filepath.Join(config.DataDir, "account-store"),
 			keystore.LightScryptN,
 			keystore.LightScryptP,
@@ -356,7 +369,16 @@ func NewServer(config *Config) (*Server, error) {
 			return nil, err
 		}
 
-		m.accManager = accounts.NewManager(m.blockchain, keystore)
+		hdStore, err := hdwallet.NewHDStore(
+			filepath.Join(config.DataDir, "hd-wallets"),
+			keystore.LightScryptN,
+			keystore.LightScryptP,
+			m.logger)
+		if err != nil {
+			return nil, err
+		}
+
+		m.accManager = accounts.NewManager(m.blockchain, keyStore, hdStore)
 	}
 
 	// here we can provide some other configuration
@@ -373,6 +395,11 @@ func NewServer(config *Config) (*Server, error) {
 			Blockchain: m.blockchain,
 		}
 
+		var journalPath string
+		if m.config.TxPoolJournal && m.config.DataDir != "" {
+			journalPath = filepath.Join(m.config.DataDir, "txpool.journal")
+		}
+
 		// start transaction pool
 		m.txpool, err = txpool.NewTxPool(
 			logger,
@@ -386,6 +413,9 @@ func NewServer(config *Config) (*Server, error) {
 				MaxAccountEnqueued: m.config.MaxAccountEnqueued,
 				ChainID:            big.NewInt(m.config.Chain.Params.ChainID),
 				PeerID:             m.network.AddrInfo().ID,
+				JournalPath:        journalPath,
+				JournalRotation:    m.config.TxPoolJournalRotation,
+				Lifetime:           m.config.TxPoolLifetime,
 			},
 		)
 		if err != nil {
@@ -411,6 +441,14 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	if m.config.StatePruningRetention > 0 {
+		if err := st.EnablePruning(m.blockchain.Header().StateRoot, m.config.StatePruningRetention); err != nil {
+			return nil, err
+		}
+
+		m.blockchain.SetStatePruner(st)
+	}
+
 	// initialize data in consensus layer
 	if err := m.consensus.Initialize(); err != nil {
 		return nil, err
@@ -535,12 +573,35 @@ func (s *Server) setupSecretsManager() error {
 		Logger: s.logger,
 	}
 
-	if secretsManagerType == secrets.Local {
+	// The encrypted local secrets are used without the config as well
+	if secretsManagerType == secrets.Local && local.IsEncryptedStore(s.config.DataDir) {
+		secretsManagerType = secrets.EncryptedLocal
+	}
+
+	switch secretsManagerType {
+	case secrets.Local:
 		// Only the base directory is required for
 		// the local secrets manager
 		secretsManagerParams.Extra = map[string]interface{}{
 			secrets.Path: s.config.DataDir,
 		}
+	case secrets.EncryptedLocal:
+		path, _ := secretsManagerConfig.Extra[secrets.Path].(string)
+		if path == "" {
+			path = s.config.DataDir
+		}
+
+		passphraseFile, _ := secretsManagerConfig.Extra[secrets.PassphraseFile].(string)
+
+		passphrase, err := secretsHelper.ReadPassphrase(passphraseFile, false)
+		if err != nil {
+			return err
+		}
+
+		secretsManagerParams.Extra = map[string]interface{}{
+			secrets.Path:       path,
+			secrets.Passphrase: passphrase,
+		}
 	}
 
 	// Grab the factory method
@@ -590,12 +651,26 @@ func (s *Server) setupConsensus() error {
 		}
 	}
 
+	var remoteSignerToken string
+
+	if s.config.RemoteSignerTokenFile != "" {
+		token, err := os.ReadFile(s.config.RemoteSignerTokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read the remote signer token: %w", err)
+		}
+
+		remoteSignerToken = strings.TrimSpace(string(token))
+	}
+
 	config := &consensus.Config{
-		Params:      s.config.Chain.Params,
-		Config:      engineConfig,
-		Path:        filepath.Join(s.config.DataDir, "consensus"),
-		IsRelayer:   s.config.Relayer,
-		RPCEndpoint: s.config.JSONRPC.JSONRPCAddr.String(),
+		Params:            s.config.Chain.Params,
+		Config:            engineConfig,
+		Path:              filepath.Join(s.config.DataDir, "consensus"),
+		IsRelayer:         s.config.Relayer,
+		SnapSync:          s.config.SnapSync,
+		RPCEndpoint:       s.config.JSONRPC.JSONRPCAddr.String(),
+		RemoteSignerURL:   s.config.RemoteSignerURL,
+		RemoteSignerToken: remoteSignerToken,
 	}
 
 	consensus, err := engine(
@@ -606,6 +681,7 @@ func (s *Server) setupConsensus() error {
 			Network:         s.network,
 			Blockchain:      s.blockchain,
 			Executor:        s.executor,
+			StateStorage:    s.stateStorage,
 			Grpc:            s.grpcServer,
 			Logger:          s.logger,
 			SecretsManager:  s.secretsManager,
@@ -656,6 +732,7 @@ func extractBlockTime(engineConfig map[string]interface{}) (common.Duration, err
 }
 
 type jsonRPCHub struct {
+	logger             hclog.Logger
 	state              state.State
 	restoreProgression *progress.ProgressionWrapper
 
@@ -721,6 +798,16 @@ func (j *jsonRPCHub) GetCode(root types.Hash, addr types.Address) ([]byte, error
 	return code, nil
 }
 
+// GetProof returns the Merkle proof of the account and its storage slots in the state with the given root
+func (j *jsonRPCHub) GetProof(root types.Hash, addr types.Address, keys []types.Hash) (*itrie.AccountProof, error) {
+	prover, ok := j.state.(*itrie.State)
+	if !ok {
+		return nil, fmt.Errorf("state proofs are not supported by the state storage")
+	}
+
+	return prover.GetProof(root, addr, keys)
+}
+
 func (j *jsonRPCHub) ApplyTxn(
 	header *types.Header,
 	txn *types.Transaction,
@@ -750,6 +837,68 @@ func (j *jsonRPCHub) ApplyTxn(
 	return
 }
 
+// ApplyTxnBundle applies the transactions one after another on top of the state of the given header
+func (j *jsonRPCHub) ApplyTxnBundle(
+	header *types.Header,
+	txns []*types.Transaction,
+	overrides []types.StateOverride,
+	nonPayable bool,
+) ([]*jsonrpc.AppliedTxn, error) {
+	blockCreator, err := j.GetConsensus().GetBlockCreator(header)
+	if err != nil {
+		return nil, err
+	}
+
+	transition, err := j.BeginTxn(header.StateRoot, header, blockCreator)
+	if err != nil {
+		return nil, err
+	}
+
+	transition.SetNonPayable(nonPayable)
+
+	var (
+		applied = make([]*jsonrpc.AppliedTxn, 0, len(txns))
+		gasLeft = header.GasLimit
+	)
+
+	for i, txn := range txns {
+		if i < len(overrides) && overrides[i] != nil {
+			if err := transition.WithStateOverride(overrides[i]); err != nil {
+				return nil, fmt.Errorf("call %d: %w", i, err)
+			}
+		}
+
+		// the calls are executed in order, so the nonce is taken from the state changed by the previous ones
+		txn.SetNonce(transition.GetNonce(txn.From()))
+
+		if txn.Gas() == 0 {
+			txn.SetGas(gasLeft)
+		}
+
+		txn.ComputeHash()
+
+		result, err := transition.Apply(txn)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+
+		logs := transition.Txn().Logs()
+
+		// the suicided accounts are deleted before the next call, like between the block transactions
+		if err := transition.Txn().CleanDeleteObjects(true); err != nil {
+			return nil, err
+		}
+
+		gasLeft -= result.GasUsed
+		applied = append(applied, &jsonrpc.AppliedTxn{
+			Result: result,
+			Logs:   logs,
+		})
+	}
+
+	return applied, nil
+}
+
 // TraceBlock traces all transactions in the given block and returns all results
 func (j *jsonRPCHub) TraceBlock(
 	block *types.Block,
@@ -846,9 +995,11 @@ func (j *jsonRPCHub) TraceTxn(
 	return tracer.GetResult()
 }
 
+// TraceCall traces a single call on top of the state of the given header
 func (j *jsonRPCHub) TraceCall(
 	tx *types.Transaction,
 	parentHeader *types.Header,
+	override types.StateOverride,
 	tracer tracer.Tracer,
 ) (interface{}, error) {
 	blockCreator, err := j.GetConsensus().GetBlockCreator(parentHeader)
@@ -861,6 +1012,12 @@ func (j *jsonRPCHub) TraceCall(
 		return nil, err
 	}
 
+	if override != nil {
+		if err := transition.WithStateOverride(override); err != nil {
+			return nil, err
+		}
+	}
+
 	transition.SetTracer(tracer)
 
 	if _, err := transition.Apply(tx); err != nil {
@@ -889,6 +1046,7 @@ func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
 // setupJSONRCP sets up the JSONRPC server, using the set configuration
 func (s *Server) setupJSONRPC() error {
 	hub := &jsonRPCHub{
+		logger:             s.logger,
 		state:              s.state,
 		restoreProgression: s.restoreProgression,
 		Blockchain:         s.blockchain,
@@ -915,6 +1073,21 @@ func (s *Server) setupJSONRPC() error {
 		TLSCertFile:              s.config.TLSCertFile,
 		TLSKeyFile:               s.config.TLSKeyFile,
 		SecretsManager:           s.secretsManager,
+		APIKeyHeader:             s.config.JSONRPC.APIKeyHeader,
+		APIKeys:                  s.config.JSONRPC.APIKeys,
+		ClientRateLimit:          s.config.JSONRPC.ClientRateLimit,
+		MethodRateLimits:         s.config.JSONRPC.MethodRateLimits,
+		AllowedMethods:           s.config.JSONRPC.AllowedMethods,
+		DeniedMethods:            s.config.JSONRPC.DeniedMethods,
+	}
+
+	if s.config.JSONRPC.AdminEnabled {
+		token, err := loadJSONRPCAdminToken(s.secretsManager)
+		if err != nil {
+			return err
+		}
+
+		conf.AdminToken = token
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf, s.accManager)
@@ -176,6 +176,19 @@ func (s *systemService) BlockByNumber(
 	}, nil
 }
 
+// ReloadConfig re-reads the config file and applies the settings which can be changed at runtime
+func (s *systemService) ReloadConfig(_ context.Context, _ *empty.Empty) (*proto.ReloadConfigResponse, error) {
+	result, err := s.server.ReloadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ReloadConfigResponse{
+		Applied:         result.Applied,
+		RestartRequired: result.RestartRequired,
+	}, nil
+}
+
 func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_ExportServer) error {
 	var (
 		from uint64 = 0
@@ -649,6 +649,11 @@ func NewGasLimitReachedTransitionApplicationError(err error) *GasLimitReachedTra
 func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, error) {
 	var err error
 
+	stateTracer, _ := t.ctx.Tracer.(tracer.StateTracer)
+	if stateTracer != nil {
+		stateTracer.TxPrepare(msg, t.ctx.Coinbase, t)
+	}
+
 	if msg.Type() == types.StateTxType {
 		err = checkAndProcessStateTx(msg)
 	} else {
@@ -756,6 +761,10 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	// return gas to the pool
 	t.addGasPool(result.GasLeft)
 
+	if stateTracer != nil {
+		stateTracer.TxFinalize(t)
+	}
+
 	return result, nil
 }
 
@@ -1120,6 +1129,14 @@ func (t *Transition) SetStorage(
 	return t.state.SetStorage(addr, key, value, config)
 }
 
+func (t *Transition) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	return t.state.GetTransientState(addr, key)
+}
+
+func (t *Transition) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	t.state.SetTransientState(addr, key, value)
+}
+
 func (t *Transition) GetTxContext() runtime.TxContext {
 	return t.ctx
 }
@@ -1160,6 +1177,11 @@ func (t *Transition) Empty(addr types.Address) bool {
 	return t.state.Empty(addr)
 }
 
+// Deleted returns true if the account is deleted when the transaction is finished
+func (t *Transition) Deleted(addr types.Address) bool {
+	return t.state.HasSuicided(addr) || t.state.Empty(addr)
+}
+
 func (t *Transition) GetNonce(addr types.Address) uint64 {
 	return t.state.GetNonce(addr)
 }
@@ -1169,6 +1191,19 @@ func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address)
 		t.state.AddRefund(24000)
 	}
 
+	// EIP-6780: the account is deleted only if it was created in the same transaction,
+	// otherwise just the balance is transferred to the beneficiary
+	if t.config.Cancun && !t.state.IsCreatedInTx(addr) {
+		if addr != beneficiary {
+			balance := t.state.GetBalance(addr)
+
+			t.state.SetBalance(addr, big.NewInt(0))
+			t.state.AddBalance(beneficiary, balance)
+		}
+
+		return
+	}
+
 	t.state.AddBalance(beneficiary, t.state.GetBalance(addr))
 	t.state.Suicide(addr)
 }
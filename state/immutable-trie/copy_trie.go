@@ -242,3 +242,46 @@ func NewTrieWithRoot(root Node) *Trie {
 		root: root,
 	}
 }
+
+// CopyStorage copies all the keys (the trie nodes, the codes and the pruning data) to the new storage
+// and returns the number of the copied keys. It is used to migrate the storage to another backend
+func CopyStorage(storage IterableStorage, newStorage Storage, batchSize int) (int, error) {
+	var (
+		batchWriter = newStorage.Batch()
+		pending     = 0
+		copied      = 0
+	)
+
+	err := storage.ForEachKey(func(k []byte) error {
+		v, ok, err := storage.Get(k)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return nil
+		}
+
+		batchWriter.Put(k, v)
+		copied++
+
+		if pending++; pending >= batchSize {
+			if err := batchWriter.Write(); err != nil {
+				return err
+			}
+
+			batchWriter, pending = newStorage.Batch(), 0
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := batchWriter.Write(); err != nil {
+		return 0, err
+	}
+
+	return copied, nil
+}
@@ -0,0 +1,169 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	// ErrInvalidProof is returned when the proven values don't match the proof
+	ErrInvalidProof = errors.New("invalid proof")
+)
+
+// AccountProof is the Merkle proof of the account and its storage slots against the state root (EIP-1186)
+type AccountProof struct {
+	Address types.Address
+	// Account is nil if the account doesn't exist, the proof then proves its absence
+	Account *state.Account
+	Proof   [][]byte
+
+	StorageProofs []*StorageProof
+}
+
+// StorageProof is the Merkle proof of the storage slot against the storage root of the account
+type StorageProof struct {
+	Key types.Hash
+	// Value is the slot value without the leading zeros, empty if the slot is not set
+	Value []byte
+	Proof [][]byte
+}
+
+// GetProof returns the proof of the account and the given storage slots in the state with the given root
+func (s *State) GetProof(root types.Hash, addr types.Address, keys []types.Hash) (*AccountProof, error) {
+	snap, err := s.NewSnapshotAt(root)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := snap.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := Prove(s.storage, root, crypto.Keccak256(addr.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AccountProof{
+		Address:       addr,
+		Account:       account,
+		Proof:         proof,
+		StorageProofs: make([]*StorageProof, len(keys)),
+	}
+
+	storageRoot := types.EmptyRootHash
+	if account != nil {
+		storageRoot = account.Root
+	}
+
+	for i, key := range keys {
+		storageProof := &StorageProof{Key: key}
+
+		if storageProof.Proof, err = Prove(s.storage, storageRoot, crypto.Keccak256(key.Bytes())); err != nil {
+			return nil, err
+		}
+
+		if storageProof.Value, err = VerifyStorageProof(storageRoot, key, storageProof.Proof); err != nil {
+			return nil, err
+		}
+
+		result.StorageProofs[i] = storageProof
+	}
+
+	return result, nil
+}
+
+// Verify checks the account and the storage slots against the given state root
+func (p *AccountProof) Verify(root types.Hash) error {
+	account, err := VerifyAccountProof(root, p.Address, p.Proof)
+	if err != nil {
+		return err
+	}
+
+	if !accountsEqual(account, p.Account) {
+		return fmt.Errorf("%w: account %s mismatch", ErrInvalidProof, p.Address)
+	}
+
+	storageRoot := types.EmptyRootHash
+	if account != nil {
+		storageRoot = account.Root
+	}
+
+	for _, storageProof := range p.StorageProofs {
+		value, err := VerifyStorageProof(storageRoot, storageProof.Key, storageProof.Proof)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(value, storageProof.Value) {
+			return fmt.Errorf("%w: storage slot %s mismatch", ErrInvalidProof, storageProof.Key)
+		}
+	}
+
+	return nil
+}
+
+// VerifyAccountProof returns the account proven against the state root by the given proof,
+// or nil if the proof shows the account doesn't exist
+func VerifyAccountProof(root types.Hash, addr types.Address, proof [][]byte) (*state.Account, error) {
+	data, err := VerifyProof(root, crypto.Keccak256(addr.Bytes()), proof)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+
+	if data == nil {
+		return nil, nil
+	}
+
+	var account state.Account
+	if err := account.UnmarshalRlp(data); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode account: %w", ErrInvalidProof, err)
+	}
+
+	return &account, nil
+}
+
+// VerifyStorageProof returns the value of the storage slot proven against the storage root by the given proof,
+// the value is empty if the proof shows the slot is not set
+func VerifyStorageProof(storageRoot types.Hash, key types.Hash, proof [][]byte) ([]byte, error) {
+	data, err := VerifyProof(storageRoot, crypto.Keccak256(key.Bytes()), proof)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+
+	if data == nil {
+		return []byte{}, nil
+	}
+
+	p := &fastrlp.Parser{}
+
+	v, err := p.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode storage value: %w", ErrInvalidProof, err)
+	}
+
+	value, err := v.GetBytes(nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode storage value: %w", ErrInvalidProof, err)
+	}
+
+	return value, nil
+}
+
+func accountsEqual(a, b *state.Account) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Nonce == b.Nonce &&
+		a.Balance.Cmp(b.Balance) == 0 &&
+		a.Root == b.Root &&
+		bytes.Equal(a.CodeHash, b.CodeHash)
+}
@@ -0,0 +1,58 @@
+package itrie
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState_GetProof(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	root := buildTestState(t, storage)
+	st := NewState(storage)
+
+	// the account with the storage
+	key := types.BytesToHash([]byte{0x3})
+	missingKey := types.BytesToHash([]byte{0xff})
+
+	proof, err := st.GetProof(root, types.BytesToAddress([]byte{0x1}), []types.Hash{key, missingKey})
+	require.NoError(t, err)
+	require.NotNil(t, proof.Account)
+	require.NotEqual(t, types.EmptyRootHash, proof.Account.Root)
+	require.Equal(t, []byte{0x3}, proof.StorageProofs[0].Value)
+	require.Empty(t, proof.StorageProofs[1].Value)
+	require.NoError(t, proof.Verify(root))
+
+	// the proof doesn't match the other values
+	proof.StorageProofs[0].Value = []byte{0x4}
+	require.ErrorIs(t, proof.Verify(root), ErrInvalidProof)
+
+	proof.StorageProofs[0].Value = []byte{0x3}
+	proof.Account.Nonce++
+	require.ErrorIs(t, proof.Verify(root), ErrInvalidProof)
+
+	// the incomplete proof is invalid
+	proof.Account.Nonce--
+	proof.Proof = proof.Proof[:len(proof.Proof)-1]
+	require.ErrorIs(t, proof.Verify(root), ErrInvalidProof)
+
+	// the account without the storage
+	proof, err = st.GetProof(root, types.BytesToAddress([]byte{0x2}), []types.Hash{key})
+	require.NoError(t, err)
+	require.Equal(t, types.EmptyRootHash, proof.Account.Root)
+	require.Empty(t, proof.StorageProofs[0].Proof)
+	require.NoError(t, proof.Verify(root))
+
+	// the missing account
+	proof, err = st.GetProof(root, types.StringToAddress("ff01"), []types.Hash{key})
+	require.NoError(t, err)
+	require.Nil(t, proof.Account)
+	require.NotEmpty(t, proof.Proof)
+	require.NoError(t, proof.Verify(root))
+
+	_, err = st.GetProof(types.StringToHash("01"), types.BytesToAddress([]byte{0x1}), nil)
+	require.ErrorIs(t, err, ErrStateNotAvailable)
+}
@@ -0,0 +1,455 @@
+package itrie
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+const (
+	// pruneBatchSize is the number of the keys deleted by the offline pruning in one batch
+	pruneBatchSize = 10000
+)
+
+var (
+	// refCountPrefix is the prefix of the reference counters of the trie nodes
+	refCountPrefix = []byte("refcount")
+
+	// prunerRootsKey is the key of the state roots retained by the online pruning
+	prunerRootsKey = []byte("pruner-roots")
+
+	// ErrStateNotAvailable is returned when the state with the given root is not in the storage
+	ErrStateNotAvailable = errors.New("state is not available, it might have been pruned")
+)
+
+// PruneResult is the result of the offline pruning
+type PruneResult struct {
+	RetainedRoots int
+	MissingRoots  int
+	KeptNodes     int
+	DeletedNodes  int
+}
+
+// Prune deletes the trie nodes which are not reachable from the given state roots, ordered from the oldest.
+// The contract codes are kept. If the online pruning was enabled on the storage, its reference counters
+// are rebuilt for the retained roots. It must not be used while the storage is used by a running node
+func Prune(storage IterableStorage, roots []types.Hash) (*PruneResult, error) {
+	result := &PruneResult{}
+	reachable := make(map[types.Hash]struct{})
+	retained := make([]types.Hash, 0, len(roots))
+
+	_, onlinePruning, err := storage.Get(prunerRootsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, root := range roots {
+		if root == types.EmptyRootHash {
+			continue
+		}
+
+		if _, ok, err := storage.Get(root.Bytes()); err != nil {
+			return nil, err
+		} else if !ok {
+			result.MissingRoots++
+
+			continue
+		}
+
+		if err := markReachable(storage, root, reachable); err != nil {
+			return nil, err
+		}
+
+		result.RetainedRoots++
+		retained = append(retained, root)
+	}
+
+	result.KeptNodes = len(reachable)
+
+	batch := storage.Batch()
+	pending := 0
+
+	// the reference counters are deleted along with the nodes, the kept nodes are counted again below
+	err = storage.ForEachKey(func(k []byte) error {
+		if len(k) == types.HashLength {
+			if _, ok := reachable[types.BytesToHash(k)]; ok {
+				return nil
+			}
+
+			result.DeletedNodes++
+		} else if !hasPrefix(k, refCountPrefix) && string(k) != string(prunerRootsKey) {
+			return nil
+		}
+
+		batch.Delete(k)
+
+		if pending++; pending >= pruneBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+
+			batch, pending = storage.Batch(), 0
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+
+	if onlinePruning {
+		// the node trims the retained roots to its own retention on the next block
+		p := &pruner{storage: storage, retention: len(retained)}
+
+		for _, root := range retained {
+			if _, err := p.commit(root); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// markReachable adds the nodes of the state trie with the given root and of its storage tries to the set
+func markReachable(storage Storage, root types.Hash, reachable map[types.Hash]struct{}) error {
+	stack := []types.Hash{root}
+
+	for len(stack) > 0 {
+		hash := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if _, ok := reachable[hash]; ok {
+			continue
+		}
+
+		data, ok, err := storage.Get(hash.Bytes())
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrMissingTrieNode, hash)
+		}
+
+		reachable[hash] = struct{}{}
+
+		refs, err := nodeReferences(data, storage)
+		if err != nil {
+			return err
+		}
+
+		stack = append(stack, refs...)
+	}
+
+	return nil
+}
+
+// nodeReferences returns the hashes of the stored nodes referenced by the given encoded node.
+// The leaves holding an account reference the root of the account storage trie
+func nodeReferences(data []byte, storage Storage) ([]types.Hash, error) {
+	node, err := decodeStoredNode(data, storage)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []types.Hash
+
+	err = walkReferences(node,
+		func(hash []byte) {
+			refs = append(refs, types.BytesToHash(hash))
+		},
+		func(value []byte) error {
+			// the storage values are encoded as bytes, only the accounts are encoded as lists
+			var account state.Account
+			if account.UnmarshalRlp(value) == nil && account.Root != types.EmptyRootHash {
+				refs = append(refs, account.Root)
+			}
+
+			return nil
+		},
+	)
+
+	return refs, err
+}
+
+// decodeStoredNode decodes the node as it is stored in the storage
+func decodeStoredNode(data []byte, storage Storage) (Node, error) {
+	p := parserPool.Get()
+	defer parserPool.Put(p)
+
+	v, err := p.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Type() != fastrlp.TypeArray {
+		return nil, fmt.Errorf("storage item should be an array")
+	}
+
+	return decodeNode(v, storage)
+}
+
+// walkReferences calls the ref function for each reference to the stored node
+// and the leaf function for each leaf value within the given (possibly inlined) node
+func walkReferences(node Node, ref func(hash []byte), leaf func(value []byte) error) error {
+	switch n := node.(type) {
+	case *ValueNode:
+		if n.hash {
+			ref(n.buf)
+
+			return nil
+		}
+
+		return leaf(n.buf)
+
+	case *ShortNode:
+		return walkReferences(n.child, ref, leaf)
+
+	case *FullNode:
+		if n.value != nil {
+			if err := walkReferences(n.value, ref, leaf); err != nil {
+				return err
+			}
+		}
+
+		for _, child := range n.children {
+			if child == nil {
+				continue
+			}
+
+			if err := walkReferences(child, ref, leaf); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func hasPrefix(k, prefix []byte) bool {
+	return len(k) >= len(prefix) && string(k[:len(prefix)]) == string(prefix)
+}
+
+// pruner counts the references to the trie nodes from the state roots of the retained blocks
+// and from the other nodes, the nodes which are no longer referenced after a block leaves
+// the retention window are deleted. The nodes of the states which never became canonical
+// are not counted and are left to the offline pruning
+type pruner struct {
+	storage   Storage
+	retention int
+
+	// roots are the state roots of the retained blocks, the oldest first
+	roots []types.Hash
+
+	// counters are the reference counters changed by the current commit
+	counters map[types.Hash]uint64
+	// deleted are the nodes deleted by the current commit
+	deleted []types.Hash
+
+	lock sync.Mutex
+}
+
+func newPruner(storage Storage, head types.Hash, retention int) (*pruner, error) {
+	p := &pruner{
+		storage:   storage,
+		retention: retention,
+	}
+
+	data, ok, err := storage.Get(prunerRootsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		for i := 0; i+types.HashLength <= len(data); i += types.HashLength {
+			p.roots = append(p.roots, types.BytesToHash(data[i:i+types.HashLength]))
+		}
+	}
+
+	// the head state is counted when the pruning is enabled for the first time,
+	// afterwards it is already retained (or pruned, if the head is behind the window)
+	if !ok {
+		if _, err := p.commit(head); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// commit counts the references to the nodes of the state root of the inserted block.
+// It returns the nodes deleted because the oldest blocks have left the retention window
+func (p *pruner) commit(root types.Hash) ([]types.Hash, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.counters = make(map[types.Hash]uint64)
+	p.deleted = nil
+
+	if root != types.EmptyRootHash {
+		if err := p.ref(root); err != nil {
+			return nil, err
+		}
+
+		p.roots = append(p.roots, root)
+	}
+
+	for len(p.roots) > p.retention {
+		if err := p.unref(p.roots[0]); err != nil {
+			return nil, err
+		}
+
+		p.roots = p.roots[1:]
+	}
+
+	if err := p.write(); err != nil {
+		return nil, err
+	}
+
+	return p.deleted, nil
+}
+
+// ref increments the reference counter of the node,
+// the references from the node to its children are counted when it is referenced for the first time.
+// The counters are flushed in batches, so that counting a whole state doesn't hold them all in memory
+func (p *pruner) ref(hash types.Hash) error {
+	count, err := p.counter(hash)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		data, ok, err := p.storage.Get(hash.Bytes())
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			refs, err := nodeReferences(data, p.storage)
+			if err != nil {
+				return err
+			}
+
+			for _, child := range refs {
+				if err := p.ref(child); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	p.counters[hash] = count + 1
+
+	if len(p.counters) >= pruneBatchSize {
+		return p.flushCounters()
+	}
+
+	return nil
+}
+
+// unref decrements the reference counter of the node, the node is deleted when it is no longer referenced.
+// The nodes without the counter are not known to the pruner and are never deleted
+func (p *pruner) unref(hash types.Hash) error {
+	count, err := p.counter(hash)
+	if err != nil || count == 0 {
+		return err
+	}
+
+	p.counters[hash] = count - 1
+
+	if count > 1 {
+		return nil
+	}
+
+	data, ok, err := p.storage.Get(hash.Bytes())
+	if err != nil || !ok {
+		return err
+	}
+
+	refs, err := nodeReferences(data, p.storage)
+	if err != nil {
+		return err
+	}
+
+	p.deleted = append(p.deleted, hash)
+
+	for _, child := range refs {
+		if err := p.unref(child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *pruner) counter(hash types.Hash) (uint64, error) {
+	if count, ok := p.counters[hash]; ok {
+		return count, nil
+	}
+
+	data, ok, err := p.storage.Get(refCountKey(hash))
+	if err != nil || !ok || len(data) != 8 {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(data), nil
+}
+
+// flushCounters writes the incremented counters and releases them from memory
+func (p *pruner) flushCounters() error {
+	batch := p.storage.Batch()
+	p.putCounters(batch)
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	p.counters = make(map[types.Hash]uint64)
+
+	return nil
+}
+
+func (p *pruner) putCounters(batch Batch) {
+	for hash, count := range p.counters {
+		if count == 0 {
+			batch.Delete(refCountKey(hash))
+
+			continue
+		}
+
+		batch.Put(refCountKey(hash), binary.BigEndian.AppendUint64(nil, count))
+	}
+}
+
+// write writes the changed counters, deletes the unreferenced nodes and persists the retained roots
+func (p *pruner) write() error {
+	batch := p.storage.Batch()
+	p.putCounters(batch)
+
+	for _, hash := range p.deleted {
+		batch.Delete(hash.Bytes())
+	}
+
+	roots := make([]byte, 0, len(p.roots)*types.HashLength)
+	for _, root := range p.roots {
+		roots = append(roots, root.Bytes()...)
+	}
+
+	batch.Put(prunerRootsKey, roots)
+
+	return batch.Write()
+}
+
+func refCountKey(hash types.Hash) []byte {
+	return append(append([]byte{}, refCountPrefix...), hash.Bytes()...)
+}
@@ -0,0 +1,261 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+// commitTestStates commits the given number of states on top of each other,
+// each of them changing the balances and the storage of some accounts, and returns their roots
+func commitTestStates(t *testing.T, st *State, parent types.Hash, num int) []types.Hash {
+	t.Helper()
+
+	roots := make([]types.Hash, 0, num)
+
+	for n := 0; n < num; n++ {
+		snap, err := st.NewSnapshotAt(parent)
+		require.NoError(t, err)
+
+		objs := make([]*state.Object, 0, 10)
+
+		for i := 0; i < 10; i++ {
+			addr := types.BytesToAddress([]byte{byte(n*3 + i)})
+
+			account, err := snap.GetAccount(addr)
+			require.NoError(t, err)
+
+			obj := &state.Object{
+				Address:  addr,
+				Balance:  big.NewInt(int64(n + 1)),
+				Nonce:    uint64(n),
+				CodeHash: types.EmptyCodeHash,
+				Root:     types.EmptyRootHash,
+			}
+
+			if account != nil {
+				obj.Root = account.Root
+			}
+
+			obj.Storage = append(obj.Storage, &state.StorageObject{
+				Key: types.BytesToHash([]byte{byte(n + 1)}).Bytes(),
+				Val: []byte{byte(n + 1)},
+			})
+
+			objs = append(objs, obj)
+		}
+
+		_, root, err := snap.Commit(objs)
+		require.NoError(t, err)
+
+		parent = types.BytesToHash(root)
+		roots = append(roots, parent)
+	}
+
+	return roots
+}
+
+func requireStateAvailable(t *testing.T, storage Storage, root types.Hash) {
+	t.Helper()
+
+	hash, err := HashChecker(root.Bytes(), storage)
+	require.NoError(t, err)
+	require.Equal(t, root, hash)
+
+	_, err = NewState(storage).NewSnapshotAt(root)
+	require.NoError(t, err)
+}
+
+func TestPrune(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	genesis := buildTestState(t, storage)
+	roots := commitTestStates(t, NewState(storage), genesis, 10)
+
+	iterable, ok := storage.(IterableStorage)
+	require.True(t, ok)
+
+	result, err := Prune(iterable, append(roots[7:], types.StringToHash("01")))
+	require.NoError(t, err)
+	require.Equal(t, 3, result.RetainedRoots)
+	require.Equal(t, 1, result.MissingRoots)
+	require.NotZero(t, result.DeletedNodes)
+
+	for _, root := range roots[7:] {
+		requireStateAvailable(t, storage, root)
+	}
+
+	for _, root := range append([]types.Hash{genesis}, roots[:7]...) {
+		_, err := NewState(storage).NewSnapshotAt(root)
+		require.ErrorIs(t, err, ErrStateNotAvailable)
+	}
+
+	// the pruning of the pruned storage doesn't delete anything
+	result, err = Prune(iterable, roots[7:])
+	require.NoError(t, err)
+	require.Zero(t, result.DeletedNodes)
+}
+
+// insertTestBlocks retains the given state roots as if their blocks were inserted to the chain
+func insertTestBlocks(t *testing.T, st *State, roots []types.Hash) {
+	t.Helper()
+
+	for _, root := range roots {
+		require.NoError(t, st.InsertBlockState(root))
+	}
+}
+
+func TestState_Pruning(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	genesis := buildTestState(t, storage)
+
+	st := NewState(storage)
+	require.NoError(t, st.EnablePruning(genesis, 3))
+
+	roots := commitTestStates(t, st, genesis, 10)
+
+	// the committed states are not pruned until their blocks are inserted
+	for _, root := range append([]types.Hash{genesis}, roots...) {
+		requireStateAvailable(t, storage, root)
+	}
+
+	insertTestBlocks(t, st, roots)
+
+	for _, root := range roots[7:] {
+		requireStateAvailable(t, storage, root)
+	}
+
+	for _, root := range append([]types.Hash{genesis}, roots[:7]...) {
+		_, err := st.NewSnapshotAt(root)
+		require.ErrorIs(t, err, ErrStateNotAvailable)
+	}
+
+	// the retained roots are restored when the pruning is enabled again
+	st = NewState(storage)
+	require.NoError(t, st.EnablePruning(types.ZeroHash, 3))
+
+	next := commitTestStates(t, st, roots[9], 2)
+	insertTestBlocks(t, st, next)
+
+	roots = append(roots[9:], next...)
+
+	for _, root := range roots {
+		requireStateAvailable(t, storage, root)
+	}
+
+	// the online pruning leaves only the nodes of the retained states
+	iterable, ok := storage.(IterableStorage)
+	require.True(t, ok)
+
+	result, err := Prune(iterable, roots)
+	require.NoError(t, err)
+	require.Zero(t, result.DeletedNodes)
+
+	require.Error(t, st.EnablePruning(roots[2], 0))
+}
+
+func TestPrune_OnlinePruning(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	genesis := buildTestState(t, storage)
+
+	st := NewState(storage)
+	require.NoError(t, st.EnablePruning(genesis, 5))
+
+	roots := commitTestStates(t, st, genesis, 5)
+	insertTestBlocks(t, st, roots)
+
+	// a state which never becomes canonical is left to the offline pruning
+	orphan := commitTestStates(t, st, roots[2], 1)
+
+	iterable, ok := storage.(IterableStorage)
+	require.True(t, ok)
+
+	result, err := Prune(iterable, roots[2:])
+	require.NoError(t, err)
+	require.NotZero(t, result.DeletedNodes)
+
+	_, err = NewState(storage).NewSnapshotAt(orphan[0])
+	require.ErrorIs(t, err, ErrStateNotAvailable)
+
+	// the online pruning continues with the counters rebuilt by the offline pruning
+	st = NewState(storage)
+	require.NoError(t, st.EnablePruning(types.ZeroHash, 2))
+
+	next := commitTestStates(t, st, roots[4], 2)
+	insertTestBlocks(t, st, next)
+
+	for _, root := range next {
+		requireStateAvailable(t, storage, root)
+	}
+
+	for _, root := range roots {
+		_, err := st.NewSnapshotAt(root)
+		require.ErrorIs(t, err, ErrStateNotAvailable)
+	}
+
+	result, err = Prune(iterable, next)
+	require.NoError(t, err)
+	require.Zero(t, result.DeletedNodes)
+}
+
+func TestState_PruningRecreatedNode(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	genesis := buildTestState(t, storage)
+
+	st := NewState(storage)
+	require.NoError(t, st.EnablePruning(genesis, 2))
+
+	addr := types.StringToAddress("0x1")
+	key := types.StringToHash("0x1")
+
+	// commitValue commits the storage value of the account on top of the parent state
+	commitValue := func(parent types.Hash, balance int64, val byte) types.Hash {
+		snap, err := st.NewSnapshotAt(parent)
+		require.NoError(t, err)
+
+		_, root, err := snap.Commit([]*state.Object{{
+			Address:  addr,
+			Balance:  big.NewInt(balance),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+			Storage:  []*state.StorageObject{{Key: key.Bytes(), Val: []byte{val}}},
+		}})
+		require.NoError(t, err)
+
+		return types.BytesToHash(root)
+	}
+
+	first := commitValue(genesis, 1, 1)
+	insertTestBlocks(t, st, []types.Hash{first})
+
+	second := commitValue(first, 2, 2)
+	insertTestBlocks(t, st, []types.Hash{second})
+
+	// the storage of the first state is re-created by the block which moves it out of the window
+	third := commitValue(second, 3, 1)
+	insertTestBlocks(t, st, []types.Hash{third})
+
+	_, err := st.NewSnapshotAt(first)
+	require.ErrorIs(t, err, ErrStateNotAvailable)
+
+	for _, root := range []types.Hash{second, third} {
+		requireStateAvailable(t, storage, root)
+	}
+
+	snap, err := st.NewSnapshotAt(third)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(addr)
+	require.NoError(t, err)
+	require.Equal(t, types.BytesToHash([]byte{1}), snap.GetStorage(addr, account.Root, key))
+}
@@ -0,0 +1,539 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// ErrMissingTrieNode is returned when a node referenced by the trie is not in the storage
+	ErrMissingTrieNode = errors.New("missing trie node")
+
+	// ErrInvalidRangeProof is returned when the range of leaves can't be proven against the root
+	ErrInvalidRangeProof = errors.New("invalid range proof")
+)
+
+// GetRange returns at most max leaves of the trie with the given root,
+// ordered by key and starting from the origin key (inclusive)
+func GetRange(storage Storage, root types.Hash, origin []byte, max int) ([][]byte, [][]byte, error) {
+	if root == types.EmptyRootHash || max <= 0 {
+		return nil, nil, nil
+	}
+
+	node, ok, err := GetNode(root.Bytes(), storage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrMissingTrieNode, root)
+	}
+
+	originNibbles := bytesToHexNibbles(origin)
+
+	it := &rangeIterator{
+		storage:   storage,
+		origin:    originNibbles[:len(originNibbles)-1], // without the terminator
+		originKey: origin,
+		max:       max,
+	}
+
+	if err := it.walk(node, nil); err != nil {
+		return nil, nil, err
+	}
+
+	return it.keys, it.values, nil
+}
+
+type rangeIterator struct {
+	storage   Storage
+	origin    []byte
+	originKey []byte
+	max       int
+
+	keys   [][]byte
+	values [][]byte
+}
+
+func (it *rangeIterator) done() bool {
+	return len(it.keys) >= it.max
+}
+
+// skip returns true if all the keys with the given prefix are lower than the origin
+func (it *rangeIterator) skip(prefix []byte) bool {
+	n := len(prefix)
+	if n > len(it.origin) {
+		n = len(it.origin)
+	}
+
+	return bytes.Compare(prefix[:n], it.origin[:n]) < 0
+}
+
+func (it *rangeIterator) walk(node Node, path []byte) error {
+	if it.done() || it.skip(path) {
+		return nil
+	}
+
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *ValueNode:
+		if n.hash {
+			child, ok, err := GetNode(n.buf, it.storage)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				return fmt.Errorf("%w: %s", ErrMissingTrieNode, types.BytesToHash(n.buf))
+			}
+
+			return it.walk(child, path)
+		}
+
+		key := hexNibblesToBytes(path)
+		if bytes.Compare(key, it.originKey) < 0 {
+			return nil
+		}
+
+		it.keys = append(it.keys, key)
+		it.values = append(it.values, append([]byte{}, n.buf...))
+
+	case *ShortNode:
+		key := n.key
+		if hasTerminator(key) {
+			key = key[:len(key)-1]
+		}
+
+		return it.walk(n.child, concat(path, key))
+
+	case *FullNode:
+		if err := it.walk(n.value, path); err != nil {
+			return err
+		}
+
+		for i, child := range n.children {
+			if child == nil {
+				continue
+			}
+
+			if err := it.walk(child, concat(path, []byte{byte(i)})); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Prove returns the stored nodes on the path from the root to the given key,
+// which prove the presence (or the absence) of the key in the trie
+func Prove(storage Storage, root types.Hash, key []byte) ([][]byte, error) {
+	if root == types.EmptyRootHash {
+		return nil, nil
+	}
+
+	node, data, err := getCustomNode(root.Bytes(), storage)
+	if err != nil {
+		return nil, err
+	}
+
+	if node == nil {
+		return nil, fmt.Errorf("%w: %s", ErrMissingTrieNode, root)
+	}
+
+	proof := [][]byte{data}
+	search := bytesToHexNibbles(key)
+
+	for node != nil {
+		switch n := node.(type) {
+		case *ValueNode:
+			if !n.hash {
+				return proof, nil
+			}
+
+			node, data, err = getCustomNode(n.buf, storage)
+			if err != nil {
+				return nil, err
+			}
+
+			if node == nil {
+				return nil, fmt.Errorf("%w: %s", ErrMissingTrieNode, types.BytesToHash(n.buf))
+			}
+
+			proof = append(proof, data)
+
+		case *ShortNode:
+			if !bytes.HasPrefix(search, n.key) {
+				return proof, nil
+			}
+
+			node, search = n.child, search[len(n.key):]
+
+		case *FullNode:
+			if len(search) == 0 {
+				return proof, nil
+			}
+
+			node, search = n.getEdge(search[0]), search[1:]
+
+		default:
+			return nil, fmt.Errorf("unknown node type %T", node)
+		}
+	}
+
+	return proof, nil
+}
+
+// VerifyProof returns the value of the key proven against the root by the given proof nodes,
+// or nil if the proof shows the key is absent. The proof missing a node on the path is invalid
+func VerifyProof(root types.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	if root == types.EmptyRootHash {
+		// the empty trie contains no keys
+		return nil, nil
+	}
+
+	proofDB, err := newProofStorage(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		node   Node = &ValueNode{buf: root.Bytes(), hash: true}
+		search      = bytesToHexNibbles(key)
+	)
+
+	for node != nil {
+		switch n := node.(type) {
+		case *ValueNode:
+			if !n.hash {
+				if len(search) != 0 {
+					return nil, nil
+				}
+
+				return n.buf, nil
+			}
+
+			next, ok, err := GetNode(n.buf, proofDB)
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok {
+				return nil, fmt.Errorf("%w: node %s not found", ErrInvalidRangeProof, types.BytesToHash(n.buf))
+			}
+
+			node = next
+
+		case *ShortNode:
+			if !bytes.HasPrefix(search, n.key) {
+				return nil, nil
+			}
+
+			node, search = n.child, search[len(n.key):]
+
+		case *FullNode:
+			if len(search) == 0 {
+				node = n.value
+
+				continue
+			}
+
+			node, search = n.getEdge(search[0]), search[1:]
+
+		default:
+			return nil, fmt.Errorf("%w: unknown node type %T", ErrInvalidRangeProof, node)
+		}
+	}
+
+	return nil, nil
+}
+
+// VerifyRangeProof verifies that the given leaves are all the leaves of the trie with the given root
+// from the origin up to the last key. The proof must contain the paths to the origin and to the last key:
+// the trie is rebuilt from the proven nodes outside of the range and the given leaves inside of it,
+// so its root matches only if no leaf of the range is missing or altered.
+// The leaves following the last key are not covered, an empty range is always accepted
+func VerifyRangeProof(root types.Hash, origin []byte, keys, values [][]byte, proof [][]byte) error {
+	if len(keys) != len(values) {
+		return fmt.Errorf("%w: keys and values count mismatch", ErrInvalidRangeProof)
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if bytes.Compare(keys[0], origin) < 0 {
+		return fmt.Errorf("%w: first key is lower than the origin", ErrInvalidRangeProof)
+	}
+
+	for i := 1; i < len(keys); i++ {
+		if bytes.Compare(keys[i-1], keys[i]) >= 0 {
+			return fmt.Errorf("%w: keys are not ordered", ErrInvalidRangeProof)
+		}
+	}
+
+	for _, value := range values {
+		if len(value) == 0 {
+			return fmt.Errorf("%w: empty value", ErrInvalidRangeProof)
+		}
+	}
+
+	proofDB, err := newProofStorage(proof)
+	if err != nil {
+		return err
+	}
+
+	var node Node
+
+	if root != types.EmptyRootHash {
+		originNibbles := bytesToHexNibbles(origin)
+		lastNibbles := bytesToHexNibbles(keys[len(keys)-1])
+
+		edges := &rangeEdges{
+			storage: proofDB,
+			first:   originNibbles[:len(originNibbles)-1], // without the terminator
+			last:    lastNibbles[:len(lastNibbles)-1],
+		}
+
+		if node, err = edges.unset(&ValueNode{buf: root.Bytes(), hash: true}, nil); err != nil {
+			return err
+		}
+	}
+
+	txn := NewTrieWithRoot(node).Txn(proofDB)
+
+	for i, key := range keys {
+		txn.Insert(key, values[i])
+	}
+
+	hash, err := txn.Hash()
+	if err != nil {
+		return err
+	}
+
+	if types.BytesToHash(hash) != root {
+		return fmt.Errorf("%w: root mismatch", ErrInvalidRangeProof)
+	}
+
+	return nil
+}
+
+// newProofStorage puts the proof nodes into a memory storage, rejecting the malformed ones
+func newProofStorage(proof [][]byte) (Storage, error) {
+	proofDB := NewMemoryStorage()
+
+	for _, node := range proof {
+		hash := crypto.Keccak256(node)
+
+		if err := proofDB.Put(hash, node); err != nil {
+			return nil, err
+		}
+
+		// the lookup panics on malformed nodes, so they are rejected beforehand
+		if _, _, err := GetNode(hash, proofDB); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRangeProof, err)
+		}
+	}
+
+	return proofDB, nil
+}
+
+// rangeEdges are the paths (in nibbles) to the first and the last key of a range
+type rangeEdges struct {
+	storage     Storage
+	first, last []byte
+}
+
+// unset resolves the nodes on the edge paths from the proof and removes the nodes inside of the range,
+// keeping the references to the subtries which are entirely outside of it
+func (e *rangeEdges) unset(node Node, path []byte) (Node, error) {
+	switch n := node.(type) {
+	case nil:
+		return nil, nil
+
+	case *ValueNode:
+		if !n.hash || !e.onEdge(path) {
+			return e.outside(n, path), nil
+		}
+
+		if e.inside(path) {
+			// no need to resolve the subtrie, it is rebuilt from the leaves
+			return nil, nil
+		}
+
+		child, ok, err := GetNode(n.buf, e.storage)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRangeProof, err)
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("%w: node %s not found", ErrInvalidRangeProof, types.BytesToHash(n.buf))
+		}
+
+		return e.unset(child, path)
+
+	case *ShortNode:
+		key := n.key
+		if hasTerminator(key) {
+			key = key[:len(key)-1]
+		}
+
+		childPath := concat(path, key)
+		if !e.onEdge(childPath) {
+			return e.outside(n, childPath), nil
+		}
+
+		child, err := e.unset(n.child, childPath)
+		if err != nil || child == nil {
+			return nil, err
+		}
+
+		return &ShortNode{key: n.key, child: child}, nil
+
+	case *FullNode:
+		nc := &FullNode{}
+
+		for i, child := range n.children {
+			var err error
+
+			if nc.children[i], err = e.unset(child, concat(path, []byte{byte(i)})); err != nil {
+				return nil, err
+			}
+		}
+
+		value, err := e.unset(n.value, path)
+		if err != nil {
+			return nil, err
+		}
+
+		nc.value = value
+
+		return nc, nil
+
+	default:
+		return nil, fmt.Errorf("%w: unknown node type %T", ErrInvalidRangeProof, node)
+	}
+}
+
+// onEdge checks if the path leads to the first or the last key of the range
+func (e *rangeEdges) onEdge(path []byte) bool {
+	return bytes.HasPrefix(e.first, path) || bytes.HasPrefix(e.last, path)
+}
+
+// outside returns the node if all the keys under the path are out of the range, otherwise nil
+func (e *rangeEdges) outside(node Node, path []byte) Node {
+	if comparePrefix(path, e.first) < 0 || comparePrefix(path, e.last) > 0 {
+		return node
+	}
+
+	return nil
+}
+
+// inside checks if all the keys under the path are in the range
+func (e *rangeEdges) inside(path []byte) bool {
+	return compareBound(path, e.first, 0) >= 0 && compareBound(path, e.last, 0xf) <= 0
+}
+
+// compareBound compares the path padded with the given nibble to the length of the edge with the edge
+func compareBound(path, edge []byte, pad byte) int {
+	if c := comparePrefix(path, edge); c != 0 || len(path) >= len(edge) {
+		return c
+	}
+
+	for _, nibble := range edge[len(path):] {
+		if pad != nibble {
+			if pad < nibble {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// comparePrefix compares the path with the prefix of the edge of the same length
+func comparePrefix(path, edge []byte) int {
+	n := len(path)
+	if n > len(edge) {
+		n = len(edge)
+	}
+
+	return bytes.Compare(path[:n], edge[:n])
+}
+
+// hexNibblesToBytes packs the nibbles (without the terminator) into bytes
+func hexNibblesToBytes(nibbles []byte) []byte {
+	res := make([]byte, (len(nibbles)+1)/2)
+
+	for i, nibble := range nibbles {
+		if i%2 == 0 {
+			res[i/2] = nibble << 4
+		} else {
+			res[i/2] |= nibble
+		}
+	}
+
+	return res
+}
+
+// TrieBuilder builds the trie from the leaves received in ranges,
+// flushing the hashed nodes to the storage on each commit to bound the memory usage
+type TrieBuilder struct {
+	storage Storage
+	txn     *Txn
+}
+
+// NewTrieBuilder creates the builder of an empty trie
+func NewTrieBuilder(storage Storage) *TrieBuilder {
+	return &TrieBuilder{
+		storage: storage,
+		txn:     NewTrie().Txn(storage),
+	}
+}
+
+// Insert inserts the leaf to the trie
+func (b *TrieBuilder) Insert(key, value []byte) {
+	b.txn.Insert(key, value)
+}
+
+// Commit writes the nodes of the trie to the storage and returns its root
+func (b *TrieBuilder) Commit() (types.Hash, error) {
+	batch := b.storage.Batch()
+	b.txn.batch = batch
+
+	root, err := b.txn.Hash()
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	if err := batch.Write(); err != nil {
+		return types.ZeroHash, err
+	}
+
+	rootHash := types.BytesToHash(root)
+	if rootHash == types.EmptyRootHash {
+		return rootHash, nil
+	}
+
+	// reload the trie, so the written nodes are released from the memory
+	node, ok, err := GetNode(root, b.storage)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	if !ok {
+		return types.ZeroHash, fmt.Errorf("%w: %s", ErrMissingTrieNode, rootHash)
+	}
+
+	b.txn = NewTrieWithRoot(node).Txn(b.storage)
+
+	return rootHash, nil
+}
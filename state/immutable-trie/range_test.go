@@ -0,0 +1,246 @@
+package itrie
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+// buildTestTrie writes the trie with n leaves to the storage and returns its root and sorted leaves
+func buildTestTrie(t *testing.T, storage Storage, n int) (types.Hash, [][]byte, [][]byte) {
+	t.Helper()
+
+	keys := make([][]byte, n)
+	values := make([][]byte, n)
+
+	for i := 0; i < n; i++ {
+		keys[i] = crypto.Keccak256([]byte{byte(i >> 8), byte(i)})
+		values[i] = bytes.Repeat([]byte{byte(i)}, 40)
+	}
+
+	sort.Sort(&leavesByKey{keys: keys, values: values})
+
+	batch := storage.Batch()
+	txn := NewTrie().Txn(storage)
+	txn.batch = batch
+
+	for i := range keys {
+		txn.Insert(keys[i], values[i])
+	}
+
+	root, err := txn.Hash()
+	require.NoError(t, err)
+	require.NoError(t, batch.Write())
+
+	return types.BytesToHash(root), keys, values
+}
+
+type leavesByKey struct {
+	keys, values [][]byte
+}
+
+func (l *leavesByKey) Len() int { return len(l.keys) }
+
+func (l *leavesByKey) Less(i, j int) bool { return bytes.Compare(l.keys[i], l.keys[j]) < 0 }
+
+func (l *leavesByKey) Swap(i, j int) {
+	l.keys[i], l.keys[j] = l.keys[j], l.keys[i]
+	l.values[i], l.values[j] = l.values[j], l.values[i]
+}
+
+func TestGetRange(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	root, keys, values := buildTestTrie(t, storage, 100)
+
+	// whole trie
+	resKeys, resValues, err := GetRange(storage, root, make([]byte, 32), 1000)
+	require.NoError(t, err)
+	require.Equal(t, keys, resKeys)
+	require.Equal(t, values, resValues)
+
+	// limited range from an existing key
+	resKeys, resValues, err = GetRange(storage, root, keys[10], 20)
+	require.NoError(t, err)
+	require.Equal(t, keys[10:30], resKeys)
+	require.Equal(t, values[10:30], resValues)
+
+	// origin between the keys
+	origin := append([]byte{}, keys[50]...)
+	origin[31]++
+
+	resKeys, _, err = GetRange(storage, root, origin, 5)
+	require.NoError(t, err)
+	require.Equal(t, keys[51:56], resKeys)
+
+	// empty trie
+	resKeys, _, err = GetRange(storage, types.EmptyRootHash, origin, 5)
+	require.NoError(t, err)
+	require.Empty(t, resKeys)
+
+	// missing root
+	_, _, err = GetRange(NewMemoryStorage(), root, origin, 5)
+	require.ErrorIs(t, err, ErrMissingTrieNode)
+}
+
+func TestRangeProof(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	root, keys, values := buildTestTrie(t, storage, 100)
+
+	proveRange := func(first, last []byte) [][]byte {
+		firstProof, err := Prove(storage, root, first)
+		require.NoError(t, err)
+
+		lastProof, err := Prove(storage, root, last)
+		require.NoError(t, err)
+
+		return append(firstProof, lastProof...)
+	}
+
+	proof := proveRange(keys[10], keys[29])
+	require.NoError(t, VerifyRangeProof(root, keys[10], keys[10:30], values[10:30], proof))
+
+	// single key proof
+	value, err := VerifyProof(root, keys[5], proveRange(keys[5], keys[5]))
+	require.NoError(t, err)
+	require.Equal(t, values[5], value)
+
+	// truncated proof
+	singleProof, err := Prove(storage, root, keys[5])
+	require.NoError(t, err)
+
+	_, err = VerifyProof(root, keys[5], singleProof[:len(singleProof)-1])
+	require.ErrorIs(t, err, ErrInvalidRangeProof)
+
+	// tampered values, at the edge and inside of the range
+	for _, i := range []int{0, 10} {
+		tampered := append([][]byte{}, values[10:30]...)
+		tampered[i] = []byte{0x1}
+		require.ErrorIs(t, VerifyRangeProof(root, keys[10], keys[10:30], tampered, proof), ErrInvalidRangeProof)
+	}
+
+	// missing leaf inside of the range
+	require.ErrorIs(t, VerifyRangeProof(root, keys[10], append(append([][]byte{}, keys[10:15]...), keys[16:30]...),
+		append(append([][]byte{}, values[10:15]...), values[16:30]...), proof), ErrInvalidRangeProof)
+
+	// not ordered keys
+	require.ErrorIs(t, VerifyRangeProof(root, keys[10], [][]byte{keys[11], keys[10]},
+		[][]byte{values[11], values[10]}, proof), ErrInvalidRangeProof)
+
+	// key lower than the origin
+	require.ErrorIs(t, VerifyRangeProof(root, keys[11], keys[10:30], values[10:30], proof), ErrInvalidRangeProof)
+
+	// proof of another root
+	require.ErrorIs(t, VerifyRangeProof(types.StringToHash("01"), keys[10], keys[10:30], values[10:30], proof),
+		ErrInvalidRangeProof)
+
+	// malformed proof node
+	require.ErrorIs(t, VerifyRangeProof(root, keys[10], keys[10:30], values[10:30], [][]byte{{0x1, 0x2}}),
+		ErrInvalidRangeProof)
+}
+
+func TestRangeProof_Ranges(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	root, keys, values := buildTestTrie(t, storage, 200)
+
+	prove := func(first, last []byte) [][]byte {
+		firstProof, err := Prove(storage, root, first)
+		require.NoError(t, err)
+
+		lastProof, err := Prove(storage, root, last)
+		require.NoError(t, err)
+
+		return append(firstProof, lastProof...)
+	}
+
+	cases := []struct {
+		name        string
+		origin      []byte
+		first, last int
+	}{
+		{"whole trie", types.ZeroHash.Bytes(), 0, 199},
+		{"single leaf", keys[50], 50, 50},
+		{"absent origin", nextKey(keys[99]), 100, 150},
+		{"trie end", keys[180], 180, 199},
+	}
+
+	for _, c := range cases {
+		rangeKeys, rangeValues := keys[c.first:c.last+1], values[c.first:c.last+1]
+		proof := prove(c.origin, rangeKeys[len(rangeKeys)-1])
+
+		require.NoError(t, VerifyRangeProof(root, c.origin, rangeKeys, rangeValues, proof), c.name)
+
+		// the leaf following the origin can't be skipped
+		if len(rangeKeys) > 1 {
+			require.ErrorIs(t, VerifyRangeProof(root, c.origin, rangeKeys[1:], rangeValues[1:], proof),
+				ErrInvalidRangeProof, c.name)
+		}
+
+		// a missing root node is detected
+		truncated := make([][]byte, 0, len(proof))
+
+		for _, node := range proof {
+			if !bytes.Equal(node, proof[0]) {
+				truncated = append(truncated, node)
+			}
+		}
+
+		require.ErrorIs(t, VerifyRangeProof(root, c.origin, rangeKeys, rangeValues, truncated),
+			ErrInvalidRangeProof, c.name)
+	}
+}
+
+// nextKey returns the key following the given one
+func nextKey(key []byte) []byte {
+	next := append([]byte{}, key...)
+
+	for i := len(next) - 1; i >= 0; i-- {
+		if next[i]++; next[i] != 0 {
+			break
+		}
+	}
+
+	return next
+}
+
+func TestTrieBuilder(t *testing.T) {
+	t.Parallel()
+
+	root, keys, values := buildTestTrie(t, NewMemoryStorage(), 100)
+
+	storage := NewMemoryStorage()
+	builder := NewTrieBuilder(storage)
+
+	for i := 0; i < 50; i++ {
+		builder.Insert(keys[i], values[i])
+	}
+
+	partialRoot, err := builder.Commit()
+	require.NoError(t, err)
+	require.NotEqual(t, root, partialRoot)
+
+	for i := 50; i < 100; i++ {
+		builder.Insert(keys[i], values[i])
+	}
+
+	result, err := builder.Commit()
+	require.NoError(t, err)
+	require.Equal(t, root, result)
+
+	resKeys, _, err := GetRange(storage, root, nil, 1000)
+	require.NoError(t, err)
+	require.Equal(t, keys, resKeys)
+
+	result, err = NewTrieBuilder(storage).Commit()
+	require.NoError(t, err)
+	require.Equal(t, types.EmptyRootHash, result)
+}
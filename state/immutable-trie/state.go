@@ -12,6 +12,9 @@ import (
 type State struct {
 	storage Storage
 	cache   *lru.Cache
+
+	// pruner deletes the nodes of the states older than the retention window, nil if the pruning is disabled
+	pruner *pruner
 }
 
 func NewState(storage Storage) *State {
@@ -77,7 +80,7 @@ func (s *State) newTrieAt(root types.Hash) (*Trie, error) {
 	}
 
 	if !ok {
-		return nil, fmt.Errorf("state not found at hash %s", root)
+		return nil, fmt.Errorf("%w: root %s", ErrStateNotAvailable, root)
 	}
 
 	t := &Trie{
@@ -90,3 +93,39 @@ func (s *State) newTrieAt(root types.Hash) (*Trie, error) {
 func (s *State) AddState(root types.Hash, t *Trie) {
 	s.cache.Add(root, t)
 }
+
+// EnablePruning enables the online pruning, which keeps only the states of the last retention blocks.
+// The head is the root of the current state, retained when the pruning is enabled for the first time
+func (s *State) EnablePruning(head types.Hash, retention uint64) error {
+	if retention == 0 {
+		return fmt.Errorf("pruning retention must be greater than zero")
+	}
+
+	p, err := newPruner(s.storage, head, int(retention))
+	if err != nil {
+		return fmt.Errorf("failed to enable pruning: %w", err)
+	}
+
+	s.pruner = p
+
+	return nil
+}
+
+// InsertBlockState retains the state root of the block inserted to the canonical chain,
+// the state of the block which leaves the retention window is pruned. It is a noop if the pruning is disabled
+func (s *State) InsertBlockState(root types.Hash) error {
+	if s.pruner == nil {
+		return nil
+	}
+
+	deleted, err := s.pruner.commit(root)
+	if err != nil {
+		return err
+	}
+
+	for _, hash := range deleted {
+		s.cache.Remove(hash)
+	}
+
+	return nil
+}
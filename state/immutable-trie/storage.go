@@ -13,6 +13,13 @@ import (
 
 var parserPool fastrlp.ParserPool
 
+const (
+	// LevelDBBackend is the trie storage backed by leveldb
+	LevelDBBackend = "leveldb"
+	// MdbxBackend is the trie storage backed by mdbx
+	MdbxBackend = "mdbx"
+)
+
 var (
 	// codePrefix is the code prefix for leveldb
 	codePrefix = []byte("code")
@@ -25,6 +32,8 @@ var (
 type Batch interface {
 	// Put puts key and value into batch. It can not return error because actual writing is done with Write method
 	Put(k, v []byte)
+	// Delete deletes the key from the database when the batch is written
+	Delete(k []byte)
 	// Write writes all the key values pair previosly putted with Put method to the database
 	Write() error
 }
@@ -40,6 +49,13 @@ type Storage interface {
 	Close() error
 }
 
+// IterableStorage is the storage whose keys can be iterated
+type IterableStorage interface {
+	Storage
+	// ForEachKey calls the given function for each key in the storage, the iteration stops on error
+	ForEachKey(fn func(k []byte) error) error
+}
+
 // KVStorage is a k/v storage on memory using leveldb
 type KVStorage struct {
 	db *leveldb.DB
@@ -55,6 +71,10 @@ func (b *KVBatch) Put(k, v []byte) {
 	b.batch.Put(k, v)
 }
 
+func (b *KVBatch) Delete(k []byte) {
+	b.batch.Delete(k)
+}
+
 func (b *KVBatch) Write() error {
 	return b.db.Write(b.batch, nil)
 }
@@ -93,6 +113,19 @@ func (kv *KVStorage) Get(k []byte) ([]byte, bool, error) {
 	return data, true, nil
 }
 
+func (kv *KVStorage) ForEachKey(fn func(k []byte) error) error {
+	iter := kv.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if err := fn(append([]byte{}, iter.Key()...)); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
 func (kv *KVStorage) Close() error {
 	return kv.db.Close()
 }
@@ -106,6 +139,27 @@ func NewLevelDBStorage(path string, logger hclog.Logger) (Storage, error) {
 	return &KVStorage{db}, nil
 }
 
+// NewStorage creates the trie storage with the given backend at the given path
+func NewStorage(backend, path string, logger hclog.Logger) (Storage, error) {
+	switch backend {
+	case LevelDBBackend, "":
+		return NewLevelDBStorage(path, logger)
+	case MdbxBackend:
+		return NewMdbxStorage(path, logger)
+	default:
+		return nil, fmt.Errorf("unknown trie storage backend: %s", backend)
+	}
+}
+
+// StorageDir returns the directory of the trie storage with the given backend within the data directory
+func StorageDir(backend string) string {
+	if backend == MdbxBackend {
+		return "trie-mdbx"
+	}
+
+	return "trie"
+}
+
 type memStorage struct {
 	l    *sync.Mutex
 	db   map[string][]byte
@@ -162,6 +216,25 @@ func (m *memStorage) Batch() Batch {
 	return &memBatch{db: &m.db, l: new(sync.Mutex)}
 }
 
+func (m *memStorage) ForEachKey(fn func(k []byte) error) error {
+	m.l.Lock()
+
+	keys := make([][]byte, 0, len(m.db))
+	for k := range m.db {
+		keys = append(keys, hex.MustDecodeHex(k))
+	}
+
+	m.l.Unlock()
+
+	for _, k := range keys {
+		if err := fn(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *memStorage) Close() error {
 	return nil
 }
@@ -175,6 +248,13 @@ func (m *memBatch) Put(p, v []byte) {
 	(*m.db)[hex.EncodeToHex(p)] = buf
 }
 
+func (m *memBatch) Delete(p []byte) {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	delete(*m.db, hex.EncodeToHex(p))
+}
+
 func (m *memBatch) Write() error {
 	return nil
 }
@@ -0,0 +1,243 @@
+package itrie
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/erigontech/mdbx-go/mdbx"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// mdbxTable is the name of the mdbx table holding the trie nodes and the codes
+	mdbxTable = "Trie"
+
+	// mdbxIterationChunk is the number of the keys read in one read transaction during the iteration
+	mdbxIterationChunk = 10000
+
+	mdbxMapSize    = 2 << 40 // 2 TB
+	mdbxGrowthSize = 2 << 30 // 2 GB
+)
+
+// MdbxStorage is the mdbx implementation of the trie storage
+type MdbxStorage struct {
+	env *mdbx.Env
+	dbi mdbx.DBI
+}
+
+// mdbxBatch collects the writes and applies them in one write transaction,
+// so the batch can be filled by a different goroutine than the one locked to the transaction
+type mdbxBatch struct {
+	storage *MdbxStorage
+	keys    [][]byte
+	values  [][]byte // nil value marks the deletion
+}
+
+// NewMdbxStorage creates the trie storage backed by the mdbx database
+func NewMdbxStorage(path string, logger hclog.Logger) (Storage, error) {
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, err
+	}
+
+	env, err := mdbx.NewEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := env.SetOption(mdbx.OptMaxDB, 1); err != nil {
+		return nil, err
+	}
+
+	pageSize := os.Getpagesize()
+	if pageSize < 4096 {
+		pageSize = 4096
+	} else if pageSize > mdbx.MaxPageSize {
+		pageSize = mdbx.MaxPageSize
+	}
+
+	if err := env.SetGeometry(-1, -1, mdbxMapSize, mdbxGrowthSize, -1, pageSize/4096*4096); err != nil {
+		return nil, err
+	}
+
+	if err := env.Open(path, 0, 0664); err != nil {
+		return nil, err
+	}
+
+	s := &MdbxStorage{env: env}
+
+	err = env.Update(func(tx *mdbx.Txn) error {
+		s.dbi, err = tx.OpenDBISimple(mdbxTable, mdbx.Create)
+
+		return err
+	})
+	if err != nil {
+		env.Close()
+
+		return nil, err
+	}
+
+	logger.Named("mdbx").Debug("trie storage opened", "path", path)
+
+	return s, nil
+}
+
+func (s *MdbxStorage) Put(k, v []byte) error {
+	return s.env.Update(func(tx *mdbx.Txn) error {
+		return tx.Put(s.dbi, k, v, 0)
+	})
+}
+
+func (s *MdbxStorage) Get(k []byte) ([]byte, bool, error) {
+	var (
+		data  []byte
+		found bool
+	)
+
+	err := s.env.View(func(tx *mdbx.Txn) error {
+		v, err := tx.Get(s.dbi, k)
+		if err != nil {
+			if mdbx.IsNotFound(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		data, found = v, true
+
+		return nil
+	})
+
+	return data, found, err
+}
+
+func (s *MdbxStorage) SetCode(hash types.Hash, code []byte) error {
+	return s.Put(GetCodeKey(hash), code)
+}
+
+func (s *MdbxStorage) GetCode(hash types.Hash) ([]byte, bool) {
+	res, ok, err := s.Get(GetCodeKey(hash))
+	if err != nil {
+		return nil, false
+	}
+
+	return res, ok
+}
+
+func (s *MdbxStorage) Batch() Batch {
+	return &mdbxBatch{storage: s}
+}
+
+// ForEachKey iterates the keys in chunks, so the function might write to the storage
+func (s *MdbxStorage) ForEachKey(fn func(k []byte) error) error {
+	var from []byte
+
+	for {
+		keys, err := s.readKeys(from, mdbxIterationChunk)
+		if err != nil {
+			return err
+		}
+
+		for _, k := range keys {
+			if err := fn(k); err != nil {
+				return err
+			}
+		}
+
+		if len(keys) < mdbxIterationChunk {
+			return nil
+		}
+
+		// the next chunk starts right after the last key
+		from = append(keys[len(keys)-1], 0)
+	}
+}
+
+// readKeys reads at most max keys starting with the given key
+func (s *MdbxStorage) readKeys(from []byte, max int) ([][]byte, error) {
+	keys := make([][]byte, 0, max)
+
+	err := s.env.View(func(tx *mdbx.Txn) error {
+		cursor, err := tx.OpenCursor(s.dbi)
+		if err != nil {
+			return err
+		}
+
+		defer cursor.Close()
+
+		op := uint(mdbx.First)
+		if from != nil {
+			op = mdbx.SetRange
+		}
+
+		for len(keys) < max {
+			k, _, err := cursor.Get(from, nil, op)
+			if err != nil {
+				if mdbx.IsNotFound(err) {
+					return nil
+				}
+
+				return err
+			}
+
+			keys = append(keys, bytes.Clone(k))
+			op = mdbx.Next
+		}
+
+		return nil
+	})
+
+	return keys, err
+}
+
+func (s *MdbxStorage) Close() error {
+	s.env.Close()
+
+	return nil
+}
+
+func (b *mdbxBatch) Put(k, v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+
+	b.keys = append(b.keys, bytes.Clone(k))
+	b.values = append(b.values, bytes.Clone(v))
+}
+
+func (b *mdbxBatch) Delete(k []byte) {
+	b.keys = append(b.keys, bytes.Clone(k))
+	b.values = append(b.values, nil)
+}
+
+func (b *mdbxBatch) Write() error {
+	if len(b.keys) == 0 {
+		return nil
+	}
+
+	err := b.storage.env.Update(func(tx *mdbx.Txn) error {
+		for i, k := range b.keys {
+			if b.values[i] == nil {
+				if err := tx.Del(b.storage.dbi, k, nil); err != nil && !mdbx.IsNotFound(err) {
+					return err
+				}
+
+				continue
+			}
+
+			if err := tx.Put(b.storage.dbi, k, b.values[i], 0); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	b.keys, b.values = nil, nil
+
+	return nil
+}
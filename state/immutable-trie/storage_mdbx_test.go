@@ -0,0 +1,86 @@
+package itrie
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMdbxStorage(t *testing.T) {
+	t.Parallel()
+
+	storage, err := NewStorage(MdbxBackend, t.TempDir(), hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	defer storage.Close()
+
+	require.NoError(t, storage.Put([]byte{0x1}, []byte{0x2}))
+
+	v, ok, err := storage.Get([]byte{0x1})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte{0x2}, v)
+
+	_, ok, err = storage.Get([]byte{0x2})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	batch := storage.Batch()
+	batch.Put([]byte{0x2}, []byte{0x3})
+	batch.Delete([]byte{0x1})
+	batch.Delete([]byte{0x5})
+	require.NoError(t, batch.Write())
+
+	_, ok, err = storage.Get([]byte{0x1})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	iterable, ok := storage.(IterableStorage)
+	require.True(t, ok)
+
+	var keys [][]byte
+
+	require.NoError(t, iterable.ForEachKey(func(k []byte) error {
+		keys = append(keys, k)
+
+		return nil
+	}))
+	require.Equal(t, [][]byte{{0x2}}, keys)
+}
+
+func TestCopyStorage(t *testing.T) {
+	t.Parallel()
+
+	source := NewMemoryStorage()
+	root := buildTestState(t, source)
+	roots := commitTestStates(t, NewState(source), root, 3)
+
+	target, err := NewStorage(MdbxBackend, t.TempDir(), hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	defer target.Close()
+
+	copied, err := CopyStorage(source.(IterableStorage), target, 7)
+	require.NoError(t, err)
+	require.NotZero(t, copied)
+
+	for _, root := range append([]types.Hash{root}, roots...) {
+		requireStateAvailable(t, target, root)
+	}
+
+	// the state committed to the mdbx storage is pruned as with the other backends
+	st := NewState(target)
+	require.NoError(t, st.EnablePruning(roots[2], 1))
+
+	next := commitTestStates(t, st, roots[2], 1)
+	insertTestBlocks(t, st, next)
+	requireStateAvailable(t, target, next[0])
+
+	_, err = st.NewSnapshotAt(roots[2])
+	require.ErrorIs(t, err, ErrStateNotAvailable)
+
+	_, err = NewStorage("unknown", t.TempDir(), hclog.NewNullLogger())
+	require.Error(t, err)
+}
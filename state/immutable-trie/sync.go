@@ -0,0 +1,305 @@
+package itrie
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	// ErrUnexpectedSyncData is returned when the delivered data wasn't requested by the sync
+	ErrUnexpectedSyncData = errors.New("unexpected sync data")
+)
+
+// syncRequest is a node or a code scheduled for the retrieval
+type syncRequest struct {
+	hash types.Hash
+	code bool // the request is for a contract code instead of a trie node
+
+	// storage marks the nodes of the storage tries, whose leaves aren't accounts
+	storage bool
+
+	// data is the retrieved node, held until all its children are in the storage
+	data []byte
+
+	parents []*syncRequest
+	deps    int
+}
+
+// Sync downloads (heals) the state trie with the given root by retrieving the missing nodes.
+// A node is written to the storage only after its whole subtree (including the storage tries
+// and the codes of the accounts) is written, so a node found in the storage is always complete
+type Sync struct {
+	storage Storage
+	batch   Batch
+
+	nodeRequests map[types.Hash]*syncRequest
+	codeRequests map[types.Hash]*syncRequest
+
+	// written holds the hashes put into the batch, which is not yet written to the storage
+	written map[types.Hash]struct{}
+
+	// queue holds the requests which are not yet retrieved
+	queue []*syncRequest
+}
+
+// NewSync creates the sync of the state trie with the given root
+func NewSync(root types.Hash, storage Storage) *Sync {
+	s := &Sync{
+		storage:      storage,
+		batch:        storage.Batch(),
+		nodeRequests: make(map[types.Hash]*syncRequest),
+		codeRequests: make(map[types.Hash]*syncRequest),
+		written:      make(map[types.Hash]struct{}),
+	}
+
+	s.schedule(&syncRequest{hash: root}, nil)
+
+	return s
+}
+
+// Pending returns the number of the nodes and codes which are still not written to the storage
+func (s *Sync) Pending() int {
+	return len(s.nodeRequests) + len(s.codeRequests)
+}
+
+// Missing returns at most max hashes of the nodes and the codes that should be retrieved
+func (s *Sync) Missing(max int) ([]types.Hash, []types.Hash) {
+	var nodes, codes []types.Hash
+
+	for len(s.queue) > 0 && len(nodes)+len(codes) < max {
+		req := s.queue[0]
+		s.queue = s.queue[1:]
+
+		if req.code {
+			codes = append(codes, req.hash)
+		} else {
+			nodes = append(nodes, req.hash)
+		}
+	}
+
+	return nodes, codes
+}
+
+// Retry schedules again the hashes which were returned by Missing but weren't delivered
+func (s *Sync) Retry(nodes, codes []types.Hash) {
+	for _, hash := range nodes {
+		if req, ok := s.nodeRequests[hash]; ok && req.data == nil {
+			s.queue = append(s.queue, req)
+		}
+	}
+
+	for _, hash := range codes {
+		if req, ok := s.codeRequests[hash]; ok {
+			s.queue = append(s.queue, req)
+		}
+	}
+}
+
+// ProcessNode processes the retrieved trie node and schedules its missing children
+func (s *Sync) ProcessNode(data []byte) error {
+	hash := types.BytesToHash(crypto.Keccak256(data))
+
+	req, ok := s.nodeRequests[hash]
+	if !ok || req.data != nil {
+		return fmt.Errorf("%w: node %s", ErrUnexpectedSyncData, hash)
+	}
+
+	children, err := s.children(req, data)
+	if err != nil {
+		return err
+	}
+
+	req.data = data
+
+	for _, child := range children {
+		s.schedule(child, req)
+	}
+
+	if req.deps == 0 {
+		s.commit(req)
+	}
+
+	return nil
+}
+
+// ProcessCode processes the retrieved contract code
+func (s *Sync) ProcessCode(code []byte) error {
+	hash := types.BytesToHash(crypto.Keccak256(code))
+
+	req, ok := s.codeRequests[hash]
+	if !ok {
+		return fmt.Errorf("%w: code %s", ErrUnexpectedSyncData, hash)
+	}
+
+	req.data = code
+	s.commit(req)
+
+	return nil
+}
+
+// Commit writes the completed nodes and codes to the storage
+func (s *Sync) Commit() error {
+	if err := s.batch.Write(); err != nil {
+		return err
+	}
+
+	s.batch = s.storage.Batch()
+	s.written = make(map[types.Hash]struct{})
+
+	return nil
+}
+
+// schedule adds the request for the node or the code, if it is not already in the storage
+func (s *Sync) schedule(req *syncRequest, parent *syncRequest) {
+	requests := s.nodeRequests
+	if req.code {
+		requests = s.codeRequests
+	}
+
+	if existing, ok := requests[req.hash]; ok {
+		// the same node might be referenced by several parents
+		existing.parents = append(existing.parents, parent)
+		parent.deps++
+
+		return
+	}
+
+	if s.exists(req) {
+		return
+	}
+
+	if parent != nil {
+		req.parents = append(req.parents, parent)
+		parent.deps++
+	}
+
+	requests[req.hash] = req
+	s.queue = append(s.queue, req)
+}
+
+func (s *Sync) exists(req *syncRequest) bool {
+	if _, ok := s.written[req.hash]; ok {
+		return true
+	}
+
+	if req.code {
+		if req.hash == types.EmptyCodeHash {
+			return true
+		}
+
+		_, ok := s.storage.GetCode(req.hash)
+
+		return ok
+	}
+
+	if req.hash == types.EmptyRootHash {
+		return true
+	}
+
+	_, ok, err := s.storage.Get(req.hash.Bytes())
+
+	return err == nil && ok
+}
+
+// commit writes the request, which has no missing children, and continues with its parents
+func (s *Sync) commit(req *syncRequest) {
+	if req.code {
+		s.batch.Put(GetCodeKey(req.hash), req.data)
+		delete(s.codeRequests, req.hash)
+	} else {
+		s.batch.Put(req.hash.Bytes(), req.data)
+		delete(s.nodeRequests, req.hash)
+	}
+
+	s.written[req.hash] = struct{}{}
+
+	for _, parent := range req.parents {
+		parent.deps--
+
+		if parent.deps == 0 {
+			s.commit(parent)
+		}
+	}
+}
+
+// children returns the requests for the nodes referenced by the given node,
+// for the account leaves these are also the storage roots and the codes
+func (s *Sync) children(req *syncRequest, data []byte) ([]*syncRequest, error) {
+	p := parserPool.Get()
+	defer parserPool.Put(p)
+
+	v, err := p.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Type() != fastrlp.TypeArray {
+		return nil, fmt.Errorf("storage item should be an array")
+	}
+
+	node, err := decodeNode(v, s.storage)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		children []*syncRequest
+		walk     func(node Node) error
+	)
+
+	walk = func(node Node) error {
+		switch n := node.(type) {
+		case nil:
+		case *ValueNode:
+			if n.hash {
+				children = append(children, &syncRequest{
+					hash:    types.BytesToHash(n.buf),
+					storage: req.storage,
+				})
+
+				return nil
+			}
+
+			if req.storage {
+				return nil
+			}
+
+			var account state.Account
+			if err := account.UnmarshalRlp(n.buf); err != nil {
+				return fmt.Errorf("failed to decode account: %w", err)
+			}
+
+			children = append(children,
+				&syncRequest{hash: account.Root, storage: true},
+				&syncRequest{hash: types.BytesToHash(account.CodeHash), code: true},
+			)
+
+		case *ShortNode:
+			return walk(n.child)
+
+		case *FullNode:
+			if err := walk(n.value); err != nil {
+				return err
+			}
+
+			for _, child := range n.children {
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	if err := walk(node); err != nil {
+		return nil, err
+	}
+
+	return children, nil
+}
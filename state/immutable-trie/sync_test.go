@@ -0,0 +1,124 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+// buildTestState commits the accounts with storage and code to the given storage and returns the state root
+func buildTestState(t *testing.T, storage Storage) types.Hash {
+	t.Helper()
+
+	code := []byte{0x60, 0x01, 0x60, 0x02}
+	objs := make([]*state.Object, 0, 50)
+
+	for i := 0; i < 50; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress([]byte{byte(i + 1)}),
+			Balance:  big.NewInt(int64(i)),
+			Nonce:    uint64(i),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+		}
+
+		if i%5 == 0 {
+			// the same code and storage are shared by several accounts
+			obj.CodeHash = types.BytesToHash(crypto.Keccak256(code))
+			obj.Code = code
+			obj.DirtyCode = true
+
+			for j := 0; j < 10; j++ {
+				obj.Storage = append(obj.Storage, &state.StorageObject{
+					Key: types.BytesToHash([]byte{byte(j + 1)}).Bytes(),
+					Val: []byte{byte(j + 1)},
+				})
+			}
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, root, err := NewState(storage).NewSnapshot().Commit(objs)
+	require.NoError(t, err)
+
+	return types.BytesToHash(root)
+}
+
+func TestSync_Heal(t *testing.T) {
+	t.Parallel()
+
+	source := NewMemoryStorage()
+	root := buildTestState(t, source)
+
+	target := NewMemoryStorage()
+	sync := NewSync(root, target)
+
+	for sync.Pending() > 0 {
+		nodes, codes := sync.Missing(16)
+		require.NotEmpty(t, append(nodes, codes...))
+
+		for _, hash := range nodes {
+			data, ok, err := source.Get(hash.Bytes())
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			require.NoError(t, sync.ProcessNode(data))
+		}
+
+		for _, hash := range codes {
+			code, ok := source.GetCode(hash)
+			require.True(t, ok)
+
+			require.NoError(t, sync.ProcessCode(code))
+		}
+
+		require.NoError(t, sync.Commit())
+	}
+
+	result, err := HashChecker(root.Bytes(), target)
+	require.NoError(t, err)
+	require.Equal(t, root, result)
+
+	snap, err := NewState(target).NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(types.BytesToAddress([]byte{1}))
+	require.NoError(t, err)
+	require.NotEqual(t, types.EmptyRootHash, account.Root)
+
+	code, ok := snap.GetCode(types.BytesToHash(account.CodeHash))
+	require.True(t, ok)
+	require.Equal(t, []byte{0x60, 0x01, 0x60, 0x02}, code)
+
+	// nothing to sync when the state is already present
+	require.Zero(t, NewSync(root, target).Pending())
+}
+
+func TestSync_UnexpectedData(t *testing.T) {
+	t.Parallel()
+
+	source := NewMemoryStorage()
+	root := buildTestState(t, source)
+
+	sync := NewSync(root, NewMemoryStorage())
+
+	require.ErrorIs(t, sync.ProcessNode([]byte{0xc0}), ErrUnexpectedSyncData)
+	require.ErrorIs(t, sync.ProcessCode([]byte{0x1}), ErrUnexpectedSyncData)
+
+	// undelivered nodes are requested again
+	nodes, _ := sync.Missing(10)
+	require.Equal(t, []types.Hash{root}, nodes)
+
+	nodes, _ = sync.Missing(10)
+	require.Empty(t, nodes)
+
+	sync.Retry([]types.Hash{root}, nil)
+
+	nodes, _ = sync.Missing(10)
+	require.Equal(t, []types.Hash{root}, nodes)
+}
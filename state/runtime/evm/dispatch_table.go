@@ -88,6 +88,10 @@ func init() {
 	register(SLOAD, handler{inst: opSload, stack: 1, gas: 0})
 	register(SSTORE, handler{inst: opSStore, stack: 2, gas: 0})
 
+	// transient store
+	register(TLOAD, handler{inst: opTload, stack: 1, gas: 0})
+	register(TSTORE, handler{inst: opTstore, stack: 2, gas: 0})
+
 	register(SHA3, handler{inst: opSha3, stack: 2, gas: 30})
 
 	register(POP, handler{inst: opPop, stack: 1, gas: 2})
@@ -117,6 +121,7 @@ func init() {
 	register(CALLDATACOPY, handler{inst: opCallDataCopy, stack: 3, gas: 3})
 	register(RETURNDATACOPY, handler{inst: opReturnDataCopy, stack: 3, gas: 3})
 	register(CODECOPY, handler{inst: opCodeCopy, stack: 3, gas: 3})
+	register(MCOPY, handler{inst: opMCopy, stack: 3, gas: 3})
 
 	// block information
 	register(BLOCKHASH, handler{inst: opBlockHash, stack: 1, gas: 20})
@@ -60,6 +60,13 @@ func (m *mockHostF) SetStorage(
 func (m *mockHostF) SetState(addr types.Address, key types.Hash, value types.Hash) {
 }
 
+func (m *mockHostF) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	return types.Hash{}
+}
+
+func (m *mockHostF) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+}
+
 func (m *mockHostF) SetNonPayable(nonPayable bool) {
 }
 
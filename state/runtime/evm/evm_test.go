@@ -63,6 +63,16 @@ func (m *mockHost) SetStorage(
 	return args.Get(0).(runtime.StorageStatus)
 }
 
+func (m *mockHost) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	args := m.Called(addr, key)
+
+	return args.Get(0).(types.Hash)
+}
+
+func (m *mockHost) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	m.Called(addr, key, value)
+}
+
 func (m *mockHost) SetNonPayable(bool) {
 	panic("Not implemented in tests") //nolint:gocritic
 }
@@ -500,6 +500,48 @@ func opSStore(c *state) {
 
 const sha3WordGas uint64 = 6
 
+// opTload implements EIP-1153 transient storage read
+func opTload(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	if !c.consumeGas(WarmStorageReadCostEIP2929) {
+		return
+	}
+
+	loc := c.top()
+
+	val := c.host.GetTransientStorage(c.msg.Address, uint256ToHash(loc))
+	loc.SetBytes(val.Bytes())
+}
+
+// opTstore implements EIP-1153 transient storage write
+func opTstore(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	if c.inStaticCall() {
+		c.exit(errWriteProtection)
+
+		return
+	}
+
+	if !c.consumeGas(WarmStorageReadCostEIP2929) {
+		return
+	}
+
+	key := c.popHash()
+	val := c.popHash()
+
+	c.host.SetTransientStorage(c.msg.Address, key, val)
+}
+
 func opSha3(c *state) {
 	offset := c.pop()
 	length := c.pop()
@@ -796,6 +838,34 @@ func opCallDataCopy(c *state) {
 	}
 }
 
+// opMCopy implements EIP-5656 memory copy
+func opMCopy(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	dstOffset := c.pop()
+	srcOffset := c.pop()
+	length := c.pop()
+
+	// memory is expanded to cover both the source and the destination area
+	if !c.allocateMemory(srcOffset, length) || !c.allocateMemory(dstOffset, length) {
+		return
+	}
+
+	size := length.Uint64()
+	if !c.consumeGas(((size + 31) / 32) * copyGas) {
+		return
+	}
+
+	if size != 0 {
+		dst, src := dstOffset.Uint64(), srcOffset.Uint64()
+		copy(c.memory[dst:dst+size], c.memory[src:src+size])
+	}
+}
+
 func opReturnDataCopy(c *state) {
 	if !c.config.Byzantium {
 		c.exit(errOpCodeNotFound)
@@ -736,6 +736,82 @@ func TestSStore(t *testing.T) {
 	})
 }
 
+func TestTload(t *testing.T) {
+	t.Run("Cancun", func(t *testing.T) {
+		s, closeFn := getState(&allEnabledForks)
+		defer closeFn()
+
+		mockHost := &mockHost{}
+		mockHost.On("GetTransientStorage", mock.Anything, bigToHash(one)).Return(bigToHash(two)).Once()
+		s.host = mockHost
+
+		s.push(one256)
+
+		opTload(s)
+		require.NoError(t, s.err)
+		assert.Equal(t, defaultInitialGas-WarmStorageReadCostEIP2929, s.gas)
+		v := s.pop()
+		assert.Equal(t, bigToHash(two), bigToHash(v.ToBig()))
+	})
+
+	t.Run("Cancun disabled", func(t *testing.T) {
+		allExceptCancunFork := chain.AllForksEnabled.Copy().RemoveFork(chain.Cancun).At(0)
+
+		s, closeFn := getState(&allExceptCancunFork)
+		defer closeFn()
+
+		s.push(one256)
+
+		opTload(s)
+		assert.ErrorIs(t, s.err, errOpCodeNotFound)
+	})
+}
+
+func TestTstore(t *testing.T) {
+	t.Run("Cancun", func(t *testing.T) {
+		s, closeFn := getState(&allEnabledForks)
+		defer closeFn()
+
+		mockHost := &mockHost{}
+		mockHost.On("SetTransientStorage", mock.Anything, bigToHash(one), bigToHash(two)).Once()
+		s.host = mockHost
+
+		s.push(*uint256.NewInt(2))
+		s.push(one256)
+
+		opTstore(s)
+		require.NoError(t, s.err)
+		assert.Equal(t, defaultInitialGas-WarmStorageReadCostEIP2929, s.gas)
+		mockHost.AssertExpectations(t)
+	})
+
+	t.Run("StaticCall", func(t *testing.T) {
+		s, closeFn := getState(&allEnabledForks)
+		defer closeFn()
+
+		s.msg.Static = true
+
+		s.push(*uint256.NewInt(2))
+		s.push(one256)
+
+		opTstore(s)
+		assert.ErrorIs(t, s.err, errWriteProtection)
+	})
+
+	t.Run("Cancun disabled", func(t *testing.T) {
+		allExceptCancunFork := chain.AllForksEnabled.Copy().RemoveFork(chain.Cancun).At(0)
+
+		s, closeFn := getState(&allExceptCancunFork)
+		defer closeFn()
+
+		s.push(*uint256.NewInt(2))
+		s.push(one256)
+
+		opTstore(s)
+		assert.ErrorIs(t, s.err, errOpCodeNotFound)
+	})
+}
+
 func TestBalance(t *testing.T) {
 	balance := big.NewInt(100)
 
@@ -1179,6 +1255,50 @@ func TestCallDataCopy(t *testing.T) {
 	assert.Equal(t, big.NewInt(1).FillBytes(make([]byte, 32)), s.memory)
 }
 
+func TestMCopy(t *testing.T) {
+	t.Run("Cancun", func(t *testing.T) {
+		s, closeFn := getState(&allEnabledForks)
+		defer closeFn()
+
+		// one word of memory is already allocated
+		source := make([]byte, 32)
+		for i := range source {
+			source[i] = byte(i + 1)
+		}
+
+		s.memory = append(s.memory, source...)
+		s.lastGasCost = 3
+
+		// copy the word to an overlapping area, expanding the memory by one word
+		s.push(*uint256.NewInt(32))
+		s.push(zero256)
+		s.push(*uint256.NewInt(16))
+
+		opMCopy(s)
+		require.NoError(t, s.err)
+
+		// 3 for the memory expansion and 3 for copying one word
+		assert.Equal(t, defaultInitialGas-6, s.gas)
+		assert.Len(t, s.memory, 64)
+		assert.Equal(t, source[:16], s.memory[:16])
+		assert.Equal(t, source, s.memory[16:48])
+	})
+
+	t.Run("Cancun disabled", func(t *testing.T) {
+		allExceptCancunFork := chain.AllForksEnabled.Copy().RemoveFork(chain.Cancun).At(0)
+
+		s, closeFn := getState(&allExceptCancunFork)
+		defer closeFn()
+
+		s.push(one256)
+		s.push(zero256)
+		s.push(zero256)
+
+		opMCopy(s)
+		assert.ErrorIs(t, s.err, errOpCodeNotFound)
+	})
+}
+
 func TestCodeCopyLenZero(t *testing.T) {
 	s, cancelFn := getState(&chain.ForksInTime{})
 	defer cancelFn()
@@ -66,6 +66,8 @@ type Host interface {
 	GetStorage(addr types.Address, key types.Hash) types.Hash
 	SetStorage(addr types.Address, key types.Hash, value types.Hash, config *chain.ForksInTime) StorageStatus
 	SetState(addr types.Address, key types.Hash, value types.Hash)
+	GetTransientStorage(addr types.Address, key types.Hash) types.Hash
+	SetTransientStorage(addr types.Address, key types.Hash, value types.Hash)
 	SetNonPayable(nonPayable bool)
 	GetBalance(addr types.Address) *big.Int
 	GetCodeSize(addr types.Address) int
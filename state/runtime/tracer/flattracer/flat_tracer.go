@@ -0,0 +1,367 @@
+package flattracer
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
+)
+
+const (
+	callTraceType     = "call"
+	createTraceType   = "create"
+	suicideTraceType  = "suicide"
+	revertedErrorText = "Reverted"
+)
+
+var (
+	_ tracer.Tracer      = (*FlatTracer)(nil)
+	_ tracer.StateTracer = (*FlatTracer)(nil)
+
+	callTypes = map[runtime.CallType]string{
+		runtime.Call:         "call",
+		runtime.CallCode:     "callcode",
+		runtime.DelegateCall: "delegatecall",
+		runtime.StaticCall:   "staticcall",
+		runtime.Create:       "create",
+		runtime.Create2:      "create2",
+	}
+)
+
+type Config struct {
+	StateDiff bool // collect the state diff of the transaction
+}
+
+// Action is the call, creation or self destruction done by the trace
+type Action struct {
+	CallType       string         `json:"callType,omitempty"`
+	CreationMethod string         `json:"creationMethod,omitempty"`
+	From           *types.Address `json:"from,omitempty"`
+	To             *types.Address `json:"to,omitempty"`
+	Gas            string         `json:"gas,omitempty"`
+	Input          string         `json:"input,omitempty"`
+	Init           string         `json:"init,omitempty"`
+	Value          string         `json:"value,omitempty"`
+	Address        *types.Address `json:"address,omitempty"`
+	RefundAddress  *types.Address `json:"refundAddress,omitempty"`
+	Balance        string         `json:"balance,omitempty"`
+}
+
+// Result is the outcome of a successful call or creation
+type Result struct {
+	GasUsed string         `json:"gasUsed"`
+	Output  string         `json:"output,omitempty"`
+	Address *types.Address `json:"address,omitempty"`
+	Code    string         `json:"code,omitempty"`
+}
+
+// Trace is a single entry of the flat trace list
+type Trace struct {
+	Action       *Action `json:"action"`
+	Result       *Result `json:"result"`
+	Error        string  `json:"error,omitempty"`
+	Subtraces    int     `json:"subtraces"`
+	TraceAddress []int   `json:"traceAddress"`
+	Type         string  `json:"type"`
+}
+
+// TxTrace is the result of the tracer for a single transaction
+type TxTrace struct {
+	Output    string    `json:"output"`
+	Traces    []*Trace  `json:"trace"`
+	StateDiff StateDiff `json:"stateDiff"`
+}
+
+type frame struct {
+	trace        *Trace
+	gas          uint64
+	availableGas uint64
+}
+
+// FlatTracer collects the Parity (OpenEthereum) style flat call traces of the transaction,
+// where the position of each call in the call tree is given by its trace address
+type FlatTracer struct {
+	Config Config
+
+	traces []*Trace
+	frames []*frame
+	output []byte
+
+	// gasLimit of the transaction, used to account the intrinsic gas to the top level call
+	gasLimit uint64
+
+	// selfDestruct is the self destruction captured before the opcode is executed
+	selfDestruct *Trace
+
+	diffTracer *prestatetracer.PrestateTracer
+
+	cancelLock sync.RWMutex
+	reason     error
+	stop       bool
+}
+
+func NewFlatTracer(config Config) *FlatTracer {
+	f := &FlatTracer{
+		Config: config,
+	}
+
+	if config.StateDiff {
+		f.diffTracer = prestatetracer.NewPrestateTracer(prestatetracer.Config{DiffMode: true})
+	}
+
+	return f
+}
+
+func (f *FlatTracer) Cancel(err error) {
+	f.cancelLock.Lock()
+	defer f.cancelLock.Unlock()
+
+	f.reason = err
+	f.stop = true
+}
+
+func (f *FlatTracer) cancelled() bool {
+	f.cancelLock.RLock()
+	defer f.cancelLock.RUnlock()
+
+	return f.stop
+}
+
+func (f *FlatTracer) Clear() {
+	f.cancelLock.Lock()
+	defer f.cancelLock.Unlock()
+
+	f.reason = nil
+	f.stop = false
+	f.traces = nil
+	f.frames = nil
+	f.output = nil
+	f.gasLimit = 0
+	f.selfDestruct = nil
+
+	if f.diffTracer != nil {
+		f.diffTracer.Clear()
+	}
+}
+
+func (f *FlatTracer) GetResult() (interface{}, error) {
+	f.cancelLock.RLock()
+	defer f.cancelLock.RUnlock()
+
+	if f.reason != nil {
+		return nil, f.reason
+	}
+
+	result := &TxTrace{
+		Output: hex.EncodeToHex(f.output),
+		Traces: f.traces,
+	}
+
+	if result.Traces == nil {
+		result.Traces = []*Trace{}
+	}
+
+	if f.diffTracer != nil {
+		diff, err := f.diffTracer.GetResult()
+		if err != nil {
+			return nil, err
+		}
+
+		diffResult, ok := diff.(*prestatetracer.DiffResult)
+		if !ok {
+			return nil, errors.New("unexpected state diff result")
+		}
+
+		result.StateDiff = NewStateDiff(diffResult)
+	}
+
+	return result, nil
+}
+
+func (f *FlatTracer) TxPrepare(msg *types.Transaction, coinbase types.Address, host tracer.RuntimeHost) {
+	if f.diffTracer != nil {
+		f.diffTracer.TxPrepare(msg, coinbase, host)
+	}
+}
+
+func (f *FlatTracer) TxFinalize(host tracer.FinalizeHost) {
+	if f.diffTracer != nil {
+		f.diffTracer.TxFinalize(host)
+	}
+}
+
+func (f *FlatTracer) TxStart(gasLimit uint64) {
+	f.gasLimit = gasLimit
+}
+
+func (f *FlatTracer) TxEnd(gasLeft uint64) {
+}
+
+func (f *FlatTracer) CallStart(depth int, from, to types.Address, callType int,
+	gas uint64, value *big.Int, input []byte) {
+	if f.cancelled() {
+		return
+	}
+
+	if f.diffTracer != nil {
+		f.diffTracer.CallStart(depth, from, to, callType, gas, value, input)
+	}
+
+	val := "0x0"
+	if value != nil {
+		val = hex.EncodeBig(value)
+	}
+
+	action := &Action{
+		From:  &from,
+		Gas:   hex.EncodeUint64(gas),
+		Value: val,
+	}
+
+	trace := &Trace{
+		Action: action,
+		Type:   callTraceType,
+	}
+
+	switch typ := runtime.CallType(callType); typ {
+	case runtime.Create, runtime.Create2:
+		action.CreationMethod = callTypes[typ]
+		action.Init = hex.EncodeToHex(input)
+		trace.Type = createTraceType
+		trace.Result = &Result{Address: &to}
+	default:
+		action.CallType = callTypes[typ]
+		action.To = &to
+		action.Input = hex.EncodeToHex(input)
+		trace.Result = &Result{}
+	}
+
+	f.push(trace)
+	f.frames = append(f.frames, &frame{
+		trace:        trace,
+		gas:          gas,
+		availableGas: gas,
+	})
+}
+
+func (f *FlatTracer) CallEnd(depth int, output []byte, err error) {
+	if f.diffTracer != nil {
+		f.diffTracer.CallEnd(depth, output, err)
+	}
+
+	if len(f.frames) == 0 {
+		return
+	}
+
+	current := f.frames[len(f.frames)-1]
+	f.frames = f.frames[:len(f.frames)-1]
+
+	if depth == 1 {
+		f.output = output
+	}
+
+	if err != nil {
+		current.trace.Result = nil
+		current.trace.Error = err.Error()
+
+		if errors.Is(err, runtime.ErrExecutionReverted) {
+			current.trace.Error = revertedErrorText
+		}
+
+		return
+	}
+
+	gasUsed := uint64(0)
+	if current.gas > current.availableGas {
+		gasUsed = current.gas - current.availableGas
+	}
+
+	// the top level call includes the intrinsic gas of the transaction
+	if len(f.frames) == 0 && f.gasLimit > current.gas {
+		gasUsed += f.gasLimit - current.gas
+	}
+
+	current.trace.Result.GasUsed = hex.EncodeUint64(gasUsed)
+
+	if current.trace.Type == createTraceType {
+		current.trace.Result.Code = hex.EncodeToHex(output)
+	} else {
+		current.trace.Result.Output = hex.EncodeToHex(output)
+	}
+}
+
+func (f *FlatTracer) CaptureState(memory []byte, stack []uint256.Int, opCode int,
+	contractAddress types.Address, sp int, host tracer.RuntimeHost, state tracer.VMState) {
+	if f.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	if f.diffTracer != nil {
+		f.diffTracer.CaptureState(memory, stack, opCode, contractAddress, sp, host, state)
+	}
+
+	if opCode != evm.SELFDESTRUCT || sp < 1 {
+		return
+	}
+
+	beneficiary := types.BytesToAddress(stack[sp-1].Bytes())
+
+	// the balance is captured before it is transferred,
+	// but the trace is added only once the opcode succeeds
+	f.selfDestruct = &Trace{
+		Action: &Action{
+			Address:       &contractAddress,
+			RefundAddress: &beneficiary,
+			Balance:       hex.EncodeBig(host.GetBalance(contractAddress)),
+		},
+		Type: suicideTraceType,
+	}
+}
+
+func (f *FlatTracer) ExecuteState(contractAddress types.Address, ip uint64, opcode string,
+	availableGas uint64, cost uint64, lastReturnData []byte, depth int, err error, host tracer.RuntimeHost) {
+	if f.selfDestruct != nil {
+		if err == nil {
+			f.push(f.selfDestruct)
+		}
+
+		f.selfDestruct = nil
+	}
+
+	if len(f.frames) == 0 {
+		return
+	}
+
+	current := f.frames[len(f.frames)-1]
+	current.availableGas = availableGas
+
+	if cost <= availableGas {
+		current.availableGas = availableGas - cost
+	}
+}
+
+// push appends the trace to the list, as a subtrace of the currently executed call
+func (f *FlatTracer) push(trace *Trace) {
+	trace.TraceAddress = []int{}
+
+	if len(f.frames) > 0 {
+		parent := f.frames[len(f.frames)-1].trace
+
+		trace.TraceAddress = make([]int, len(parent.TraceAddress), len(parent.TraceAddress)+1)
+		copy(trace.TraceAddress, parent.TraceAddress)
+		trace.TraceAddress = append(trace.TraceAddress, parent.Subtraces)
+
+		parent.Subtraces++
+	}
+
+	f.traces = append(f.traces, trace)
+}
@@ -0,0 +1,269 @@
+package flattracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+	addr3 = types.StringToAddress("3")
+	addr4 = types.StringToAddress("4")
+)
+
+type mockHost struct {
+	balance *big.Int
+}
+
+func (m *mockHost) GetRefund() uint64 {
+	return 0
+}
+
+func (m *mockHost) GetStorage(types.Address, types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+func (m *mockHost) GetTransientStorage(types.Address, types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+func (m *mockHost) GetBalance(types.Address) *big.Int {
+	return m.balance
+}
+
+func (m *mockHost) GetNonce(types.Address) uint64 {
+	return 0
+}
+
+func (m *mockHost) GetCode(types.Address) []byte {
+	return nil
+}
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+func TestFlatTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("timeout")
+	tracer := NewFlatTracer(Config{})
+
+	require.False(t, tracer.cancelled())
+
+	tracer.Cancel(err)
+
+	require.True(t, tracer.cancelled())
+
+	state := &mockState{}
+	tracer.CaptureState(nil, nil, int(evm.STOP), addr1, 0, &mockHost{}, state)
+	require.True(t, state.halted)
+
+	res, resErr := tracer.GetResult()
+	require.Nil(t, res)
+	require.Equal(t, err, resErr)
+
+	tracer.Clear()
+
+	require.False(t, tracer.cancelled())
+}
+
+func TestFlatTracer_Traces(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewFlatTracer(Config{})
+	host := &mockHost{balance: big.NewInt(5)}
+
+	// addr1 calls addr2, which creates addr3 and then calls addr4 which reverts,
+	// after that addr2 self destructs in favor of addr1
+	tracer.TxStart(1021)
+	tracer.CallStart(1, addr1, addr2, int(runtime.Call), 1000, big.NewInt(1), []byte{0x1})
+	tracer.ExecuteState(addr2, 0, "PUSH1", 1000, 3, nil, 1, nil, host)
+
+	tracer.CallStart(2, addr2, addr3, int(runtime.Create2), 500, nil, []byte{0x2})
+	tracer.ExecuteState(addr3, 0, "RETURN", 500, 100, nil, 2, nil, host)
+	tracer.CallEnd(2, []byte{0x3}, nil)
+
+	tracer.CallStart(2, addr2, addr4, int(runtime.StaticCall), 300, nil, []byte{0x4})
+	tracer.CallEnd(2, nil, runtime.ErrExecutionReverted)
+
+	tracer.CaptureState(nil, []uint256.Int{*uint256.NewInt(0).SetBytes(addr1.Bytes())},
+		evm.SELFDESTRUCT, addr2, 1, host, &mockState{})
+	tracer.ExecuteState(addr2, 10, "SELFDESTRUCT", 600, 5000, nil, 1, nil, host)
+	tracer.CallEnd(1, []byte{0x5}, nil)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	result, ok := res.(*TxTrace)
+	require.True(t, ok)
+
+	require.Equal(t, hex.EncodeToHex([]byte{0x5}), result.Output)
+	require.Nil(t, result.StateDiff)
+	require.Equal(t, []*Trace{
+		{
+			Action: &Action{
+				CallType: "call",
+				From:     &addr1,
+				To:       &addr2,
+				Gas:      "0x3e8",
+				Input:    "0x01",
+				Value:    "0x1",
+			},
+			Result: &Result{
+				GasUsed: "0x1a5",
+				Output:  "0x05",
+			},
+			Subtraces:    3,
+			TraceAddress: []int{},
+			Type:         "call",
+		},
+		{
+			Action: &Action{
+				CreationMethod: "create2",
+				From:           &addr2,
+				Gas:            "0x1f4",
+				Init:           "0x02",
+				Value:          "0x0",
+			},
+			Result: &Result{
+				GasUsed: "0x64",
+				Address: &addr3,
+				Code:    "0x03",
+			},
+			TraceAddress: []int{0},
+			Type:         "create",
+		},
+		{
+			Action: &Action{
+				CallType: "staticcall",
+				From:     &addr2,
+				To:       &addr4,
+				Gas:      "0x12c",
+				Input:    "0x04",
+				Value:    "0x0",
+			},
+			Error:        "Reverted",
+			TraceAddress: []int{1},
+			Type:         "call",
+		},
+		{
+			Action: &Action{
+				Address:       &addr2,
+				RefundAddress: &addr1,
+				Balance:       "0x5",
+			},
+			TraceAddress: []int{2},
+			Type:         "suicide",
+		},
+	}, result.Traces)
+}
+
+func TestFlatTracer_FailedSelfDestruct(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewFlatTracer(Config{})
+	host := &mockHost{balance: big.NewInt(5)}
+
+	// a plain value transfer uses the intrinsic gas only
+	tracer.TxStart(21000)
+	tracer.CallStart(1, addr1, addr2, int(runtime.Call), 0, big.NewInt(1), nil)
+
+	// the self destruction runs out of gas
+	tracer.CaptureState(nil, []uint256.Int{*uint256.NewInt(0).SetBytes(addr1.Bytes())},
+		evm.SELFDESTRUCT, addr2, 1, host, &mockState{})
+	tracer.ExecuteState(addr2, 0, "SELFDESTRUCT", 0, 5000, nil, 1, runtime.ErrOutOfGas, host)
+	tracer.CallEnd(1, nil, nil)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	result, ok := res.(*TxTrace)
+	require.True(t, ok)
+
+	require.Len(t, result.Traces, 1)
+	require.Zero(t, result.Traces[0].Subtraces)
+	require.Equal(t, hex.EncodeUint64(21000), result.Traces[0].Result.GasUsed)
+}
+
+func TestNewStateDiff(t *testing.T) {
+	t.Parallel()
+
+	slot1, slot2, slot3 := types.StringToHash("1"), types.StringToHash("2"), types.StringToHash("3")
+
+	stateDiff := NewStateDiff(&prestatetracer.DiffResult{
+		Pre: map[types.Address]*prestatetracer.Account{
+			addr1: {
+				Balance: "0x10",
+				Nonce:   1,
+				Storage: map[types.Hash]types.Hash{
+					slot1: types.StringToHash("a"),
+					slot2: types.StringToHash("b"),
+				},
+			},
+			// self destructed
+			addr3: {
+				Balance: "0x5",
+				Nonce:   1,
+				Code:    "0x02",
+				Storage: map[types.Hash]types.Hash{
+					slot1: types.StringToHash("e"),
+				},
+			},
+		},
+		Post: map[types.Address]*prestatetracer.Account{
+			addr1: {
+				Nonce: 2,
+				Storage: map[types.Hash]types.Hash{
+					slot1: types.StringToHash("c"),
+					slot3: types.StringToHash("d"),
+				},
+			},
+			addr2: {
+				Nonce: 1,
+				Code:  "0x01",
+			},
+		},
+	})
+
+	require.Equal(t, StateDiff{
+		addr1: {
+			Balance: "=",
+			Nonce:   changed("0x1", "0x2"),
+			Code:    "=",
+			Storage: map[types.Hash]interface{}{
+				slot1: changed(types.StringToHash("a").String(), types.StringToHash("c").String()),
+				slot2: changed(types.StringToHash("b").String(), types.ZeroHash.String()),
+				slot3: changed(types.ZeroHash.String(), types.StringToHash("d").String()),
+			},
+		},
+		addr2: {
+			Balance: born("0x0"),
+			Nonce:   born("0x1"),
+			Code:    born("0x01"),
+			Storage: map[types.Hash]interface{}{},
+		},
+		addr3: {
+			Balance: died("0x5"),
+			Nonce:   died("0x1"),
+			Code:    died("0x02"),
+			Storage: map[types.Hash]interface{}{
+				slot1: died(types.StringToHash("e").String()),
+			},
+		},
+	}, stateDiff)
+}
@@ -0,0 +1,152 @@
+package flattracer
+
+import (
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	unchangedMarker = "="
+	bornMarker      = "+"
+	diedMarker      = "-"
+	changedMarker   = "*"
+
+	emptyCode = "0x"
+)
+
+// StateDiff is the Parity style diff of the accounts modified by the transaction
+type StateDiff map[types.Address]*AccountDiff
+
+// AccountDiff is the diff of a single account. Each field is either "=" for the unchanged values,
+// {"+": value} for the values of the created accounts, {"-": value} for the values of the deleted accounts
+// or {"*": {"from": value, "to": value}}
+type AccountDiff struct {
+	Balance interface{}                `json:"balance"`
+	Nonce   interface{}                `json:"nonce"`
+	Code    interface{}                `json:"code"`
+	Storage map[types.Hash]interface{} `json:"storage"`
+}
+
+// Change is the value modified by the transaction
+type Change struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// NewStateDiff converts the diff of the prestate tracer to the Parity style state diff
+func NewStateDiff(diff *prestatetracer.DiffResult) StateDiff {
+	stateDiff := make(StateDiff, len(diff.Post))
+
+	// the deleted accounts are only present in the pre state
+	for addr, pre := range diff.Pre {
+		if _, ok := diff.Post[addr]; !ok {
+			stateDiff[addr] = diedAccountDiff(pre)
+		}
+	}
+
+	for addr, post := range diff.Post {
+		pre, ok := diff.Pre[addr]
+		if !ok {
+			stateDiff[addr] = bornAccountDiff(post)
+
+			continue
+		}
+
+		accountDiff := &AccountDiff{
+			Balance: unchangedMarker,
+			Nonce:   unchangedMarker,
+			Code:    unchangedMarker,
+			Storage: make(map[types.Hash]interface{}),
+		}
+
+		// only the modified fields are set in the post state. The nonce and the code of the account
+		// which still exists can't drop to zero, they are cleared only together with the account
+		if post.Balance != "" {
+			accountDiff.Balance = changed(pre.Balance, post.Balance)
+		}
+
+		if post.Nonce != 0 && post.Nonce != pre.Nonce {
+			accountDiff.Nonce = changed(hex.EncodeUint64(pre.Nonce), hex.EncodeUint64(post.Nonce))
+		}
+
+		if post.Code != "" {
+			accountDiff.Code = changed(orEmptyCode(pre.Code), post.Code)
+		}
+
+		for slot, value := range post.Storage {
+			accountDiff.Storage[slot] = changed(pre.Storage[slot].String(), value.String())
+		}
+
+		// the cleared slots are only present in the pre state
+		for slot, value := range pre.Storage {
+			if _, ok := post.Storage[slot]; !ok {
+				accountDiff.Storage[slot] = changed(value.String(), types.ZeroHash.String())
+			}
+		}
+
+		stateDiff[addr] = accountDiff
+	}
+
+	return stateDiff
+}
+
+func bornAccountDiff(post *prestatetracer.Account) *AccountDiff {
+	balance := post.Balance
+	if balance == "" {
+		balance = "0x0"
+	}
+
+	accountDiff := &AccountDiff{
+		Balance: born(balance),
+		Nonce:   born(hex.EncodeUint64(post.Nonce)),
+		Code:    born(orEmptyCode(post.Code)),
+		Storage: make(map[types.Hash]interface{}, len(post.Storage)),
+	}
+
+	for slot, value := range post.Storage {
+		accountDiff.Storage[slot] = born(value.String())
+	}
+
+	return accountDiff
+}
+
+func diedAccountDiff(pre *prestatetracer.Account) *AccountDiff {
+	balance := pre.Balance
+	if balance == "" {
+		balance = "0x0"
+	}
+
+	accountDiff := &AccountDiff{
+		Balance: died(balance),
+		Nonce:   died(hex.EncodeUint64(pre.Nonce)),
+		Code:    died(orEmptyCode(pre.Code)),
+		Storage: make(map[types.Hash]interface{}, len(pre.Storage)),
+	}
+
+	for slot, value := range pre.Storage {
+		accountDiff.Storage[slot] = died(value.String())
+	}
+
+	return accountDiff
+}
+
+func born(value string) map[string]string {
+	return map[string]string{bornMarker: value}
+}
+
+func died(value string) map[string]string {
+	return map[string]string{diedMarker: value}
+}
+
+func changed(from, to string) map[string]*Change {
+	return map[string]*Change{changedMarker: {From: from, To: to}}
+}
+
+func orEmptyCode(code string) string {
+	if code == "" {
+		return emptyCode
+	}
+
+	return code
+}
@@ -0,0 +1,108 @@
+package fourbytetracer
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
+)
+
+const selectorLength = 4
+
+var _ tracer.Tracer = (*FourByteTracer)(nil)
+
+// FourByteTracer counts the function selectors of all the calls made by the transaction.
+// The selectors are keyed together with the size of the call arguments,
+// e.g. "0xa9059cbb-64" for an ERC20 transfer
+type FourByteTracer struct {
+	ids map[string]int
+
+	cancelLock sync.RWMutex
+	reason     error
+	stop       bool
+}
+
+func NewFourByteTracer() *FourByteTracer {
+	return &FourByteTracer{
+		ids: make(map[string]int),
+	}
+}
+
+func (f *FourByteTracer) Cancel(err error) {
+	f.cancelLock.Lock()
+	defer f.cancelLock.Unlock()
+
+	f.reason = err
+	f.stop = true
+}
+
+func (f *FourByteTracer) cancelled() bool {
+	f.cancelLock.RLock()
+	defer f.cancelLock.RUnlock()
+
+	return f.stop
+}
+
+func (f *FourByteTracer) Clear() {
+	f.cancelLock.Lock()
+	defer f.cancelLock.Unlock()
+
+	f.reason = nil
+	f.stop = false
+	f.ids = make(map[string]int)
+}
+
+func (f *FourByteTracer) GetResult() (interface{}, error) {
+	f.cancelLock.RLock()
+	defer f.cancelLock.RUnlock()
+
+	if f.reason != nil {
+		return nil, f.reason
+	}
+
+	return f.ids, nil
+}
+
+func (f *FourByteTracer) TxStart(gasLimit uint64) {
+}
+
+func (f *FourByteTracer) TxEnd(gasLeft uint64) {
+}
+
+func (f *FourByteTracer) CallStart(depth int, from, to types.Address, callType int,
+	gas uint64, value *big.Int, input []byte) {
+	if f.cancelled() {
+		return
+	}
+
+	// the input of the contract creation is the init code
+	if typ := runtime.CallType(callType); typ == runtime.Create || typ == runtime.Create2 {
+		return
+	}
+
+	if len(input) < selectorLength {
+		return
+	}
+
+	id := fmt.Sprintf("%s-%d", hex.EncodeToHex(input[:selectorLength]), len(input)-selectorLength)
+	f.ids[id]++
+}
+
+func (f *FourByteTracer) CallEnd(depth int, output []byte, err error) {
+}
+
+func (f *FourByteTracer) CaptureState(memory []byte, stack []uint256.Int, opCode int,
+	contractAddress types.Address, sp int, host tracer.RuntimeHost, state tracer.VMState) {
+	if f.cancelled() {
+		state.Halt()
+	}
+}
+
+func (f *FourByteTracer) ExecuteState(contractAddress types.Address, ip uint64, opcode string,
+	availableGas uint64, cost uint64, lastReturnData []byte, depth int, err error, host tracer.RuntimeHost) {
+}
@@ -0,0 +1,67 @@
+package fourbytetracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFourByteTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("timeout")
+	tracer := NewFourByteTracer()
+
+	require.False(t, tracer.cancelled())
+
+	tracer.Cancel(err)
+
+	require.True(t, tracer.cancelled())
+
+	res, resErr := tracer.GetResult()
+	require.Nil(t, res)
+	require.Equal(t, err, resErr)
+
+	tracer.Clear()
+
+	require.False(t, tracer.cancelled())
+}
+
+func TestFourByteTracer_CallStart(t *testing.T) {
+	t.Parallel()
+
+	var (
+		from     = types.StringToAddress("1")
+		to       = types.StringToAddress("2")
+		transfer = hex.MustDecodeHex("0xa9059cbb")
+	)
+
+	tracer := NewFourByteTracer()
+
+	// transfer(address,uint256) called twice
+	tracer.CallStart(1, from, to, 0, 0, big.NewInt(0), append(transfer, make([]byte, 64)...))
+	tracer.CallStart(2, to, from, 3, 0, big.NewInt(0), append(transfer, make([]byte, 64)...))
+	// the same selector with different arguments size
+	tracer.CallStart(2, to, from, 2, 0, big.NewInt(0), transfer)
+	// too short input
+	tracer.CallStart(2, to, from, 0, 0, big.NewInt(0), []byte{0x1})
+	// contract creation
+	tracer.CallStart(2, to, from, 4, 0, big.NewInt(0), append(transfer, 0x1))
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{
+		"0xa9059cbb-64": 2,
+		"0xa9059cbb-0":  1,
+	}, res)
+
+	tracer.Clear()
+
+	res, err = tracer.GetResult()
+	require.NoError(t, err)
+	require.Empty(t, res)
+}
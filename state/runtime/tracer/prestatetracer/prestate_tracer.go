@@ -0,0 +1,360 @@
+package prestatetracer
+
+import (
+	"bytes"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
+)
+
+var (
+	_ tracer.Tracer      = (*PrestateTracer)(nil)
+	_ tracer.StateTracer = (*PrestateTracer)(nil)
+)
+
+type Config struct {
+	DiffMode bool // report the state before and after the execution
+}
+
+// Account is the state of an account touched by the transaction
+type Account struct {
+	Balance string                    `json:"balance,omitempty"`
+	Nonce   uint64                    `json:"nonce,omitempty"`
+	Code    string                    `json:"code,omitempty"`
+	Storage map[types.Hash]types.Hash `json:"storage,omitempty"`
+}
+
+// DiffResult is the result of the tracer in diff mode. The post state holds only the modified fields,
+// the accounts deleted by the transaction are only reported in the pre state
+type DiffResult struct {
+	Pre  map[types.Address]*Account `json:"pre"`
+	Post map[types.Address]*Account `json:"post"`
+}
+
+type account struct {
+	balance *big.Int
+	nonce   uint64
+	code    []byte
+	storage map[types.Hash]types.Hash
+}
+
+func (a *account) empty() bool {
+	return a.balance.Sign() == 0 && a.nonce == 0 && len(a.code) == 0
+}
+
+func (a *account) toAccount() *Account {
+	result := &Account{
+		Nonce: a.nonce,
+	}
+
+	if a.balance != nil {
+		result.Balance = hex.EncodeBig(a.balance)
+	}
+
+	if len(a.code) > 0 {
+		result.Code = hex.EncodeToHex(a.code)
+	}
+
+	if len(a.storage) > 0 {
+		result.Storage = a.storage
+	}
+
+	return result
+}
+
+// PrestateTracer collects the state of all the accounts touched by the transaction,
+// as it was before the execution. In diff mode the state after the execution is
+// collected as well, and only the modified accounts and fields are reported
+type PrestateTracer struct {
+	Config Config
+
+	host    tracer.RuntimeHost
+	pre     map[types.Address]*account
+	post    map[types.Address]*account
+	created map[types.Address]struct{}
+
+	cancelLock sync.RWMutex
+	reason     error
+	stop       bool
+}
+
+func NewPrestateTracer(config Config) *PrestateTracer {
+	return &PrestateTracer{
+		Config:  config,
+		pre:     make(map[types.Address]*account),
+		post:    make(map[types.Address]*account),
+		created: make(map[types.Address]struct{}),
+	}
+}
+
+func (p *PrestateTracer) Cancel(err error) {
+	p.cancelLock.Lock()
+	defer p.cancelLock.Unlock()
+
+	p.reason = err
+	p.stop = true
+}
+
+func (p *PrestateTracer) cancelled() bool {
+	p.cancelLock.RLock()
+	defer p.cancelLock.RUnlock()
+
+	return p.stop
+}
+
+func (p *PrestateTracer) Clear() {
+	p.cancelLock.Lock()
+	defer p.cancelLock.Unlock()
+
+	p.reason = nil
+	p.stop = false
+	p.host = nil
+	p.pre = make(map[types.Address]*account)
+	p.post = make(map[types.Address]*account)
+	p.created = make(map[types.Address]struct{})
+}
+
+func (p *PrestateTracer) GetResult() (interface{}, error) {
+	p.cancelLock.RLock()
+	defer p.cancelLock.RUnlock()
+
+	if p.reason != nil {
+		return nil, p.reason
+	}
+
+	pre := make(map[types.Address]*Account, len(p.pre))
+	for addr, acc := range p.pre {
+		pre[addr] = acc.toAccount()
+	}
+
+	if !p.Config.DiffMode {
+		return pre, nil
+	}
+
+	post := make(map[types.Address]*Account, len(p.post))
+	for addr, acc := range p.post {
+		post[addr] = acc.toAccount()
+	}
+
+	return &DiffResult{
+		Pre:  pre,
+		Post: post,
+	}, nil
+}
+
+func (p *PrestateTracer) TxPrepare(msg *types.Transaction, coinbase types.Address, host tracer.RuntimeHost) {
+	p.host = host
+
+	p.lookupAccount(msg.From())
+	p.lookupAccount(coinbase)
+
+	if msg.IsContractCreation() {
+		created := crypto.CreateAddress(msg.From(), host.GetNonce(msg.From()))
+
+		p.created[created] = struct{}{}
+		p.lookupAccount(created)
+	} else {
+		p.lookupAccount(*msg.To())
+	}
+}
+
+func (p *PrestateTracer) TxFinalize(host tracer.FinalizeHost) {
+	if !p.Config.DiffMode || p.cancelled() {
+		return
+	}
+
+	for addr, pre := range p.pre {
+		if host.Deleted(addr) {
+			// the cleared slots are not reported, as the whole storage is gone
+			for slot, value := range pre.storage {
+				if value == types.ZeroHash {
+					delete(pre.storage, slot)
+				}
+			}
+
+			// the account which didn't exist before the transaction either is not reported
+			if pre.empty() && len(pre.storage) == 0 {
+				delete(p.pre, addr)
+			}
+
+			continue
+		}
+
+		post := &account{
+			storage: make(map[types.Hash]types.Hash),
+		}
+		modified := false
+
+		if balance := host.GetBalance(addr); balance.Cmp(pre.balance) != 0 {
+			post.balance = new(big.Int).Set(balance)
+			modified = true
+		}
+
+		if nonce := host.GetNonce(addr); nonce != pre.nonce {
+			post.nonce = nonce
+			modified = true
+		}
+
+		if code := host.GetCode(addr); !bytes.Equal(code, pre.code) {
+			post.code = code
+			modified = true
+		}
+
+		for slot, value := range pre.storage {
+			newValue := host.GetStorage(addr, slot)
+			if newValue == value {
+				delete(pre.storage, slot)
+
+				continue
+			}
+
+			// the cleared slots are only reported in the pre state
+			if newValue != types.ZeroHash {
+				post.storage[slot] = newValue
+			}
+
+			modified = true
+
+			if value == types.ZeroHash {
+				delete(pre.storage, slot)
+			}
+		}
+
+		if modified {
+			p.post[addr] = post
+		} else {
+			delete(p.pre, addr)
+		}
+	}
+
+	// the accounts which didn't exist before the transaction are only reported in the post state
+	for addr := range p.created {
+		if pre, ok := p.pre[addr]; ok && pre.empty() {
+			delete(p.pre, addr)
+		}
+	}
+}
+
+func (p *PrestateTracer) TxStart(gasLimit uint64) {
+}
+
+func (p *PrestateTracer) TxEnd(gasLeft uint64) {
+}
+
+func (p *PrestateTracer) CallStart(depth int, from, to types.Address, callType int,
+	gas uint64, value *big.Int, input []byte) {
+	if p.cancelled() {
+		return
+	}
+
+	if typ := runtime.CallType(callType); typ == runtime.Create || typ == runtime.Create2 {
+		p.created[to] = struct{}{}
+	}
+
+	p.lookupAccount(from)
+	p.lookupAccount(to)
+}
+
+func (p *PrestateTracer) CallEnd(depth int, output []byte, err error) {
+}
+
+func (p *PrestateTracer) CaptureState(memory []byte, stack []uint256.Int, opCode int,
+	contractAddress types.Address, sp int, host tracer.RuntimeHost, state tracer.VMState) {
+	if p.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	switch opCode {
+	case evm.SLOAD, evm.SSTORE:
+		if sp >= 1 {
+			p.lookupStorage(contractAddress, types.BytesToHash(stack[sp-1].Bytes()))
+		}
+	case evm.BALANCE, evm.EXTCODESIZE, evm.EXTCODECOPY, evm.EXTCODEHASH, evm.SELFDESTRUCT:
+		if sp >= 1 {
+			p.lookupAccount(types.BytesToAddress(stack[sp-1].Bytes()))
+		}
+	case evm.CALL, evm.CALLCODE, evm.DELEGATECALL, evm.STATICCALL:
+		if sp >= 2 {
+			p.lookupAccount(types.BytesToAddress(stack[sp-2].Bytes()))
+		}
+	case evm.CREATE:
+		p.lookupCreated(crypto.CreateAddress(contractAddress, host.GetNonce(contractAddress)))
+	case evm.CREATE2:
+		if sp >= 4 {
+			if initCode, ok := memorySlice(memory, &stack[sp-2], &stack[sp-3]); ok {
+				p.lookupCreated(crypto.CreateAddress2(contractAddress, stack[sp-4].Bytes32(), initCode))
+			}
+		}
+	}
+}
+
+func (p *PrestateTracer) ExecuteState(contractAddress types.Address, ip uint64, opcode string,
+	availableGas uint64, cost uint64, lastReturnData []byte, depth int, err error, host tracer.RuntimeHost) {
+}
+
+// lookupAccount stores the current state of the account, if it has not been touched yet
+func (p *PrestateTracer) lookupAccount(addr types.Address) {
+	if p.host == nil {
+		return
+	}
+
+	if _, ok := p.pre[addr]; ok {
+		return
+	}
+
+	p.pre[addr] = &account{
+		balance: new(big.Int).Set(p.host.GetBalance(addr)),
+		nonce:   p.host.GetNonce(addr),
+		code:    p.host.GetCode(addr),
+		storage: make(map[types.Hash]types.Hash),
+	}
+}
+
+// lookupStorage stores the current value of the storage slot, if it has not been touched yet
+func (p *PrestateTracer) lookupStorage(addr types.Address, slot types.Hash) {
+	p.lookupAccount(addr)
+
+	acc, ok := p.pre[addr]
+	if !ok {
+		return
+	}
+
+	if _, ok := acc.storage[slot]; ok {
+		return
+	}
+
+	acc.storage[slot] = p.host.GetStorage(addr, slot)
+}
+
+func (p *PrestateTracer) lookupCreated(addr types.Address) {
+	p.created[addr] = struct{}{}
+	p.lookupAccount(addr)
+}
+
+// memorySlice returns the given memory range, if it is within the already expanded memory.
+// Otherwise, the created account is looked up once the creation starts
+func memorySlice(memory []byte, offset, size *uint256.Int) ([]byte, bool) {
+	if !offset.IsUint64() || !size.IsUint64() {
+		return nil, false
+	}
+
+	start, length := offset.Uint64(), size.Uint64()
+	if length == 0 {
+		return nil, true
+	}
+
+	if start+length < start || start+length > uint64(len(memory)) {
+		return nil, false
+	}
+
+	return memory[start : start+length], true
+}
@@ -0,0 +1,351 @@
+package prestatetracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	sender   = types.StringToAddress("1")
+	receiver = types.StringToAddress("2")
+	coinbase = types.StringToAddress("3")
+	callee   = types.StringToAddress("4")
+
+	slot1 = types.StringToHash("1")
+	slot2 = types.StringToHash("2")
+)
+
+type mockAccount struct {
+	balance *big.Int
+	nonce   uint64
+	code    []byte
+	storage map[types.Hash]types.Hash
+
+	suicided bool
+}
+
+type mockHost struct {
+	accounts map[types.Address]*mockAccount
+}
+
+func (m *mockHost) account(addr types.Address) *mockAccount {
+	acc, ok := m.accounts[addr]
+	if !ok {
+		acc = &mockAccount{balance: big.NewInt(0), storage: map[types.Hash]types.Hash{}}
+		m.accounts[addr] = acc
+	}
+
+	return acc
+}
+
+func (m *mockHost) GetRefund() uint64 {
+	return 0
+}
+
+func (m *mockHost) GetStorage(addr types.Address, slot types.Hash) types.Hash {
+	return m.account(addr).storage[slot]
+}
+
+func (m *mockHost) GetTransientStorage(types.Address, types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+func (m *mockHost) GetBalance(addr types.Address) *big.Int {
+	return m.account(addr).balance
+}
+
+func (m *mockHost) GetNonce(addr types.Address) uint64 {
+	return m.account(addr).nonce
+}
+
+func (m *mockHost) GetCode(addr types.Address) []byte {
+	return m.account(addr).code
+}
+
+func (m *mockHost) Deleted(addr types.Address) bool {
+	acc := m.account(addr)
+
+	return acc.suicided || acc.balance.Sign() == 0 && acc.nonce == 0 && len(acc.code) == 0
+}
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+func newMockHost() *mockHost {
+	return &mockHost{
+		accounts: map[types.Address]*mockAccount{
+			sender: {
+				balance: big.NewInt(100),
+				nonce:   1,
+				storage: map[types.Hash]types.Hash{},
+			},
+			receiver: {
+				balance: big.NewInt(0),
+				code:    []byte{0x1},
+				storage: map[types.Hash]types.Hash{
+					slot1: types.StringToHash("a"),
+					slot2: types.StringToHash("b"),
+				},
+			},
+		},
+	}
+}
+
+// runTx simulates the transaction in which the receiver reads slot1, overwrites slot2,
+// calls the callee and gets paid by the sender
+func runTx(t *testing.T, tracer *PrestateTracer, host *mockHost) {
+	t.Helper()
+
+	to := receiver
+	tx := types.NewTx(types.NewLegacyTx(types.WithFrom(sender), types.WithTo(&to)))
+
+	tracer.TxPrepare(tx, coinbase, host)
+	tracer.CallStart(1, sender, receiver, 0, 0, big.NewInt(10), nil)
+
+	state := &mockState{}
+
+	tracer.CaptureState(nil, []uint256.Int{*uint256.NewInt(0).SetBytes(slot1.Bytes())},
+		evm.SLOAD, receiver, 1, host, state)
+	tracer.CaptureState(nil, []uint256.Int{*uint256.NewInt(1), *uint256.NewInt(0).SetBytes(slot2.Bytes())},
+		evm.SSTORE, receiver, 2, host, state)
+	host.account(receiver).storage[slot2] = types.StringToHash("c")
+
+	tracer.CaptureState(nil, []uint256.Int{*uint256.NewInt(0).SetBytes(callee.Bytes()), *uint256.NewInt(0)},
+		evm.CALL, receiver, 2, host, state)
+	require.False(t, state.halted)
+
+	host.account(sender).balance = big.NewInt(90)
+	host.account(sender).nonce = 2
+	host.account(receiver).balance = big.NewInt(10)
+
+	tracer.TxFinalize(host)
+}
+
+func TestPrestateTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("timeout")
+	tracer := NewPrestateTracer(Config{})
+
+	require.False(t, tracer.cancelled())
+
+	tracer.Cancel(err)
+
+	require.True(t, tracer.cancelled())
+
+	state := &mockState{}
+	tracer.CaptureState(nil, nil, int(evm.STOP), receiver, 0, newMockHost(), state)
+	require.True(t, state.halted)
+
+	res, resErr := tracer.GetResult()
+	require.Nil(t, res)
+	require.Equal(t, err, resErr)
+
+	tracer.Clear()
+
+	require.False(t, tracer.cancelled())
+	require.Empty(t, tracer.pre)
+}
+
+func TestPrestateTracer_Prestate(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewPrestateTracer(Config{})
+	runTx(t, tracer, newMockHost())
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	require.Equal(t, map[types.Address]*Account{
+		sender: {
+			Balance: "0x64",
+			Nonce:   1,
+		},
+		receiver: {
+			Balance: "0x0",
+			Code:    hex.EncodeToHex([]byte{0x1}),
+			Storage: map[types.Hash]types.Hash{
+				slot1: types.StringToHash("a"),
+				slot2: types.StringToHash("b"),
+			},
+		},
+		coinbase: {
+			Balance: "0x0",
+		},
+		callee: {
+			Balance: "0x0",
+		},
+	}, res)
+}
+
+func TestPrestateTracer_DiffMode(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewPrestateTracer(Config{DiffMode: true})
+	runTx(t, tracer, newMockHost())
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	// untouched accounts and slots are not reported
+	require.Equal(t, &DiffResult{
+		Pre: map[types.Address]*Account{
+			sender: {
+				Balance: "0x64",
+				Nonce:   1,
+			},
+			receiver: {
+				Balance: "0x0",
+				Code:    hex.EncodeToHex([]byte{0x1}),
+				Storage: map[types.Hash]types.Hash{
+					slot2: types.StringToHash("b"),
+				},
+			},
+		},
+		Post: map[types.Address]*Account{
+			sender: {
+				Balance: "0x5a",
+				Nonce:   2,
+			},
+			receiver: {
+				Balance: "0xa",
+				Storage: map[types.Hash]types.Hash{
+					slot2: types.StringToHash("c"),
+				},
+			},
+		},
+	}, res)
+}
+
+func TestPrestateTracer_DiffModeCreate(t *testing.T) {
+	t.Parallel()
+
+	host := newMockHost()
+	tracer := NewPrestateTracer(Config{DiffMode: true})
+
+	created := crypto.CreateAddress(sender, 1)
+	tx := types.NewTx(types.NewLegacyTx(types.WithFrom(sender)))
+
+	tracer.TxPrepare(tx, coinbase, host)
+
+	host.account(created).nonce = 1
+	host.account(created).code = []byte{0x2}
+	host.account(sender).nonce = 2
+
+	tracer.CallStart(1, sender, created, 4, 0, big.NewInt(0), nil)
+	tracer.TxFinalize(host)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	// the created account is only reported in the post state
+	require.Equal(t, &DiffResult{
+		Pre: map[types.Address]*Account{
+			sender: {
+				Balance: "0x64",
+				Nonce:   1,
+			},
+		},
+		Post: map[types.Address]*Account{
+			sender: {
+				Nonce: 2,
+			},
+			created: {
+				Nonce: 1,
+				Code:  hex.EncodeToHex([]byte{0x2}),
+			},
+		},
+	}, res)
+}
+
+func TestPrestateTracer_DiffModeSelfDestruct(t *testing.T) {
+	t.Parallel()
+
+	host := newMockHost()
+	host.account(receiver).balance = big.NewInt(50)
+
+	tracer := NewPrestateTracer(Config{DiffMode: true})
+
+	to := receiver
+	tracer.TxPrepare(types.NewTx(types.NewLegacyTx(types.WithFrom(sender), types.WithTo(&to))), coinbase, host)
+	tracer.CallStart(1, sender, receiver, 0, 0, big.NewInt(0), nil)
+	tracer.CaptureState(nil, []uint256.Int{*uint256.NewInt(0).SetBytes(callee.Bytes())},
+		evm.SELFDESTRUCT, receiver, 1, host, &mockState{})
+
+	host.account(receiver).balance = big.NewInt(0)
+	host.account(receiver).suicided = true
+	host.account(callee).balance = big.NewInt(50)
+	host.account(sender).nonce = 2
+
+	tracer.TxFinalize(host)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	// the deleted account is only reported in the pre state
+	require.Equal(t, &DiffResult{
+		Pre: map[types.Address]*Account{
+			sender: {
+				Balance: "0x64",
+				Nonce:   1,
+			},
+			receiver: {
+				Balance: "0x32",
+				Code:    hex.EncodeToHex([]byte{0x1}),
+			},
+			callee: {
+				Balance: "0x0",
+			},
+		},
+		Post: map[types.Address]*Account{
+			sender: {
+				Nonce: 2,
+			},
+			callee: {
+				Balance: "0x32",
+			},
+		},
+	}, res)
+}
+
+func TestPrestateTracer_Create2(t *testing.T) {
+	t.Parallel()
+
+	host := newMockHost()
+	tracer := NewPrestateTracer(Config{})
+
+	to := receiver
+	tracer.TxPrepare(types.NewTx(types.NewLegacyTx(types.WithFrom(sender), types.WithTo(&to))), coinbase, host)
+
+	initCode := []byte{0x1, 0x2, 0x3}
+	memory := append([]byte{0x0}, initCode...)
+	salt := uint256.NewInt(5)
+
+	// salt, size, offset, value
+	stack := []uint256.Int{*salt, *uint256.NewInt(3), *uint256.NewInt(1), *uint256.NewInt(0)}
+	tracer.CaptureState(memory, stack, evm.CREATE2, receiver, len(stack), host, &mockState{})
+
+	created := crypto.CreateAddress2(receiver, salt.Bytes32(), initCode)
+
+	require.Contains(t, tracer.pre, created)
+	require.Contains(t, tracer.created, created)
+
+	// the init code out of the expanded memory is not read
+	stack[1] = *uint256.NewInt(10)
+	tracer.CaptureState(memory, stack, evm.CREATE2, callee, len(stack), host, &mockState{})
+
+	require.Len(t, tracer.created, 1)
+}
@@ -34,6 +34,8 @@ type StructLog struct {
 	Storage       map[string]string `json:"storage,omitempty"`
 	RefundCounter uint64            `json:"refund,omitempty"`
 	ReturnData    string            `json:"returnData,omitempty"`
+
+	TransientStorage map[string]string `json:"transientStorage,omitempty"`
 }
 
 type StructTracer struct {
@@ -52,6 +54,9 @@ type StructTracer struct {
 	storage       []map[types.Address]map[types.Hash]types.Hash
 	currentMemory [][]byte
 	currentStack  [][]uint256.Int
+
+	// transient storage is bound to the transaction instead of the call frame
+	transientStorage map[types.Address]map[types.Hash]types.Hash
 }
 
 func NewStructTracer(config Config) *StructTracer {
@@ -95,6 +100,7 @@ func (t *StructTracer) Clear() {
 	t.storage = []map[types.Address]map[types.Hash]types.Hash{
 		{},
 	}
+	t.transientStorage = nil
 	t.currentMemory = make([][]byte, 1)
 	t.currentStack = make([][]uint256.Int, 1)
 }
@@ -239,11 +245,41 @@ func (t *StructTracer) captureStorage(
 			addToStorage(slot, value)
 		}
 
+	case evm.TLOAD:
+		if sp >= 1 {
+			slot := types.BytesToHash(stack[sp-1].Bytes())
+			value := host.GetTransientStorage(contractAddress, slot)
+
+			t.addToTransientStorage(contractAddress, slot, value)
+		}
+
+	case evm.TSTORE:
+		if sp >= 2 {
+			slot := types.BytesToHash(stack[sp-1].Bytes())
+			value := types.BytesToHash(stack[sp-2].Bytes())
+
+			t.addToTransientStorage(contractAddress, slot, value)
+		}
+
 	case evm.CALL, evm.STATICCALL:
 		t.storage = append(t.storage, map[types.Address]map[types.Hash]types.Hash{})
 	}
 }
 
+func (t *StructTracer) addToTransientStorage(contractAddress types.Address, key, value types.Hash) {
+	if t.transientStorage == nil {
+		t.transientStorage = make(map[types.Address]map[types.Hash]types.Hash)
+	}
+
+	if submap, initialized := t.transientStorage[contractAddress]; !initialized {
+		t.transientStorage[contractAddress] = map[types.Hash]types.Hash{
+			key: value,
+		}
+	} else {
+		submap[key] = value
+	}
+}
+
 func (t *StructTracer) ExecuteState(
 	contractAddress types.Address,
 	ip uint64,
@@ -261,6 +297,7 @@ func (t *StructTracer) ExecuteState(
 		stack      []string
 		returnData string
 		storage    map[string]string
+		transient  map[string]string
 		isCallOp   bool = opCode == evm.OpCode(evm.CALL).String() || opCode == evm.OpCode(evm.STATICCALL).String()
 	)
 
@@ -303,6 +340,14 @@ func (t *StructTracer) ExecuteState(
 				storage[hex.EncodeToString(k.Bytes())] = hex.EncodeToString(v.Bytes())
 			}
 		}
+
+		if contractStorage, ok := t.transientStorage[contractAddress]; ok {
+			transient = make(map[string]string, len(contractStorage))
+
+			for k, v := range contractStorage {
+				transient[hex.EncodeToString(k.Bytes())] = hex.EncodeToString(v.Bytes())
+			}
+		}
 	}
 
 	if t.Config.EnableReturnData && len(lastReturnData) > 0 {
@@ -328,6 +373,8 @@ func (t *StructTracer) ExecuteState(
 				Depth:         depth,
 				RefundCounter: host.GetRefund(),
 				Error:         errStr,
+
+				TransientStorage: transient,
 			},
 		)
 	}
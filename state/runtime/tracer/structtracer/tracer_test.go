@@ -32,8 +32,12 @@ func (m *mockState) Halt() {
 }
 
 type mockHost struct {
-	getRefundFn    func() uint64
-	getStorageFunc func(types.Address, types.Hash) types.Hash
+	getRefundFn             func() uint64
+	getStorageFunc          func(types.Address, types.Hash) types.Hash
+	getTransientStorageFunc func(types.Address, types.Hash) types.Hash
+	getBalanceFn            func(types.Address) *big.Int
+	getNonceFn              func(types.Address) uint64
+	getCodeFn               func(types.Address) []byte
 }
 
 func (m *mockHost) GetRefund() uint64 {
@@ -44,6 +48,22 @@ func (m *mockHost) GetStorage(a types.Address, h types.Hash) types.Hash {
 	return m.getStorageFunc(a, h)
 }
 
+func (m *mockHost) GetTransientStorage(a types.Address, h types.Hash) types.Hash {
+	return m.getTransientStorageFunc(a, h)
+}
+
+func (m *mockHost) GetBalance(a types.Address) *big.Int {
+	return m.getBalanceFn(a)
+}
+
+func (m *mockHost) GetNonce(a types.Address) uint64 {
+	return m.getNonceFn(a)
+}
+
+func (m *mockHost) GetCode(a types.Address) []byte {
+	return m.getCodeFn(a)
+}
+
 func TestStructLogErrorString(t *testing.T) {
 	t.Parallel()
 
@@ -487,6 +507,85 @@ func TestStructTracerCaptureState(t *testing.T) {
 				}},
 			expectedVMState: &mockState{},
 		},
+		{
+			name: "should capture transient storage by TLOAD",
+			tracer: &StructTracer{
+				Config: Config{
+					EnableStorage:    true,
+					EnableStructLogs: true,
+				},
+				storage: []map[types.Address]map[types.Hash]types.Hash{
+					make(map[types.Address]map[types.Hash]types.Hash),
+				},
+			},
+			memory:          memory,
+			stack:           stack,
+			opCode:          evm.TLOAD,
+			contractAddress: contractAddress,
+			sp:              2,
+			host: &mockHost{
+				getTransientStorageFunc: func(a types.Address, h types.Hash) types.Hash {
+					assert.Equal(t, contractAddress, a)
+					assert.Equal(t, types.BytesToHash(big.NewInt(2).Bytes()), h)
+
+					return storageValue
+				},
+			},
+			vmState: &mockState{
+				halted: false,
+			},
+			expectedTracer: &StructTracer{
+				Config: Config{
+					EnableStorage:    true,
+					EnableStructLogs: true,
+				},
+				storage: []map[types.Address]map[types.Hash]types.Hash{
+					make(map[types.Address]map[types.Hash]types.Hash),
+				},
+				transientStorage: map[types.Address]map[types.Hash]types.Hash{
+					contractAddress: {
+						types.BytesToHash(big.NewInt(2).Bytes()): storageValue,
+					},
+				},
+			},
+			expectedVMState: &mockState{},
+		},
+		{
+			name: "should capture transient storage by TSTORE",
+			tracer: &StructTracer{
+				Config: Config{
+					EnableStorage:    true,
+					EnableStructLogs: true,
+				},
+				storage: []map[types.Address]map[types.Hash]types.Hash{
+					make(map[types.Address]map[types.Hash]types.Hash),
+				},
+			},
+			memory:          memory,
+			stack:           stack,
+			opCode:          evm.TSTORE,
+			contractAddress: contractAddress,
+			sp:              2,
+			host:            nil,
+			vmState: &mockState{
+				halted: false,
+			},
+			expectedTracer: &StructTracer{
+				Config: Config{
+					EnableStorage:    true,
+					EnableStructLogs: true,
+				},
+				storage: []map[types.Address]map[types.Hash]types.Hash{
+					make(map[types.Address]map[types.Hash]types.Hash),
+				},
+				transientStorage: map[types.Address]map[types.Hash]types.Hash{
+					contractAddress: {
+						types.BytesToHash(big.NewInt(2).Bytes()): types.BytesToHash(big.NewInt(1).Bytes()),
+					},
+				},
+			},
+			expectedVMState: &mockState{},
+		},
 		{
 			name: "should call Halt() if it's been canceled",
 			tracer: &StructTracer{
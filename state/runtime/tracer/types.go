@@ -13,6 +13,14 @@ type RuntimeHost interface {
 	GetRefund() uint64
 	// GetStorage access the storage slot at the given address and slot hash
 	GetStorage(types.Address, types.Hash) types.Hash
+	// GetTransientStorage access the transient storage slot at the given address and slot hash
+	GetTransientStorage(types.Address, types.Hash) types.Hash
+	// GetBalance returns the balance of the given address
+	GetBalance(types.Address) *big.Int
+	// GetNonce returns the nonce of the given address
+	GetNonce(types.Address) uint64
+	// GetCode returns the code deployed at the given address
+	GetCode(types.Address) []byte
 }
 
 type VMState interface {
@@ -69,3 +77,20 @@ type Tracer interface {
 		host RuntimeHost,
 	)
 }
+
+// FinalizeHost is the RuntimeHost of the transaction whose changes are all applied
+type FinalizeHost interface {
+	RuntimeHost
+	// Deleted returns true if the account is deleted at the end of the transaction,
+	// because it self destructed or is empty
+	Deleted(types.Address) bool
+}
+
+// StateTracer is implemented by the tracers which need to inspect the state
+// before the transaction is processed and after all its changes are applied
+type StateTracer interface {
+	// TxPrepare is called before the sender is charged for the transaction
+	TxPrepare(msg *types.Transaction, coinbase types.Address, host RuntimeHost)
+	// TxFinalize is called after the fees are paid and the gas is refunded
+	TxFinalize(host FinalizeHost)
+}
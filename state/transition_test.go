@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -167,3 +168,73 @@ func TestTransfer(t *testing.T) {
 		})
 	}
 }
+
+func TestSelfdestruct(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                string
+		config              chain.ForksInTime
+		created             bool
+		beneficiary         types.Address
+		expectedSuicided    bool
+		expectedBalance     uint64
+		expectedBeneficiary uint64
+	}{
+		{
+			name:                "should delete account before Cancun",
+			config:              chain.ForksInTime{London: true},
+			beneficiary:         addr2,
+			expectedSuicided:    true,
+			expectedBalance:     0,
+			expectedBeneficiary: 1000,
+		},
+		{
+			name:                "should only transfer balance of existing account",
+			config:              chain.ForksInTime{London: true, Cancun: true},
+			beneficiary:         addr2,
+			expectedSuicided:    false,
+			expectedBalance:     0,
+			expectedBeneficiary: 1000,
+		},
+		{
+			name:                "should keep balance if beneficiary is the account itself",
+			config:              chain.ForksInTime{London: true, Cancun: true},
+			beneficiary:         addr1,
+			expectedSuicided:    false,
+			expectedBalance:     1000,
+			expectedBeneficiary: 1000,
+		},
+		{
+			name:                "should delete account created in the same transaction",
+			config:              chain.ForksInTime{London: true, Cancun: true},
+			created:             true,
+			beneficiary:         addr2,
+			expectedSuicided:    true,
+			expectedBalance:     0,
+			expectedBeneficiary: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			transition := newTestTransition(map[types.Address]*PreState{
+				addr1: {Balance: 1000},
+			})
+			transition.config = tt.config
+
+			if tt.created {
+				transition.state.CreateAccount(addr1)
+			}
+
+			transition.Selfdestruct(addr1, tt.beneficiary)
+
+			assert.Equal(t, tt.expectedSuicided, transition.state.HasSuicided(addr1))
+			assert.Equal(t, tt.expectedBalance, transition.GetBalance(addr1).Uint64())
+			assert.Equal(t, tt.expectedBeneficiary, transition.GetBalance(tt.beneficiary).Uint64())
+		})
+	}
+}
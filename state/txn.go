@@ -36,6 +36,12 @@ var (
 
 	// refundIndex is the index of the refund
 	refundIndex = types.BytesToHash([]byte{3}).Bytes()
+
+	// transientStorageIndex is the index prefix of the transient storage (EIP-1153)
+	transientStorageIndex = types.BytesToHash([]byte{4}).Bytes()
+
+	// createdAccountIndex is the index prefix of the accounts created in the current transaction (EIP-6780)
+	createdAccountIndex = types.BytesToHash([]byte{5}).Bytes()
 )
 
 // Txn is a reference of the state
@@ -495,6 +501,36 @@ func (txn *Txn) GetRefund() uint64 {
 	return data.(uint64) //nolint:forcetypeassert
 }
 
+// GetTransientState returns the transient storage value (EIP-1153) of the address
+func (txn *Txn) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	val, exists := txn.txn.Get(transientStorageKey(addr, key))
+	if !exists {
+		return types.ZeroHash
+	}
+
+	return val.(types.Hash) //nolint:forcetypeassert
+}
+
+// SetTransientState sets the transient storage value (EIP-1153) of the address.
+// Transient storage is reverted together with the snapshots and discarded at the end of the transaction
+func (txn *Txn) SetTransientState(addr types.Address, key types.Hash, value types.Hash) {
+	if value == types.ZeroHash {
+		txn.txn.Delete(transientStorageKey(addr, key))
+
+		return
+	}
+
+	txn.txn.Insert(transientStorageKey(addr, key), value)
+}
+
+func transientStorageKey(addr types.Address, key types.Hash) []byte {
+	k := make([]byte, 0, len(transientStorageIndex)+types.AddressLength+types.HashLength)
+	k = append(k, transientStorageIndex...)
+	k = append(k, addr.Bytes()...)
+
+	return append(k, key.Bytes()...)
+}
+
 // GetCommittedState returns the state of the address in the trie
 func (txn *Txn) GetCommittedState(addr types.Address, key types.Hash) types.Hash {
 	obj, ok := txn.getStateObject(addr)
@@ -574,6 +610,21 @@ func (txn *Txn) CreateAccount(addr types.Address) {
 	}
 
 	txn.txn.Insert(addr.Bytes(), obj)
+	txn.txn.Insert(createdAccountKey(addr), true)
+}
+
+// IsCreatedInTx returns true if the account was created in the current transaction
+func (txn *Txn) IsCreatedInTx(addr types.Address) bool {
+	_, exists := txn.txn.Get(createdAccountKey(addr))
+
+	return exists
+}
+
+func createdAccountKey(addr types.Address) []byte {
+	k := make([]byte, 0, len(createdAccountIndex)+types.AddressLength)
+	k = append(k, createdAccountIndex...)
+
+	return append(k, addr.Bytes()...)
 }
 
 func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) error {
@@ -611,6 +662,12 @@ func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) error {
 	// delete refunds
 	txn.txn.Delete(refundIndex)
 
+	// delete transient storage
+	txn.txn.DeletePrefix(transientStorageIndex)
+
+	// reset the accounts created in the transaction
+	txn.txn.DeletePrefix(createdAccountIndex)
+
 	return nil
 }
 
@@ -73,6 +73,51 @@ func TestSnapshotUpdateData(t *testing.T) {
 	assert.Equal(t, hash1, txn.GetState(addr1, hash1))
 }
 
+func TestTransientStorage(t *testing.T) {
+	t.Parallel()
+
+	txn := newTestTxn(defaultPreState)
+
+	txn.SetTransientState(addr1, hash1, hash1)
+	assert.Equal(t, hash1, txn.GetTransientState(addr1, hash1))
+	assert.Equal(t, types.ZeroHash, txn.GetTransientState(addr2, hash1))
+
+	// transient storage is reverted together with the snapshot
+	ss := txn.Snapshot()
+	txn.SetTransientState(addr1, hash1, hash2)
+	assert.Equal(t, hash2, txn.GetTransientState(addr1, hash1))
+
+	require.NoError(t, txn.RevertToSnapshot(ss))
+	assert.Equal(t, hash1, txn.GetTransientState(addr1, hash1))
+
+	// transient storage is not part of the persistent storage
+	assert.Equal(t, hash1, txn.GetState(addr1, hash1))
+
+	// transient storage is discarded at the end of the transaction
+	require.NoError(t, txn.CleanDeleteObjects(true))
+	assert.Equal(t, types.ZeroHash, txn.GetTransientState(addr1, hash1))
+}
+
+func TestCreatedInTx(t *testing.T) {
+	t.Parallel()
+
+	txn := newTestTxn(defaultPreState)
+
+	assert.False(t, txn.IsCreatedInTx(addr1))
+
+	ss := txn.Snapshot()
+	txn.CreateAccount(addr2)
+	assert.True(t, txn.IsCreatedInTx(addr2))
+
+	require.NoError(t, txn.RevertToSnapshot(ss))
+	assert.False(t, txn.IsCreatedInTx(addr2))
+
+	// the created accounts are reset at the end of the transaction
+	txn.CreateAccount(addr2)
+	require.NoError(t, txn.CleanDeleteObjects(true))
+	assert.False(t, txn.IsCreatedInTx(addr2))
+}
+
 func TestIncrNonce(t *testing.T) {
 	t.Parallel()
 
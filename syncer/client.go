@@ -17,6 +17,7 @@ import (
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/peer"
+	rawGrpc "google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -24,6 +25,7 @@ const (
 	SyncPeerClientLoggerName = "sync-peer-client"
 	statusTopicName          = "syncer/status/0.1"
 	defaultTimeoutForStatus  = 10 * time.Second
+	defaultTimeoutForState   = 30 * time.Second
 )
 
 type syncPeerClient struct {
@@ -347,6 +349,125 @@ func (m *syncPeerClient) GetBlocks(
 	return blockCh, nil
 }
 
+// GetAccountRange returns the accounts of the state trie with the given root from the origin
+func (m *syncPeerClient) GetAccountRange(
+	peerID peer.ID,
+	root, origin types.Hash,
+	limit uint64,
+) (*TrieRange, error) {
+	return m.getRange(peerID, func(ctx context.Context, clt proto.SyncPeerClient) (*proto.RangeResponse, error) {
+		return clt.GetAccountRange(ctx, &proto.GetAccountRangeRequest{
+			Root:   root.Bytes(),
+			Origin: origin.Bytes(),
+			Limit:  limit,
+		})
+	})
+}
+
+// GetStorageRange returns the storage slots of the storage trie with the given root from the origin
+func (m *syncPeerClient) GetStorageRange(
+	peerID peer.ID,
+	root, origin types.Hash,
+	limit uint64,
+) (*TrieRange, error) {
+	return m.getRange(peerID, func(ctx context.Context, clt proto.SyncPeerClient) (*proto.RangeResponse, error) {
+		return clt.GetStorageRange(ctx, &proto.GetStorageRangeRequest{
+			Root:   root.Bytes(),
+			Origin: origin.Bytes(),
+			Limit:  limit,
+		})
+	})
+}
+
+// GetByteCodes returns the contract codes by hashes, the unknown codes are returned empty
+func (m *syncPeerClient) GetByteCodes(peerID peer.ID, hashes []types.Hash) ([][]byte, error) {
+	return m.getByHashes(peerID, hashes, proto.SyncPeerClient.GetByteCodes)
+}
+
+// GetTrieNodes returns the state trie nodes by hashes, the unknown nodes are returned empty
+func (m *syncPeerClient) GetTrieNodes(peerID peer.ID, hashes []types.Hash) ([][]byte, error) {
+	return m.getByHashes(peerID, hashes, proto.SyncPeerClient.GetTrieNodes)
+}
+
+// GetReceipts returns the receipts of the blocks by hashes
+func (m *syncPeerClient) GetReceipts(peerID peer.ID, hashes []types.Hash) ([][]*types.Receipt, error) {
+	data, err := m.getByHashes(peerID, hashes, proto.SyncPeerClient.GetReceipts)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([][]*types.Receipt, len(data))
+
+	for i, raw := range data {
+		if len(raw) == 0 {
+			return nil, fmt.Errorf("receipts of the block %s not found", hashes[i])
+		}
+
+		var receipts types.Receipts
+		if err := receipts.UnmarshalStoreRLP(raw); err != nil {
+			return nil, fmt.Errorf("failed to decode receipts of the block %s: %w", hashes[i], err)
+		}
+
+		res[i] = receipts
+	}
+
+	return res, nil
+}
+
+func (m *syncPeerClient) getRange(
+	peerID peer.ID,
+	call func(context.Context, proto.SyncPeerClient) (*proto.RangeResponse, error),
+) (*TrieRange, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForState)
+	defer cancel()
+
+	resp, err := call(ctx, clt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TrieRange{
+		Keys:   resp.Keys,
+		Values: resp.Values,
+		Proof:  resp.Proof,
+	}, nil
+}
+
+func (m *syncPeerClient) getByHashes(
+	peerID peer.ID,
+	hashes []types.Hash,
+	call func(proto.SyncPeerClient, context.Context, *proto.HashesRequest, ...rawGrpc.CallOption) (*proto.DataResponse, error),
+) ([][]byte, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForState)
+	defer cancel()
+
+	req := &proto.HashesRequest{Hashes: make([][]byte, len(hashes))}
+	for i, hash := range hashes {
+		req.Hashes[i] = hash.Bytes()
+	}
+
+	resp, err := call(clt, ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Data) != len(hashes) {
+		return nil, fmt.Errorf("invalid response size, expected %d but got %d", len(hashes), len(resp.Data))
+	}
+
+	return resp.Data, nil
+}
+
 // newSyncPeerClient creates gRPC client
 func (m *syncPeerClient) newSyncPeerClient(peerID peer.ID) (proto.SyncPeerClient, error) {
 	conn, err := m.network.NewProtoConnection(syncerProto, peerID)
@@ -1,4 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.7
+// source: syncer/proto/syncer.proto
 
 package proto
 
@@ -12,6 +16,7 @@ import (
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
 const _ = grpc.SupportPackageIsVersion7
 
 // SyncPeerClient is the client API for SyncPeer service.
@@ -22,6 +27,16 @@ type SyncPeerClient interface {
 	GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (SyncPeer_GetBlocksClient, error)
 	// Returns server's status
 	GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SyncPeerStatus, error)
+	// Returns the accounts of the state trie with the proof
+	GetAccountRange(ctx context.Context, in *GetAccountRangeRequest, opts ...grpc.CallOption) (*RangeResponse, error)
+	// Returns the storage slots of the account with the proof
+	GetStorageRange(ctx context.Context, in *GetStorageRangeRequest, opts ...grpc.CallOption) (*RangeResponse, error)
+	// Returns the contract codes by hashes
+	GetByteCodes(ctx context.Context, in *HashesRequest, opts ...grpc.CallOption) (*DataResponse, error)
+	// Returns the state trie nodes by hashes
+	GetTrieNodes(ctx context.Context, in *HashesRequest, opts ...grpc.CallOption) (*DataResponse, error)
+	// Returns the RLP encoded receipts of the blocks by hashes
+	GetReceipts(ctx context.Context, in *HashesRequest, opts ...grpc.CallOption) (*DataResponse, error)
 }
 
 type syncPeerClient struct {
@@ -33,7 +48,7 @@ func NewSyncPeerClient(cc grpc.ClientConnInterface) SyncPeerClient {
 }
 
 func (c *syncPeerClient) GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (SyncPeer_GetBlocksClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_SyncPeer_serviceDesc.Streams[0], "/v1.SyncPeer/GetBlocks", opts...)
+	stream, err := c.cc.NewStream(ctx, &SyncPeer_ServiceDesc.Streams[0], "/v1.SyncPeer/GetBlocks", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -73,6 +88,51 @@ func (c *syncPeerClient) GetStatus(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
+func (c *syncPeerClient) GetAccountRange(ctx context.Context, in *GetAccountRangeRequest, opts ...grpc.CallOption) (*RangeResponse, error) {
+	out := new(RangeResponse)
+	err := c.cc.Invoke(ctx, "/v1.SyncPeer/GetAccountRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *syncPeerClient) GetStorageRange(ctx context.Context, in *GetStorageRangeRequest, opts ...grpc.CallOption) (*RangeResponse, error) {
+	out := new(RangeResponse)
+	err := c.cc.Invoke(ctx, "/v1.SyncPeer/GetStorageRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *syncPeerClient) GetByteCodes(ctx context.Context, in *HashesRequest, opts ...grpc.CallOption) (*DataResponse, error) {
+	out := new(DataResponse)
+	err := c.cc.Invoke(ctx, "/v1.SyncPeer/GetByteCodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *syncPeerClient) GetTrieNodes(ctx context.Context, in *HashesRequest, opts ...grpc.CallOption) (*DataResponse, error) {
+	out := new(DataResponse)
+	err := c.cc.Invoke(ctx, "/v1.SyncPeer/GetTrieNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *syncPeerClient) GetReceipts(ctx context.Context, in *HashesRequest, opts ...grpc.CallOption) (*DataResponse, error) {
+	out := new(DataResponse)
+	err := c.cc.Invoke(ctx, "/v1.SyncPeer/GetReceipts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SyncPeerServer is the server API for SyncPeer service.
 // All implementations must embed UnimplementedSyncPeerServer
 // for forward compatibility
@@ -81,6 +141,16 @@ type SyncPeerServer interface {
 	GetBlocks(*GetBlocksRequest, SyncPeer_GetBlocksServer) error
 	// Returns server's status
 	GetStatus(context.Context, *emptypb.Empty) (*SyncPeerStatus, error)
+	// Returns the accounts of the state trie with the proof
+	GetAccountRange(context.Context, *GetAccountRangeRequest) (*RangeResponse, error)
+	// Returns the storage slots of the account with the proof
+	GetStorageRange(context.Context, *GetStorageRangeRequest) (*RangeResponse, error)
+	// Returns the contract codes by hashes
+	GetByteCodes(context.Context, *HashesRequest) (*DataResponse, error)
+	// Returns the state trie nodes by hashes
+	GetTrieNodes(context.Context, *HashesRequest) (*DataResponse, error)
+	// Returns the RLP encoded receipts of the blocks by hashes
+	GetReceipts(context.Context, *HashesRequest) (*DataResponse, error)
 	mustEmbedUnimplementedSyncPeerServer()
 }
 
@@ -94,6 +164,21 @@ func (UnimplementedSyncPeerServer) GetBlocks(*GetBlocksRequest, SyncPeer_GetBloc
 func (UnimplementedSyncPeerServer) GetStatus(context.Context, *emptypb.Empty) (*SyncPeerStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
 }
+func (UnimplementedSyncPeerServer) GetAccountRange(context.Context, *GetAccountRangeRequest) (*RangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountRange not implemented")
+}
+func (UnimplementedSyncPeerServer) GetStorageRange(context.Context, *GetStorageRangeRequest) (*RangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStorageRange not implemented")
+}
+func (UnimplementedSyncPeerServer) GetByteCodes(context.Context, *HashesRequest) (*DataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByteCodes not implemented")
+}
+func (UnimplementedSyncPeerServer) GetTrieNodes(context.Context, *HashesRequest) (*DataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrieNodes not implemented")
+}
+func (UnimplementedSyncPeerServer) GetReceipts(context.Context, *HashesRequest) (*DataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReceipts not implemented")
+}
 func (UnimplementedSyncPeerServer) mustEmbedUnimplementedSyncPeerServer() {}
 
 // UnsafeSyncPeerServer may be embedded to opt out of forward compatibility for this service.
@@ -104,7 +189,7 @@ type UnsafeSyncPeerServer interface {
 }
 
 func RegisterSyncPeerServer(s grpc.ServiceRegistrar, srv SyncPeerServer) {
-	s.RegisterService(&_SyncPeer_serviceDesc, srv)
+	s.RegisterService(&SyncPeer_ServiceDesc, srv)
 }
 
 func _SyncPeer_GetBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
@@ -146,7 +231,100 @@ func _SyncPeer_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
-var _SyncPeer_serviceDesc = grpc.ServiceDesc{
+func _SyncPeer_GetAccountRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncPeerServer).GetAccountRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SyncPeer/GetAccountRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncPeerServer).GetAccountRange(ctx, req.(*GetAccountRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SyncPeer_GetStorageRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStorageRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncPeerServer).GetStorageRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SyncPeer/GetStorageRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncPeerServer).GetStorageRange(ctx, req.(*GetStorageRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SyncPeer_GetByteCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncPeerServer).GetByteCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SyncPeer/GetByteCodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncPeerServer).GetByteCodes(ctx, req.(*HashesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SyncPeer_GetTrieNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncPeerServer).GetTrieNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SyncPeer/GetTrieNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncPeerServer).GetTrieNodes(ctx, req.(*HashesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SyncPeer_GetReceipts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncPeerServer).GetReceipts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SyncPeer/GetReceipts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncPeerServer).GetReceipts(ctx, req.(*HashesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SyncPeer_ServiceDesc is the grpc.ServiceDesc for SyncPeer service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SyncPeer_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.SyncPeer",
 	HandlerType: (*SyncPeerServer)(nil),
 	Methods: []grpc.MethodDesc{
@@ -154,6 +332,26 @@ var _SyncPeer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetStatus",
 			Handler:    _SyncPeer_GetStatus_Handler,
 		},
+		{
+			MethodName: "GetAccountRange",
+			Handler:    _SyncPeer_GetAccountRange_Handler,
+		},
+		{
+			MethodName: "GetStorageRange",
+			Handler:    _SyncPeer_GetStorageRange_Handler,
+		},
+		{
+			MethodName: "GetByteCodes",
+			Handler:    _SyncPeer_GetByteCodes_Handler,
+		},
+		{
+			MethodName: "GetTrieNodes",
+			Handler:    _SyncPeer_GetTrieNodes_Handler,
+		},
+		{
+			MethodName: "GetReceipts",
+			Handler:    _SyncPeer_GetReceipts_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -3,33 +3,48 @@ package syncer
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/network/grpc"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/syncer/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/armon/go-metrics"
 	"github.com/golang/protobuf/ptypes/empty"
 )
 
+const (
+	// maxRangeLeaves is the maximum number of the trie leaves returned at once
+	maxRangeLeaves = 1024
+	// maxHashesPerRequest is the maximum number of the items requested by hashes at once
+	maxHashesPerRequest = 512
+)
+
 var (
-	ErrBlockNotFound = errors.New("block not found")
+	ErrBlockNotFound   = errors.New("block not found")
+	ErrTooManyHashes   = fmt.Errorf("too many hashes requested, maximum is %d", maxHashesPerRequest)
+	ErrNoStateStorage  = errors.New("state storage is not available")
+	ErrInvalidTrieRoot = errors.New("invalid trie root")
 )
 
 type syncPeerService struct {
 	proto.UnimplementedSyncPeerServer
 
-	blockchain Blockchain       // reference to the blockchain module
-	network    Network          // reference to the network module
-	stream     *grpc.GrpcStream // reference to the grpc stream
+	blockchain   Blockchain       // reference to the blockchain module
+	network      Network          // reference to the network module
+	stateStorage itrie.Storage    // reference to the state storage, served for the snap sync
+	stream       *grpc.GrpcStream // reference to the grpc stream
 }
 
 func NewSyncPeerService(
 	network Network,
 	blockchain Blockchain,
+	stateStorage itrie.Storage,
 ) SyncPeerService {
 	return &syncPeerService{
-		blockchain: blockchain,
-		network:    network,
+		blockchain:   blockchain,
+		network:      network,
+		stateStorage: stateStorage,
 	}
 }
 
@@ -91,6 +106,136 @@ func (s *syncPeerService) GetStatus(
 	}, nil
 }
 
+// GetAccountRange is a gRPC endpoint to return the accounts of the state trie starting from the origin
+func (s *syncPeerService) GetAccountRange(
+	ctx context.Context,
+	req *proto.GetAccountRangeRequest,
+) (*proto.RangeResponse, error) {
+	return s.getRange(req.Root, req.Origin, req.Limit)
+}
+
+// GetStorageRange is a gRPC endpoint to return the storage slots of the account starting from the origin
+func (s *syncPeerService) GetStorageRange(
+	ctx context.Context,
+	req *proto.GetStorageRangeRequest,
+) (*proto.RangeResponse, error) {
+	return s.getRange(req.Root, req.Origin, req.Limit)
+}
+
+// GetByteCodes is a gRPC endpoint to return the contract codes by hashes
+func (s *syncPeerService) GetByteCodes(
+	ctx context.Context,
+	req *proto.HashesRequest,
+) (*proto.DataResponse, error) {
+	return s.getByHashes(req.Hashes, func(hash types.Hash) ([]byte, error) {
+		code, _ := s.stateStorage.GetCode(hash)
+
+		return code, nil
+	})
+}
+
+// GetTrieNodes is a gRPC endpoint to return the state trie nodes by hashes
+func (s *syncPeerService) GetTrieNodes(
+	ctx context.Context,
+	req *proto.HashesRequest,
+) (*proto.DataResponse, error) {
+	return s.getByHashes(req.Hashes, func(hash types.Hash) ([]byte, error) {
+		node, _, err := s.stateStorage.Get(hash.Bytes())
+
+		return node, err
+	})
+}
+
+// GetReceipts is a gRPC endpoint to return the receipts of the blocks by hashes
+func (s *syncPeerService) GetReceipts(
+	ctx context.Context,
+	req *proto.HashesRequest,
+) (*proto.DataResponse, error) {
+	if len(req.Hashes) > maxHashesPerRequest {
+		return nil, ErrTooManyHashes
+	}
+
+	resp := &proto.DataResponse{Data: make([][]byte, len(req.Hashes))}
+
+	for i, hash := range req.Hashes {
+		receipts, err := s.blockchain.GetReceiptsByHash(types.BytesToHash(hash))
+		if err != nil {
+			continue
+		}
+
+		resp.Data[i] = types.Receipts(receipts).MarshalStoreRLPTo(nil)
+	}
+
+	return resp, nil
+}
+
+// getRange returns the leaves of the trie with the given root starting from the origin,
+// along with the proof of the origin and the last leaf
+func (s *syncPeerService) getRange(rawRoot, origin []byte, limit uint64) (*proto.RangeResponse, error) {
+	if s.stateStorage == nil {
+		return nil, ErrNoStateStorage
+	}
+
+	if len(rawRoot) != types.HashLength {
+		return nil, ErrInvalidTrieRoot
+	}
+
+	if limit == 0 || limit > maxRangeLeaves {
+		limit = maxRangeLeaves
+	}
+
+	root := types.BytesToHash(rawRoot)
+
+	keys, values, err := itrie.GetRange(s.stateStorage, root, origin, int(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.RangeResponse{Keys: keys, Values: values}
+
+	if len(keys) == 0 {
+		return resp, nil
+	}
+
+	for _, key := range [][]byte{origin, keys[len(keys)-1]} {
+		proof, err := itrie.Prove(s.stateStorage, root, key)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Proof = append(resp.Proof, proof...)
+	}
+
+	return resp, nil
+}
+
+// getByHashes returns the data by hashes in the requested order, the unknown data is returned empty
+func (s *syncPeerService) getByHashes(
+	hashes [][]byte,
+	get func(types.Hash) ([]byte, error),
+) (*proto.DataResponse, error) {
+	if s.stateStorage == nil {
+		return nil, ErrNoStateStorage
+	}
+
+	if len(hashes) > maxHashesPerRequest {
+		return nil, ErrTooManyHashes
+	}
+
+	resp := &proto.DataResponse{Data: make([][]byte, len(hashes))}
+
+	for i, hash := range hashes {
+		data, err := get(types.BytesToHash(hash))
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Data[i] = data
+	}
+
+	return resp, nil
+}
+
 // toProtoBlock converts type.Block -> proto.Block
 func toProtoBlock(block *types.Block) *proto.Block {
 	return &proto.Block{
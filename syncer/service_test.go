@@ -7,6 +7,8 @@ import (
 	"net"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/crypto"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/syncer/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
@@ -154,3 +156,44 @@ func TestGetStatus(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, headerNumber, status.Number)
 }
+
+func TestSyncPeerService_State(t *testing.T) {
+	t.Parallel()
+
+	storage, root := buildTestState(t, 100, 10)
+
+	client := newMockGrpcClient(t, &syncPeerService{stateStorage: storage})
+
+	accounts, err := client.GetAccountRange(context.Background(), &proto.GetAccountRangeRequest{
+		Root:  root.Bytes(),
+		Limit: 30,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, accounts.Keys, 30)
+	assert.NoError(t, itrie.VerifyRangeProof(root, nil, accounts.Keys, accounts.Values, accounts.Proof))
+
+	_, err = client.GetAccountRange(context.Background(), &proto.GetAccountRangeRequest{
+		Root: []byte{0x1},
+	})
+	assert.ErrorContains(t, err, ErrInvalidTrieRoot.Error())
+
+	codeHash := crypto.Keccak256(testCode)
+
+	codes, err := client.GetByteCodes(context.Background(), &proto.HashesRequest{
+		Hashes: [][]byte{codeHash, types.ZeroHash.Bytes()},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, testCode, codes.Data[0])
+	assert.Empty(t, codes.Data[1])
+
+	nodes, err := client.GetTrieNodes(context.Background(), &proto.HashesRequest{
+		Hashes: [][]byte{root.Bytes()},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, root.Bytes(), crypto.Keccak256(nodes.Data[0]))
+
+	_, err = client.GetTrieNodes(context.Background(), &proto.HashesRequest{
+		Hashes: make([][]byte, maxHashesPerRequest+1),
+	})
+	assert.ErrorContains(t, err, ErrTooManyHashes.Error())
+}
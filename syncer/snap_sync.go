@@ -0,0 +1,502 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// snapSyncPivotDistance is the distance of the pivot block from the peer's latest block,
+	// the state of the pivot block is downloaded instead of executing the chain up to it
+	snapSyncPivotDistance = 64
+
+	// historyBatchSize is the number of the blocks whose receipts are requested at once
+	historyBatchSize = 64
+)
+
+var (
+	errNoStateProgress   = errors.New("peer doesn't serve the requested state")
+	errPivotMismatch     = errors.New("imported pivot block doesn't match the synced state")
+	errStateRootMismatch = errors.New("downloaded state doesn't match the state root")
+)
+
+// shouldSnapSync returns true if the state should be downloaded from the given peer.
+// It is the case when the node is far behind and has no state of its latest block
+// (fresh node or the previous snap sync has been interrupted)
+func (s *syncer) shouldSnapSync(bestPeer *NoForkPeer) bool {
+	if !s.snapSync || s.stateStorage == nil {
+		return false
+	}
+
+	header := s.blockchain.Header()
+	if bestPeer.Number <= header.Number+2*snapSyncPivotDistance {
+		return false
+	}
+
+	if header.Number == 0 {
+		return true
+	}
+
+	_, ok, err := s.stateStorage.Get(header.StateRoot.Bytes())
+
+	return err != nil || !ok
+}
+
+// snapSyncWithPeer downloads the state of the pivot block from the peer, heals the missing trie nodes
+// and then imports the blocks with the receipts up to the pivot without executing them
+func (s *syncer) snapSyncWithPeer(peerID peer.ID, peerLatestBlock uint64,
+	newBlockCallback func(*types.FullBlock) bool) (bool, error) {
+	pivotNumber := peerLatestBlock - snapSyncPivotDistance
+
+	pivot, err := s.getPivotBlock(peerID, pivotNumber)
+	if err != nil {
+		return false, fmt.Errorf("failed to get pivot block: %w", err)
+	}
+
+	s.logger.Info("snap sync started", "peer", peerID, "pivot", pivotNumber, "root", pivot.Header.StateRoot)
+
+	if err := s.downloadState(peerID, pivot.Header.StateRoot); err != nil {
+		return false, fmt.Errorf("failed to download state: %w", err)
+	}
+
+	if err := s.healState(peerID, pivot.Header.StateRoot); err != nil {
+		return false, fmt.Errorf("failed to heal state: %w", err)
+	}
+
+	s.logger.Info("state synced, importing history", "pivot", pivotNumber)
+
+	fullBlock, err := s.importHistory(peerID, pivot)
+	if err != nil {
+		return false, fmt.Errorf("failed to import history: %w", err)
+	}
+
+	s.logger.Info("snap sync finished", "pivot", pivotNumber)
+
+	return newBlockCallback(fullBlock), nil
+}
+
+// getPivotBlock returns the block with the given number from the peer
+func (s *syncer) getPivotBlock(peerID peer.ID, number uint64) (*types.Block, error) {
+	s.lock.RLock()
+	blockTimeout := s.blockTimeout
+	s.lock.RUnlock()
+
+	blockCh, err := s.syncPeerClient.GetBlocks(peerID, number, blockTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := s.syncPeerClient.CloseStream(peerID); err != nil {
+			s.logger.Error("Failed to close stream: ", err)
+		}
+	}()
+
+	block, ok := <-blockCh
+	if !ok || block.Number() != number {
+		return nil, ErrBlockNotFound
+	}
+
+	return block, nil
+}
+
+// downloadState downloads the accounts of the state trie range by range. An account is written
+// only after its storage and code are downloaded and verified, the rest is recovered by healing.
+// When no account is left for healing, the downloaded trie must match the state root
+func (s *syncer) downloadState(peerID peer.ID, root types.Hash) error {
+	if s.stateExists(root) {
+		return nil
+	}
+
+	var (
+		builder = itrie.NewTrieBuilder(s.stateStorage)
+		skipped int
+	)
+
+	err := s.downloadRange(
+		root,
+		func(origin types.Hash) (*TrieRange, error) {
+			return s.syncPeerClient.GetAccountRange(peerID, root, origin, maxRangeLeaves)
+		},
+		func(accounts *TrieRange) error {
+			incomplete, err := s.insertAccounts(peerID, builder, accounts)
+			if err != nil {
+				return err
+			}
+
+			if _, err := builder.Commit(); err != nil {
+				return err
+			}
+
+			skipped += incomplete
+
+			metrics.IncrCounter([]string{syncerMetrics, "snap_accounts"}, float32(len(accounts.Keys)-incomplete))
+
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	result, err := builder.Commit()
+	if err != nil {
+		return err
+	}
+
+	if skipped == 0 && result != root {
+		return fmt.Errorf("%w: expected %s, got %s", errStateRootMismatch, root, result)
+	}
+
+	return nil
+}
+
+// downloadRange fetches the verified leaves of the trie with the given root range by range, until the end of the trie
+func (s *syncer) downloadRange(
+	root types.Hash,
+	fetch func(origin types.Hash) (*TrieRange, error),
+	process func(*TrieRange) error,
+) error {
+	origin := types.ZeroHash
+
+	for {
+		leaves, err := fetch(origin)
+		if err != nil {
+			return err
+		}
+
+		if len(leaves.Keys) == 0 {
+			return nil
+		}
+
+		if err := itrie.VerifyRangeProof(root, origin.Bytes(), leaves.Keys, leaves.Values, leaves.Proof); err != nil {
+			return err
+		}
+
+		if err := process(leaves); err != nil {
+			return err
+		}
+
+		next, ok := nextHash(types.BytesToHash(leaves.Keys[len(leaves.Keys)-1]))
+		if !ok {
+			return nil
+		}
+
+		origin = next
+	}
+}
+
+// insertAccounts inserts the verified accounts whose storage and code are successfully downloaded,
+// it returns the number of the accounts left for healing
+func (s *syncer) insertAccounts(peerID peer.ID, builder *itrie.TrieBuilder, accounts *TrieRange) (int, error) {
+	decoded := make([]*state.Account, len(accounts.Keys))
+	codeHashes := make([]types.Hash, 0)
+
+	for i, value := range accounts.Values {
+		account := &state.Account{}
+		if err := account.UnmarshalRlp(value); err != nil {
+			return 0, fmt.Errorf("failed to decode account: %w", err)
+		}
+
+		decoded[i] = account
+
+		if codeHash := types.BytesToHash(account.CodeHash); !s.codeExists(codeHash) {
+			codeHashes = append(codeHashes, codeHash)
+		}
+	}
+
+	if err := s.downloadCodes(peerID, codeHashes); err != nil {
+		return 0, err
+	}
+
+	skipped := 0
+
+	for i, account := range decoded {
+		if !s.codeExists(types.BytesToHash(account.CodeHash)) {
+			skipped++
+
+			continue
+		}
+
+		complete, err := s.downloadStorage(peerID, account.Root)
+		if err != nil {
+			return 0, err
+		}
+
+		if !complete {
+			s.logger.Debug("incomplete storage, leave the account for healing", "account", accounts.Keys[i])
+
+			skipped++
+
+			continue
+		}
+
+		builder.Insert(accounts.Keys[i], accounts.Values[i])
+	}
+
+	return skipped, nil
+}
+
+// downloadCodes downloads and writes the contract codes with the given hashes
+func (s *syncer) downloadCodes(peerID peer.ID, hashes []types.Hash) error {
+	for len(hashes) > 0 {
+		n := len(hashes)
+		if n > maxHashesPerRequest {
+			n = maxHashesPerRequest
+		}
+
+		codes, err := s.syncPeerClient.GetByteCodes(peerID, hashes[:n])
+		if err != nil {
+			return err
+		}
+
+		for i, code := range codes {
+			if types.BytesToHash(crypto.Keccak256(code)) != hashes[i] {
+				continue
+			}
+
+			if err := s.stateStorage.SetCode(hashes[i], code); err != nil {
+				return err
+			}
+		}
+
+		hashes = hashes[n:]
+	}
+
+	return nil
+}
+
+// downloadStorage downloads the storage trie with the given root,
+// it returns false if the peer hasn't served the complete trie
+func (s *syncer) downloadStorage(peerID peer.ID, root types.Hash) (bool, error) {
+	if s.stateExists(root) {
+		return true, nil
+	}
+
+	builder := itrie.NewTrieBuilder(s.stateStorage)
+
+	err := s.downloadRange(
+		root,
+		func(origin types.Hash) (*TrieRange, error) {
+			return s.syncPeerClient.GetStorageRange(peerID, root, origin, maxRangeLeaves)
+		},
+		func(slots *TrieRange) error {
+			for i := range slots.Keys {
+				builder.Insert(slots.Keys[i], slots.Values[i])
+			}
+
+			_, err := builder.Commit()
+
+			return err
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := builder.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return result == root, nil
+}
+
+// healState retrieves the trie nodes and the codes which are still missing in the state with the given root
+func (s *syncer) healState(peerID peer.ID, root types.Hash) error {
+	sync := itrie.NewSync(root, s.stateStorage)
+
+	for sync.Pending() > 0 {
+		nodeHashes, codeHashes := sync.Missing(maxHashesPerRequest)
+		if len(nodeHashes) == 0 && len(codeHashes) == 0 {
+			return errNoStateProgress
+		}
+
+		delivered := 0
+
+		nodes, err := s.syncPeerClient.GetTrieNodes(peerID, nodeHashes)
+		if err != nil {
+			return err
+		}
+
+		var retryNodes, retryCodes []types.Hash
+
+		for i, node := range nodes {
+			if len(node) == 0 || sync.ProcessNode(node) != nil {
+				retryNodes = append(retryNodes, nodeHashes[i])
+
+				continue
+			}
+
+			delivered++
+		}
+
+		if len(codeHashes) > 0 {
+			codes, err := s.syncPeerClient.GetByteCodes(peerID, codeHashes)
+			if err != nil {
+				return err
+			}
+
+			for i, code := range codes {
+				if len(code) == 0 || sync.ProcessCode(code) != nil {
+					retryCodes = append(retryCodes, codeHashes[i])
+
+					continue
+				}
+
+				delivered++
+			}
+		}
+
+		if err := sync.Commit(); err != nil {
+			return err
+		}
+
+		if delivered == 0 {
+			return errNoStateProgress
+		}
+
+		sync.Retry(retryNodes, retryCodes)
+
+		metrics.IncrCounter([]string{syncerMetrics, "snap_healed"}, float32(delivered))
+	}
+
+	return nil
+}
+
+// importHistory imports the blocks up to the pivot with the receipts from the peer
+func (s *syncer) importHistory(peerID peer.ID, pivot *types.Block) (*types.FullBlock, error) {
+	localLatest := s.blockchain.Header().Number
+
+	s.lock.RLock()
+	blockTimeout := s.blockTimeout
+	s.lock.RUnlock()
+
+	blockCh, err := s.syncPeerClient.GetBlocks(peerID, localLatest+1, blockTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := s.blockchain.SubscribeEvents()
+	s.syncProgression.StartProgression(localLatest+1, subscription)
+	s.syncProgression.UpdateHighestProgression(pivot.Number())
+
+	defer func() {
+		if err := s.syncPeerClient.CloseStream(peerID); err != nil {
+			s.logger.Error("Failed to close stream: ", err)
+		}
+
+		s.syncProgression.StopProgression()
+		s.blockchain.UnsubscribeEvents(subscription)
+	}()
+
+	batch := make([]*types.Block, 0, historyBatchSize)
+
+	for {
+		block, ok := <-blockCh
+		if ok && block.Number() <= pivot.Number() {
+			batch = append(batch, block)
+		}
+
+		if len(batch) < historyBatchSize && ok && block.Number() < pivot.Number() {
+			continue
+		}
+
+		fullBlock, err := s.importBlocks(peerID, batch)
+		if err != nil {
+			return nil, err
+		}
+
+		batch = batch[:0]
+
+		if fullBlock != nil && fullBlock.Block.Number() == pivot.Number() {
+			if fullBlock.Block.Hash() != pivot.Hash() {
+				return nil, errPivotMismatch
+			}
+
+			return fullBlock, nil
+		}
+
+		if !ok {
+			return nil, errTimeout
+		}
+	}
+}
+
+// importBlocks verifies the blocks against their receipts and writes them, it returns the last written block
+func (s *syncer) importBlocks(peerID peer.ID, blocks []*types.Block) (*types.FullBlock, error) {
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+
+	hashes := make([]types.Hash, len(blocks))
+	for i, block := range blocks {
+		hashes[i] = block.Hash()
+	}
+
+	receipts, err := s.syncPeerClient.GetReceipts(peerID, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	var fullBlock *types.FullBlock
+
+	for i, block := range blocks {
+		fullBlock, err = s.blockchain.VerifyFinalizedBlockWithReceipts(block, receipts[i])
+		if err != nil {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+			return nil, fmt.Errorf("unable to verify block, %w", err)
+		}
+
+		if err := s.blockchain.WriteFullBlock(fullBlock, syncerName); err != nil {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+			return nil, fmt.Errorf("failed to write block while importing history: %w", err)
+		}
+
+		updateMetrics(fullBlock)
+	}
+
+	return fullBlock, nil
+}
+
+func (s *syncer) stateExists(root types.Hash) bool {
+	if root == types.EmptyRootHash {
+		return true
+	}
+
+	_, ok, err := s.stateStorage.Get(root.Bytes())
+
+	return err == nil && ok
+}
+
+func (s *syncer) codeExists(hash types.Hash) bool {
+	if hash == types.EmptyCodeHash {
+		return true
+	}
+
+	_, ok := s.stateStorage.GetCode(hash)
+
+	return ok
+}
+
+// nextHash returns the hash following the given one, or false if the given hash is the maximal one
+func nextHash(hash types.Hash) (types.Hash, bool) {
+	for i := len(hash) - 1; i >= 0; i-- {
+		hash[i]++
+
+		if hash[i] != 0 {
+			return hash, true
+		}
+	}
+
+	return types.ZeroHash, false
+}
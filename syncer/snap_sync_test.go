@@ -0,0 +1,305 @@
+package syncer
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCode = []byte{0x60, 0x01, 0x60, 0x02}
+
+// buildTestState builds the state with accounts having the storage and the code
+func buildTestState(t *testing.T, accounts, slots int) (itrie.Storage, types.Hash) {
+	t.Helper()
+
+	storage := itrie.NewMemoryStorage()
+	objs := make([]*state.Object, 0, accounts)
+
+	for i := 0; i < accounts; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress([]byte{byte(i >> 8), byte(i + 1)}),
+			Balance:  big.NewInt(int64(i)),
+			Nonce:    uint64(i),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+		}
+
+		if i%100 == 0 {
+			obj.CodeHash = types.BytesToHash(crypto.Keccak256(testCode))
+			obj.Code = testCode
+			obj.DirtyCode = true
+
+			for j := 0; j < slots; j++ {
+				obj.Storage = append(obj.Storage, &state.StorageObject{
+					Key: types.BytesToHash([]byte{byte(i), byte(j >> 8), byte(j + 1)}).Bytes(),
+					Val: []byte{byte(j + 1)},
+				})
+			}
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, root, err := itrie.NewState(storage).NewSnapshot().Commit(objs)
+	require.NoError(t, err)
+
+	return storage, types.BytesToHash(root)
+}
+
+func requireStateSynced(t *testing.T, storage itrie.Storage, root types.Hash) {
+	t.Helper()
+
+	result, err := itrie.HashChecker(root.Bytes(), storage)
+	require.NoError(t, err)
+	require.Equal(t, root, result)
+
+	snap, err := itrie.NewState(storage).NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(types.BytesToAddress([]byte{0, 1}))
+	require.NoError(t, err)
+
+	code, ok := snap.GetCode(types.BytesToHash(account.CodeHash))
+	require.True(t, ok)
+	require.Equal(t, testCode, code)
+
+	// the whole storage trie is present
+	_, values, err := itrie.GetRange(storage, account.Root, nil, 10000)
+	require.NoError(t, err)
+	require.NotEmpty(t, values)
+}
+
+func newSnapSyncTestBlocks(num int, pivotRoot types.Hash, pivot uint64) []*types.Block {
+	blocks := make([]*types.Block, num)
+	parentHash := types.ZeroHash
+
+	for i := 0; i < num; i++ {
+		header := &types.Header{
+			Number:     uint64(i + 1),
+			ParentHash: parentHash,
+		}
+
+		if header.Number == pivot {
+			header.StateRoot = pivotRoot
+		}
+
+		header.ComputeHash()
+		parentHash = header.Hash
+
+		blocks[i] = &types.Block{Header: header}
+	}
+
+	return blocks
+}
+
+func TestSnapSync(t *testing.T) {
+	t.Parallel()
+
+	peerStorage, root := buildTestState(t, 1100, 1100)
+
+	const latest = 200
+
+	pivot := uint64(latest - snapSyncPivotDistance)
+	blocks := newSnapSyncTestBlocks(latest, root, pivot)
+
+	var (
+		localLatest  uint64
+		written      []*types.FullBlock
+		localStorage = itrie.NewMemoryStorage()
+	)
+
+	localChain := &mockBlockchain{
+		headerHandler: func() *types.Header {
+			return &types.Header{Number: localLatest}
+		},
+		verifyWithReceiptsHandler: func(b *types.Block, receipts []*types.Receipt) (*types.FullBlock, error) {
+			assert.Equal(t, localLatest+1, b.Number())
+
+			return &types.FullBlock{Block: b, Receipts: receipts}, nil
+		},
+		writeFullBlockHandler: func(fb *types.FullBlock) error {
+			localLatest = fb.Block.Number()
+			written = append(written, fb)
+
+			return nil
+		},
+	}
+
+	client := &mockSyncPeerClient{
+		getBlocksHandler: func(_ peer.ID, from uint64, _ time.Duration) (<-chan *types.Block, error) {
+			return blocksToStream(blocks, from), nil
+		},
+		peerState: &syncPeerService{
+			stateStorage: peerStorage,
+			blockchain: &mockBlockchain{
+				getReceiptsByHashHandler: func(types.Hash) ([]*types.Receipt, error) {
+					return []*types.Receipt{}, nil
+				},
+			},
+		},
+	}
+
+	syncer := NewTestSyncer(nil, localChain, time.Second, client, &mockProgression{})
+	syncer.stateStorage = localStorage
+	syncer.snapSync = true
+
+	bestPeer := &NoForkPeer{ID: peer.ID("A"), Number: latest}
+	require.True(t, syncer.shouldSnapSync(bestPeer))
+
+	var callbackBlocks []uint64
+
+	_, err := syncer.snapSyncWithPeer(bestPeer.ID, bestPeer.Number, func(fb *types.FullBlock) bool {
+		callbackBlocks = append(callbackBlocks, fb.Block.Number())
+
+		return false
+	})
+	require.NoError(t, err)
+
+	requireStateSynced(t, localStorage, root)
+
+	// the history is imported up to the pivot and only the pivot is passed to the callback
+	require.Len(t, written, int(pivot))
+	require.Equal(t, pivot, localLatest)
+	require.Equal(t, []uint64{pivot}, callbackBlocks)
+
+	// the state of the latest block is present, so the normal sync follows
+	syncer.blockchain = &mockBlockchain{
+		headerHandler: func() *types.Header {
+			return &types.Header{Number: 1, StateRoot: root}
+		},
+	}
+
+	require.False(t, syncer.shouldSnapSync(bestPeer))
+}
+
+func TestSnapSync_HealState(t *testing.T) {
+	t.Parallel()
+
+	peerStorage, root := buildTestState(t, 100, 50)
+	localStorage := itrie.NewMemoryStorage()
+
+	syncer := NewTestSyncer(nil, nil, time.Second, &mockSyncPeerClient{
+		peerState: &syncPeerService{stateStorage: peerStorage},
+	}, &mockProgression{})
+	syncer.stateStorage = localStorage
+
+	require.NoError(t, syncer.healState(peer.ID("A"), root))
+
+	requireStateSynced(t, localStorage, root)
+
+	// the peer without the state
+	syncer.syncPeerClient = &mockSyncPeerClient{
+		peerState: &syncPeerService{stateStorage: itrie.NewMemoryStorage()},
+	}
+	syncer.stateStorage = itrie.NewMemoryStorage()
+
+	require.ErrorIs(t, syncer.healState(peer.ID("A"), root), errNoStateProgress)
+}
+
+// tamperingSyncPeerClient alters the account ranges served from the peer state
+type tamperingSyncPeerClient struct {
+	*mockSyncPeerClient
+	tamper func(origin types.Hash, accounts *TrieRange)
+}
+
+func (c *tamperingSyncPeerClient) GetAccountRange(
+	id peer.ID,
+	root, origin types.Hash,
+	limit uint64,
+) (*TrieRange, error) {
+	accounts, err := c.mockSyncPeerClient.GetAccountRange(id, root, origin, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	c.tamper(origin, accounts)
+
+	return accounts, nil
+}
+
+func TestSnapSync_DownloadState_Verification(t *testing.T) {
+	t.Parallel()
+
+	peerStorage, root := buildTestState(t, maxRangeLeaves+100, 10)
+
+	cases := []struct {
+		name   string
+		tamper func(origin types.Hash, accounts *TrieRange)
+		err    error
+	}{
+		{
+			name: "altered account",
+			tamper: func(_ types.Hash, accounts *TrieRange) {
+				accounts.Values[len(accounts.Values)/2] = accounts.Values[0]
+			},
+			err: itrie.ErrInvalidRangeProof,
+		},
+		{
+			name: "omitted account",
+			tamper: func(_ types.Hash, accounts *TrieRange) {
+				i := len(accounts.Keys) / 2
+				accounts.Keys = append(accounts.Keys[:i], accounts.Keys[i+1:]...)
+				accounts.Values = append(accounts.Values[:i], accounts.Values[i+1:]...)
+			},
+			err: itrie.ErrInvalidRangeProof,
+		},
+		{
+			name: "truncated state",
+			tamper: func(origin types.Hash, accounts *TrieRange) {
+				if origin != types.ZeroHash {
+					accounts.Keys, accounts.Values, accounts.Proof = nil, nil, nil
+				}
+			},
+			err: errStateRootMismatch,
+		},
+	}
+
+	for _, c := range cases {
+		syncer := NewTestSyncer(nil, nil, time.Second, nil, &mockProgression{})
+		syncer.stateStorage = itrie.NewMemoryStorage()
+		syncer.syncPeerClient = &tamperingSyncPeerClient{
+			mockSyncPeerClient: &mockSyncPeerClient{peerState: &syncPeerService{stateStorage: peerStorage}},
+			tamper:             c.tamper,
+		}
+
+		require.ErrorIs(t, syncer.downloadState(peer.ID("A"), root), c.err, c.name)
+	}
+}
+
+func TestNextHash(t *testing.T) {
+	t.Parallel()
+
+	next, ok := nextHash(types.StringToHash("01ff"))
+	require.True(t, ok)
+	require.Equal(t, types.StringToHash("0200"), next)
+
+	max := types.Hash{}
+	for i := range max {
+		max[i] = 0xff
+	}
+
+	_, ok = nextHash(max)
+	require.False(t, ok)
+}
+
+func blocksToStream(blocks []*types.Block, from uint64) <-chan *types.Block {
+	ch := make(chan *types.Block, len(blocks))
+
+	for _, b := range blocks {
+		if b.Number() >= from {
+			ch <- b
+		}
+	}
+
+	close(ch)
+
+	return ch
+}
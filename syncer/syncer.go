@@ -8,6 +8,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/network/event"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
@@ -40,6 +41,12 @@ type syncer struct {
 	// Channel to notify Sync that a new status arrived
 	newStatusCh chan struct{}
 
+	// Storage of the state, it is served to the peers and filled by the snap sync
+	stateStorage itrie.Storage
+
+	// Flag to download the state from the peers instead of executing the blocks, if the node is far behind
+	snapSync bool
+
 	lock sync.RWMutex
 }
 
@@ -48,16 +55,20 @@ func NewSyncer(
 	network Network,
 	blockchain Blockchain,
 	blockTimeout time.Duration,
+	stateStorage itrie.Storage,
+	snapSync bool,
 ) Syncer {
 	return &syncer{
 		logger:          logger.Named(syncerName),
 		blockchain:      blockchain,
 		syncProgression: progress.NewProgressionWrapper(progress.ChainSyncBulk),
-		syncPeerService: NewSyncPeerService(network, blockchain),
+		syncPeerService: NewSyncPeerService(network, blockchain, stateStorage),
 		syncPeerClient:  NewSyncPeerClient(logger, network, blockchain),
 		blockTimeout:    blockTimeout,
 		newStatusCh:     make(chan struct{}),
 		peerMap:         new(PeerMap),
+		stateStorage:    stateStorage,
+		snapSync:        snapSync,
 	}
 }
 
@@ -208,6 +219,24 @@ func (s *syncer) Sync(callback func(*types.FullBlock) bool) error {
 			continue
 		}
 
+		// download the state instead of executing the blocks if the node is far behind
+		if s.shouldSnapSync(bestPeer) {
+			shouldTerminate, err := s.snapSyncWithPeer(bestPeer.ID, bestPeer.Number, callback)
+			if err != nil {
+				s.logger.Warn("failed to complete snap sync with peer, try to next one", "peer ID", bestPeer.ID, "error", err)
+
+				skipList[bestPeer.ID] = true
+
+				continue
+			}
+
+			if shouldTerminate {
+				break
+			}
+
+			continue
+		}
+
 		// fetch block from the peer
 		lastNumber, shouldTerminate, err := s.bulkSyncWithPeer(bestPeer.ID, bestPeer.Number, callback)
 		if err != nil {
@@ -1,6 +1,7 @@
 package syncer
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -11,6 +12,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/network/event"
+	"github.com/0xPolygon/polygon-edge/syncer/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -42,6 +44,8 @@ type mockBlockchain struct {
 	verifyFinalizedBlockHandler func(*types.Block) (*types.FullBlock, error)
 	writeBlockHandler           func(*types.Block) error
 	writeFullBlockHandler       func(*types.FullBlock) error
+	verifyWithReceiptsHandler   func(*types.Block, []*types.Receipt) (*types.FullBlock, error)
+	getReceiptsByHashHandler    func(types.Hash) ([]*types.Receipt, error)
 }
 
 func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
@@ -71,6 +75,17 @@ func (m *mockBlockchain) WriteFullBlock(b *types.FullBlock, s string) error {
 	return m.writeFullBlockHandler(b)
 }
 
+func (m *mockBlockchain) VerifyFinalizedBlockWithReceipts(
+	b *types.Block,
+	receipts []*types.Receipt,
+) (*types.FullBlock, error) {
+	return m.verifyWithReceiptsHandler(b, receipts)
+}
+
+func (m *mockBlockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return m.getReceiptsByHashHandler(hash)
+}
+
 func newSimpleHeaderHandler(num uint64) func() *types.Header {
 	return func() *types.Header {
 		return &types.Header{
@@ -95,6 +110,9 @@ type mockSyncPeerClient struct {
 	getBlocksHandler                      func(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
 	getPeerStatusUpdateChHandler          func() <-chan *NoForkPeer
 	getPeerConnectionUpdateEventChHandler func() <-chan *event.PeerEvent
+
+	// snap sync handlers are served from the state of this peer
+	peerState *syncPeerService
 }
 
 func (m *mockSyncPeerClient) DisablePublishingPeerStatus() {}
@@ -135,6 +153,87 @@ func (m *mockSyncPeerClient) CloseStream(peerID peer.ID) error {
 	return nil
 }
 
+func (m *mockSyncPeerClient) GetAccountRange(
+	id peer.ID,
+	root, origin types.Hash,
+	limit uint64,
+) (*TrieRange, error) {
+	resp, err := m.peerState.GetAccountRange(context.Background(), &proto.GetAccountRangeRequest{
+		Root:   root.Bytes(),
+		Origin: origin.Bytes(),
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TrieRange{Keys: resp.Keys, Values: resp.Values, Proof: resp.Proof}, nil
+}
+
+func (m *mockSyncPeerClient) GetStorageRange(
+	id peer.ID,
+	root, origin types.Hash,
+	limit uint64,
+) (*TrieRange, error) {
+	resp, err := m.peerState.GetStorageRange(context.Background(), &proto.GetStorageRangeRequest{
+		Root:   root.Bytes(),
+		Origin: origin.Bytes(),
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TrieRange{Keys: resp.Keys, Values: resp.Values, Proof: resp.Proof}, nil
+}
+
+func (m *mockSyncPeerClient) GetByteCodes(id peer.ID, hashes []types.Hash) ([][]byte, error) {
+	resp, err := m.peerState.GetByteCodes(context.Background(), toHashesRequest(hashes))
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+func (m *mockSyncPeerClient) GetTrieNodes(id peer.ID, hashes []types.Hash) ([][]byte, error) {
+	resp, err := m.peerState.GetTrieNodes(context.Background(), toHashesRequest(hashes))
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+func (m *mockSyncPeerClient) GetReceipts(id peer.ID, hashes []types.Hash) ([][]*types.Receipt, error) {
+	resp, err := m.peerState.GetReceipts(context.Background(), toHashesRequest(hashes))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([][]*types.Receipt, len(resp.Data))
+
+	for i, data := range resp.Data {
+		var receipts types.Receipts
+		if err := receipts.UnmarshalStoreRLP(data); err != nil {
+			return nil, err
+		}
+
+		res[i] = receipts
+	}
+
+	return res, nil
+}
+
+func toHashesRequest(hashes []types.Hash) *proto.HashesRequest {
+	req := &proto.HashesRequest{}
+	for _, hash := range hashes {
+		req.Hashes = append(req.Hashes, hash.Bytes())
+	}
+
+	return req
+}
+
 func GetAllElementsFromPeerMap(t *testing.T, p *PeerMap) []*NoForkPeer {
 	t.Helper()
 
@@ -33,6 +33,10 @@ type Blockchain interface {
 	WriteBlock(*types.Block, string) error
 	// WriteFullBlock writes a given block to chain and saves its receipts to cache
 	WriteFullBlock(*types.FullBlock, string) error
+	// VerifyFinalizedBlockWithReceipts verifies finalized block against the given receipts without executing it
+	VerifyFinalizedBlockWithReceipts(*types.Block, []*types.Receipt) (*types.FullBlock, error)
+	// GetReceiptsByHash returns the receipts of the block with the given hash
+	GetReceiptsByHash(types.Hash) ([]*types.Receipt, error)
 }
 
 type Network interface {
@@ -113,4 +117,21 @@ type SyncPeerClient interface {
 	DisablePublishingPeerStatus()
 	// EnablePublishingPeerStatus enables publishing status in syncer topic
 	EnablePublishingPeerStatus()
+	// GetAccountRange returns the accounts of the state trie with the given root from the origin
+	GetAccountRange(peerID peer.ID, root, origin types.Hash, limit uint64) (*TrieRange, error)
+	// GetStorageRange returns the storage slots of the storage trie with the given root from the origin
+	GetStorageRange(peerID peer.ID, root, origin types.Hash, limit uint64) (*TrieRange, error)
+	// GetByteCodes returns the contract codes by hashes, the unknown codes are returned empty
+	GetByteCodes(peerID peer.ID, hashes []types.Hash) ([][]byte, error)
+	// GetTrieNodes returns the state trie nodes by hashes, the unknown nodes are returned empty
+	GetTrieNodes(peerID peer.ID, hashes []types.Hash) ([][]byte, error)
+	// GetReceipts returns the receipts of the blocks by hashes
+	GetReceipts(peerID peer.ID, hashes []types.Hash) ([][]*types.Receipt, error)
+}
+
+// TrieRange is a range of the consecutive trie leaves with the proof of its first and last leaf
+type TrieRange struct {
+	Keys   [][]byte
+	Values [][]byte
+	Proof  [][]byte
 }
@@ -4,6 +4,7 @@ import (
 	"maps"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -132,6 +133,31 @@ func (m *accountsMap) allTxs(includeEnqueued bool) (
 	return
 }
 
+// localTxs returns the promoted and enqueued transactions of all the local accounts
+func (m *accountsMap) localTxs() []*types.Transaction {
+	txs := make([]*types.Transaction, 0)
+
+	m.Range(func(key, value interface{}) bool {
+		account, _ := value.(*account)
+		if !account.local.Load() {
+			return true
+		}
+
+		account.promoted.lock(false)
+		defer account.promoted.unlock()
+
+		account.enqueued.lock(false)
+		defer account.enqueued.unlock()
+
+		txs = append(txs, account.promoted.queue...)
+		txs = append(txs, account.enqueued.queue...)
+
+		return true
+	})
+
+	return txs
+}
+
 func (m *accountsMap) reinsertProposed() uint64 {
 	var count uint64
 
@@ -219,6 +245,10 @@ func (m *nonceToTxLookup) lock() {
 	m.mutex.Lock()
 }
 
+func (m *nonceToTxLookup) tryLock() bool {
+	return m.mutex.TryLock()
+}
+
 func (m *nonceToTxLookup) unlock() {
 	m.mutex.Unlock()
 }
@@ -264,6 +294,12 @@ type account struct {
 
 	//	maximum number of enqueued transactions
 	maxEnqueued uint64
+
+	// local is set if the account submitted transactions through the local endpoints
+	local atomic.Bool
+
+	// lastEnqueued is the unix time (in nanoseconds) of the last enqueued transaction
+	lastEnqueued atomic.Int64
 }
 
 // getNonce returns the next expected nonce for this account.
@@ -359,6 +395,7 @@ func (a *account) enqueue(tx *types.Transaction, replace bool) {
 	}
 
 	a.nonceToTx.set(tx)
+	a.lastEnqueued.Store(time.Now().UnixNano())
 
 	if !replace {
 		a.enqueued.push(tx)
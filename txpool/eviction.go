@@ -0,0 +1,219 @@
+package txpool
+
+import (
+	"time"
+
+	"github.com/armon/go-metrics"
+
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// lifetimeEvictionInterval is the time interval at which the enqueued transactions are checked for expiry
+const lifetimeEvictionInterval = time.Minute
+
+// runLifetimeEviction periodically evicts the stale enqueued transactions
+func (p *TxPool) runLifetimeEviction() {
+	ticker := time.NewTicker(lifetimeEvictionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case <-ticker.C:
+			p.evictStaleTxs()
+		}
+	}
+}
+
+// evictStaleTxs evicts the enqueued transactions of all the non-local accounts
+// which haven't enqueued a transaction for longer than the configured lifetime
+func (p *TxPool) evictStaleTxs() {
+	deadline := time.Now().Add(-p.lifetime).UnixNano()
+
+	p.accounts.Range(func(_, value interface{}) bool {
+		account, _ := value.(*account)
+
+		if account.local.Load() || account.lastEnqueued.Load() > deadline {
+			return true
+		}
+
+		account.enqueued.lock(true)
+		defer account.enqueued.unlock()
+
+		account.nonceToTx.lock()
+		defer account.nonceToTx.unlock()
+
+		if account.enqueued.length() == 0 {
+			return true
+		}
+
+		evicted := account.enqueued.clear()
+
+		account.nonceToTx.remove(evicted...)
+		p.index.remove(evicted...)
+		p.gauge.decrease(slotsRequired(evicted...))
+
+		metrics.IncrCounter([]string{txPoolMetrics, "evicted_stale_tx"}, float32(len(evicted)))
+
+		p.eventManager.signalEvent(proto.EventType_EVICTED, toHash(evicted...)...)
+
+		if p.logger.IsDebug() {
+			p.logger.Debug("evicted stale enqueued txs",
+				"num", len(evicted),
+				"address", evicted[0].From().String(),
+			)
+		}
+
+		return true
+	})
+}
+
+// evictUnderpriced frees up (at least) the given amount of slots by evicting
+// the cheapest non-local transactions priced lower than the incoming tx,
+// taken from the evictable queue. The transactions with a higher nonce of an account
+// are evicted along with the picked one, so no nonce gaps are introduced,
+// and the head of the promoted queue is never touched.
+// Nothing is evicted if not enough slots can be freed.
+// It is called with the locks of the incoming tx account held
+func (p *TxPool) evictUnderpriced(tx *types.Transaction, slots uint64) {
+	var (
+		baseFee = p.GetBaseFee()
+		price   = tx.GetGasPrice(baseFee)
+		planned = make([]*types.Transaction, 0)
+		skipped = make([]*types.Transaction, 0)
+		freed   uint64
+	)
+
+	// pick the cheapest transactions until enough slots are freed
+	for freed < slots {
+		candidate := p.evictables.pop()
+		if candidate == nil {
+			break
+		}
+
+		if !p.isEvictable(candidate) {
+			// stale entry
+			continue
+		}
+
+		if candidate.From() == tx.From() {
+			skipped = append(skipped, candidate)
+
+			continue
+		}
+
+		if candidate.GetGasPrice(baseFee).Cmp(price) >= 0 {
+			skipped = append(skipped, candidate)
+
+			break
+		}
+
+		planned = append(planned, candidate)
+		freed += slotsRequired(candidate)
+	}
+
+	if freed < slots {
+		p.evictables.push(append(skipped, planned...)...)
+
+		return
+	}
+
+	evicted := make([]*types.Transaction, 0, len(planned))
+
+	for _, candidate := range planned {
+		evicted = append(evicted, p.evictTx(p.accounts.get(candidate.From()), candidate)...)
+	}
+
+	// the picked transactions which weren't evicted remain evictable
+	for _, candidate := range planned {
+		if p.isEvictable(candidate) {
+			skipped = append(skipped, candidate)
+		}
+	}
+
+	p.evictables.push(skipped...)
+
+	if len(evicted) == 0 {
+		return
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "evicted_underpriced_tx"}, float32(len(evicted)))
+
+	p.eventManager.signalEvent(proto.EventType_EVICTED, toHash(evicted...)...)
+
+	if p.logger.IsDebug() {
+		p.logger.Debug("evicted underpriced txs", "num", len(evicted), "hash", tx.Hash().String())
+	}
+}
+
+// isEvictable checks if the given transaction is still in the pool
+// and belongs to a non-local account
+func (p *TxPool) isEvictable(tx *types.Transaction) bool {
+	if pooled, ok := p.index.get(tx.Hash()); !ok || pooled != tx {
+		return false
+	}
+
+	account := p.accounts.get(tx.From())
+
+	return account != nil && !account.local.Load()
+}
+
+// evictTx removes the given transaction from the account along with the transactions
+// with a higher nonce, which would be left with a nonce gap otherwise.
+// The head of the promoted queue is never evicted, evicting promoted transactions
+// rolls back the next expected nonce of the account.
+// Nothing is evicted if the account locks are held by someone else,
+// as the caller holds the locks of another account
+func (p *TxPool) evictTx(account *account, tx *types.Transaction) []*types.Transaction {
+	if !account.promoted.tryLock() {
+		return nil
+	}
+	defer account.promoted.unlock()
+
+	if !account.enqueued.tryLock() {
+		return nil
+	}
+	defer account.enqueued.unlock()
+
+	if !account.nonceToTx.tryLock() {
+		return nil
+	}
+	defer account.nonceToTx.unlock()
+
+	if account.nonceToTx.get(tx.Nonce()) != tx || account.promoted.peek() == tx {
+		return nil
+	}
+
+	evicted := make([]*types.Transaction, 0)
+
+	for {
+		var tail *types.Transaction
+
+		switch {
+		case account.enqueued.length() > 0:
+			tail = account.enqueued.tail()
+			account.enqueued.remove(tail)
+		case account.promoted.length() > 1:
+			tail = account.promoted.tail()
+			account.promoted.remove(tail)
+
+			account.setNonce(tail.Nonce())
+			p.updatePending(-1)
+		default:
+			// unreachable as the transaction is not the head of the promoted queue
+			return evicted
+		}
+
+		account.nonceToTx.remove(tail)
+		p.index.remove(tail)
+		p.gauge.decrease(slotsRequired(tail))
+
+		evicted = append(evicted, tail)
+
+		if tail == tx {
+			return evicted
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package txpool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func newPricedTx(addr types.Address, nonce, price uint64) *types.Transaction {
+	tx := newTx(addr, nonce, 1, types.LegacyTxType)
+	tx.SetGasPrice(new(big.Int).SetUint64(price))
+
+	return tx
+}
+
+func TestTxPool_EvictStaleTxs(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	pool.lifetime = time.Hour
+
+	evictedSubscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_EVICTED})
+	defer pool.eventManager.cancelSubscription(evictedSubscription.subscriptionID)
+
+	stale := newPricedTx(addr1, 3, 1)
+	require.NoError(t, pool.addTx(gossip, stale))
+	require.NoError(t, pool.addTx(gossip, newPricedTx(addr2, 3, 1)))
+	require.NoError(t, pool.addTx(local, newPricedTx(addr3, 3, 1)))
+
+	// addr2 is still active, the local addr3 is never evicted
+	expired := time.Now().Add(-2 * pool.lifetime).UnixNano()
+	pool.accounts.get(addr1).lastEnqueued.Store(expired)
+	pool.accounts.get(addr3).lastEnqueued.Store(expired)
+
+	pool.evictStaleTxs()
+
+	require.Equal(t, uint64(0), pool.accounts.get(addr1).enqueued.length())
+	require.Equal(t, uint64(1), pool.accounts.get(addr2).enqueued.length())
+	require.Equal(t, uint64(1), pool.accounts.get(addr3).enqueued.length())
+	require.Equal(t, uint64(2), pool.gauge.read())
+
+	_, exists := pool.index.get(stale.Hash())
+	require.False(t, exists)
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelFn()
+
+	events := waitForEvents(ctx, evictedSubscription, 1)
+	require.Len(t, events, 1)
+	require.Equal(t, stale.Hash().String(), events[0].TxHash)
+}
+
+func TestTxPool_EvictUnderpriced(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPoolWithSlots(4)
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	evictedSubscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_EVICTED})
+	defer pool.eventManager.cancelSubscription(evictedSubscription.subscriptionID)
+
+	cheapest := newPricedTx(addr1, 2, 1)
+
+	// fill the pool
+	require.NoError(t, pool.addTx(gossip, newPricedTx(addr1, 1, 1)))
+	require.NoError(t, pool.addTx(gossip, cheapest))
+	require.NoError(t, pool.addTx(gossip, newPricedTx(addr2, 1, 5)))
+	require.NoError(t, pool.addTx(local, newPricedTx(addr3, 1, 1)))
+	require.Equal(t, pool.gauge.max, pool.gauge.read())
+
+	// the tail tx of addr1 is evicted to make room for the higher paying tx
+	require.NoError(t, pool.addTx(gossip, newPricedTx(addr4, 0, 3)))
+	<-pool.promoteReqCh
+
+	require.Equal(t, pool.gauge.max, pool.gauge.read())
+	require.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+	require.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.peek().Nonce())
+
+	_, exists := pool.index.get(cheapest.Hash())
+	require.False(t, exists)
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelFn()
+
+	events := waitForEvents(ctx, evictedSubscription, 1)
+	require.Len(t, events, 1)
+	require.Equal(t, cheapest.Hash().String(), events[0].TxHash)
+
+	// nothing cheaper than the incoming tx is left
+	require.ErrorIs(t, pool.addTx(gossip, newPricedTx(addr5, 0, 1)), ErrTxPoolOverflow)
+	require.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+}
+
+func TestTxPool_EvictTx(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+
+	account := pool.getOrCreateAccount(addr1)
+	txs := []*types.Transaction{newPricedTx(addr1, 0, 1), newPricedTx(addr1, 1, 1), newPricedTx(addr1, 2, 1)}
+
+	for _, tx := range txs {
+		account.promoted.push(tx)
+		account.nonceToTx.set(tx)
+		pool.index.add(tx)
+	}
+
+	account.setNonce(3)
+	pool.gauge.increase(slotsRequired(txs...))
+
+	// the head of the promoted queue is never evicted
+	require.Empty(t, pool.evictTx(account, txs[0]))
+
+	// the txs with a higher nonce are evicted along
+	require.Equal(t, []*types.Transaction{txs[2], txs[1]}, pool.evictTx(account, txs[1]))
+
+	require.Equal(t, uint64(1), account.getNonce())
+	require.Equal(t, uint64(1), account.promoted.length())
+	require.Nil(t, account.nonceToTx.get(1))
+	require.Nil(t, account.nonceToTx.get(2))
+	require.Equal(t, slotsRequired(txs[0]), pool.gauge.read())
+
+	// the account is skipped while its locks are held
+	tx := newPricedTx(addr1, 1, 1)
+	account.promoted.push(tx)
+	account.nonceToTx.set(tx)
+	pool.index.add(tx)
+
+	account.enqueued.lock(false)
+	require.Empty(t, pool.evictTx(account, tx))
+	account.enqueued.unlock()
+
+	require.Equal(t, []*types.Transaction{tx}, pool.evictTx(account, tx))
+}
+
+func TestTxPool_EvictUnderpriced_RejectedTx(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPoolWithSlots(2)
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	evictedSubscription := pool.eventManager.subscribe([]proto.EventType{proto.EventType_EVICTED})
+	defer pool.eventManager.cancelSubscription(evictedSubscription.subscriptionID)
+
+	// fill the pool
+	require.NoError(t, pool.addTx(gossip, newPricedTx(addr1, 1, 1)))
+	require.NoError(t, pool.addTx(gossip, newPricedTx(addr2, 0, 5)))
+	<-pool.promoteReqCh
+	require.Equal(t, pool.gauge.max, pool.gauge.read())
+
+	// txs paying more than the cheapest one, which are rejected by the pool checks
+	pool.getOrCreateAccount(addr3).setNonce(5)
+
+	require.ErrorIs(t, pool.addTx(gossip, newPricedTx(addr3, 0, 10)), ErrNonceTooLow)
+	require.ErrorIs(t, pool.addTx(gossip, newPricedTx(addr2, 0, 4)), ErrReplacementUnderpriced)
+	require.ErrorIs(t, pool.addTx(gossip, newPricedTx(addr4, 1, 10)), ErrRejectFutureTx)
+
+	// the rejected txs haven't evicted anything
+	require.Equal(t, pool.gauge.max, pool.gauge.read())
+	require.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), time.Second)
+	defer cancelFn()
+
+	require.Empty(t, waitForEvents(ctx, evictedSubscription, 1))
+}
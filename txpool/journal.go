@@ -0,0 +1,213 @@
+package txpool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	errNoActiveJournal = errors.New("no active journal")
+	errJournalClosed   = errors.New("journal closed")
+
+	// maxJournalEntrySize is the upper bound of a single journal entry,
+	// anything above it is considered corrupted (txs are bounded by txMaxSize)
+	maxJournalEntrySize = uint32(txMaxSize)
+)
+
+// journal is an append-only on-disk log of the locally submitted transactions,
+// used to keep them across node restarts.
+// Each entry is encoded as a 4 bytes big endian length followed by the RLP encoded transaction
+type journal struct {
+	lock sync.Mutex
+
+	path   string
+	writer *os.File
+	closed bool
+}
+
+// newJournal creates a journal for the given file path
+func newJournal(path string) *journal {
+	return &journal{path: path}
+}
+
+// load reads the journal file and passes every transaction to the add callback.
+// It returns the number of loaded and dropped transactions
+func (j *journal) load(add func(tx *types.Transaction) error) (int, int, error) {
+	file, err := os.Open(j.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// nothing to load
+			return 0, 0, nil
+		}
+
+		return 0, 0, err
+	}
+	defer file.Close()
+
+	var (
+		reader          = bufio.NewReader(file)
+		loaded, dropped int
+	)
+
+	for {
+		tx, err := readJournalEntry(reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return loaded, dropped, nil
+			}
+
+			return loaded, dropped, err
+		}
+
+		if err := add(tx); err != nil {
+			dropped++
+
+			continue
+		}
+
+		loaded++
+	}
+}
+
+// insert appends the transaction to the journal
+func (j *journal) insert(tx *types.Transaction) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.writer == nil {
+		return errNoActiveJournal
+	}
+
+	return writeJournalEntry(j.writer, tx)
+}
+
+// rotate regenerates the journal from the given transactions,
+// dropping the ones which are not in the pool anymore
+func (j *journal) rotate(txs []*types.Transaction) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	// a rotation racing with the shutdown must not reopen the file
+	if j.closed {
+		return errJournalClosed
+	}
+
+	if j.writer != nil {
+		if err := j.writer.Close(); err != nil {
+			return err
+		}
+
+		j.writer = nil
+	}
+
+	tmpPath := j.path + ".new"
+
+	replacement, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(replacement)
+
+	for _, tx := range txs {
+		if err := writeJournalEntry(writer, tx); err != nil {
+			replacement.Close()
+
+			return err
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		replacement.Close()
+
+		return err
+	}
+
+	if err := replacement.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		return err
+	}
+
+	sink, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	j.writer = sink
+
+	return nil
+}
+
+// close flushes the journal contents to disk and closes the file.
+// The journal can't be rotated afterwards
+func (j *journal) close() error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	j.closed = true
+
+	if j.writer == nil {
+		return nil
+	}
+
+	err := j.writer.Close()
+	j.writer = nil
+
+	return err
+}
+
+func writeJournalEntry(w io.Writer, tx *types.Transaction) error {
+	raw := tx.MarshalRLP()
+
+	var size [4]byte
+
+	binary.BigEndian.PutUint32(size[:], uint32(len(raw)))
+
+	if _, err := w.Write(size[:]); err != nil {
+		return err
+	}
+
+	_, err := w.Write(raw)
+
+	return err
+}
+
+func readJournalEntry(r io.Reader) (*types.Transaction, error) {
+	var size [4]byte
+
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return nil, err
+	}
+
+	length := binary.BigEndian.Uint32(size[:])
+	if length > maxJournalEntrySize {
+		return nil, fmt.Errorf("journal entry too large: %d bytes", length)
+	}
+
+	raw := make([]byte, length)
+	if _, err := io.ReadFull(r, raw); err != nil {
+		if errors.Is(err, io.EOF) {
+			// the entry has been partially written
+			return nil, io.ErrUnexpectedEOF
+		}
+
+		return nil, err
+	}
+
+	tx := &types.Transaction{}
+	if err := tx.UnmarshalRLP(raw); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
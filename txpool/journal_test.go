@@ -0,0 +1,150 @@
+package txpool
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestJournal_InsertRotateLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "txpool.journal")
+	j := newJournal(path)
+
+	// nothing to load from a missing journal
+	loaded, dropped, err := j.load(func(tx *types.Transaction) error { return nil })
+	require.NoError(t, err)
+	require.Zero(t, loaded)
+	require.Zero(t, dropped)
+
+	// inserts are rejected before the journal is rotated
+	require.ErrorIs(t, j.insert(newTx(addr1, 0, 1, types.LegacyTxType)), errNoActiveJournal)
+
+	txs := []*types.Transaction{
+		newTx(addr1, 0, 1, types.LegacyTxType),
+		newTx(addr1, 1, 1, types.DynamicFeeTxType),
+	}
+
+	require.NoError(t, j.rotate(txs))
+
+	inserted := newTx(addr2, 0, 2, types.LegacyTxType)
+	require.NoError(t, j.insert(inserted))
+	require.NoError(t, j.close())
+
+	txs = append(txs, inserted)
+	read := make([]*types.Transaction, 0, len(txs))
+
+	loaded, dropped, err = j.load(func(tx *types.Transaction) error {
+		read = append(read, tx)
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, len(txs), loaded)
+	require.Zero(t, dropped)
+
+	for i, tx := range txs {
+		require.Equal(t, tx.MarshalRLP(), read[i].MarshalRLP())
+	}
+
+	// a closed journal is not reopened by a rotation
+	require.ErrorIs(t, j.rotate(txs), errJournalClosed)
+
+	// rotation drops everything which is not passed in
+	j = newJournal(path)
+	require.NoError(t, j.rotate(txs[:1]))
+	require.NoError(t, j.close())
+
+	loaded, _, err = j.load(func(tx *types.Transaction) error { return nil })
+	require.NoError(t, err)
+	require.Equal(t, 1, loaded)
+}
+
+func TestJournal_LoadCorrupted(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "txpool.journal")
+	j := newJournal(path)
+
+	require.NoError(t, j.rotate([]*types.Transaction{newTx(addr1, 0, 1, types.LegacyTxType)}))
+	require.NoError(t, j.close())
+
+	// simulate a partially written entry
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+
+	_, err = file.Write([]byte{0, 0, 1, 0, 0xc0})
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	loaded, _, err := j.load(func(tx *types.Transaction) error { return nil })
+	require.Error(t, err)
+	require.Equal(t, 1, loaded)
+}
+
+func TestTxPool_JournalReplay(t *testing.T) {
+	t.Parallel()
+
+	const maxAccountEnqueued = 2
+
+	signer := crypto.NewLondonSigner(100)
+	key, addr := tests.GenerateKeyAndAddr(t)
+	path := filepath.Join(t.TempDir(), "txpool.journal")
+
+	signTx := func(tx *types.Transaction) *types.Transaction {
+		signed, err := signer.SignTx(tx, key)
+		require.NoError(t, err)
+
+		return signed
+	}
+
+	// journal future txs which exceed the enqueued limit of the account
+	j := newJournal(path)
+	require.NoError(t, j.rotate([]*types.Transaction{
+		signTx(newTx(addr, 1, 1, types.LegacyTxType)),
+		signTx(newTx(addr, 2, 1, types.LegacyTxType)),
+		signTx(newTx(addr, 3, 1, types.LegacyTxType)),
+	}))
+	require.NoError(t, j.close())
+
+	pool, err := NewTxPool(
+		hclog.NewNullLogger(),
+		getDefaultEnabledForks(),
+		defaultMockStore{DefaultHeader: mockHeader},
+		nil,
+		nil,
+		&Config{
+			PriceLimit:         defaultPriceLimit,
+			MaxSlots:           defaultMaxSlots,
+			MaxAccountEnqueued: maxAccountEnqueued,
+			ChainID:            big.NewInt(100),
+			JournalPath:        path,
+		},
+	)
+	require.NoError(t, err)
+
+	pool.SetSigner(signer)
+	pool.Start()
+
+	require.Equal(t, uint64(maxAccountEnqueued), pool.accounts.get(addr).enqueued.length())
+	require.True(t, pool.accounts.get(addr).local.Load())
+
+	// newly added local txs are appended to the rotated journal
+	require.NoError(t, pool.addTx(local, signTx(newTx(addr, 0, 1, types.LegacyTxType))))
+
+	pool.Close()
+
+	loaded, dropped, err := j.load(func(tx *types.Transaction) error { return nil })
+	require.NoError(t, err)
+	require.Equal(t, maxAccountEnqueued+1, loaded)
+	require.Zero(t, dropped)
+}
@@ -54,6 +54,17 @@ func (p *TxPool) GetBaseFee() uint64 {
 	return atomic.LoadUint64(&p.baseFee)
 }
 
+// GetPriceLimit returns the lower threshold for the gas price of the transactions accepted by the pool
+func (p *TxPool) GetPriceLimit() uint64 {
+	return atomic.LoadUint64(&p.priceLimit)
+}
+
+// SetPriceLimit sets the lower threshold for the gas price,
+// it applies only to the transactions added after the change
+func (p *TxPool) SetPriceLimit(priceLimit uint64) {
+	atomic.StoreUint64(&p.priceLimit, priceLimit)
+}
+
 // SetBaseFee calculates base fee from the (current) header and sets value into baseFee field
 func (p *TxPool) SetBaseFee(header *types.Header) {
 	atomic.StoreUint64(&p.baseFee, p.store.CalculateBaseFee(header))
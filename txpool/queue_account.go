@@ -36,6 +36,17 @@ func (q *accountQueue) lock(write bool) {
 	q.wLock.Store(write)
 }
 
+// tryLock acquires the write lock if it is not held, reporting whether it succeeded
+func (q *accountQueue) tryLock() bool {
+	if !q.TryLock() {
+		return false
+	}
+
+	q.wLock.Store(true)
+
+	return true
+}
+
 func (q *accountQueue) unlock() {
 	if q.wLock.Swap(false) {
 		q.Unlock()
@@ -95,6 +106,33 @@ func (q *accountQueue) pop() *types.Transaction {
 	return transaction
 }
 
+// tail returns the transaction with the highest nonce without removing it.
+func (q *accountQueue) tail() *types.Transaction {
+	var last *types.Transaction
+
+	for _, tx := range q.queue {
+		if last == nil || tx.Nonce() > last.Nonce() {
+			last = tx
+		}
+	}
+
+	return last
+}
+
+// remove removes the given transaction from the queue.
+// Returns false if the transaction is not present.
+func (q *accountQueue) remove(tx *types.Transaction) bool {
+	for i, x := range q.queue {
+		if x == tx {
+			heap.Remove(&q.queue, i)
+
+			return true
+		}
+	}
+
+	return false
+}
+
 // length returns the number of transactions in the queue.
 func (q *accountQueue) length() uint64 {
 	return uint64(q.queue.Len())
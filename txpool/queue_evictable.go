@@ -0,0 +1,123 @@
+package txpool
+
+import (
+	"container/heap"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// evictableQueue indexes the non-local transactions by gas price, starting from the cheapest,
+// so the eviction from a full pool doesn't need to go through all the accounts.
+// Transactions leaving the pool are not removed from it: the stale entries
+// are skipped when popped and dropped whenever the queue is reheaped
+type evictableQueue struct {
+	lock  sync.Mutex
+	queue minPriceQueue
+}
+
+// newEvictableQueue creates an empty evictable queue
+func newEvictableQueue() *evictableQueue {
+	return &evictableQueue{}
+}
+
+// push adds the given transactions to the queue
+func (q *evictableQueue) push(txs ...*types.Transaction) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for _, tx := range txs {
+		heap.Push(&q.queue, tx)
+	}
+}
+
+// pop removes the cheapest transaction from the queue or returns nil if the queue is empty
+func (q *evictableQueue) pop() *types.Transaction {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.queue.Len() == 0 {
+		return nil
+	}
+
+	tx, _ := heap.Pop(&q.queue).(*types.Transaction)
+
+	return tx
+}
+
+// reheap drops the transactions which are not alive anymore
+// and reorders the rest by the gas price under the given base fee
+func (q *evictableQueue) reheap(baseFee uint64, alive func(tx *types.Transaction) bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	txs := q.queue.txs[:0]
+
+	for _, tx := range q.queue.txs {
+		if alive(tx) {
+			txs = append(txs, tx)
+		}
+	}
+
+	// release the references held by the dropped entries
+	for i := len(txs); i < len(q.queue.txs); i++ {
+		q.queue.txs[i] = nil
+	}
+
+	q.queue.txs = txs
+	q.queue.baseFee = baseFee
+
+	heap.Init(&q.queue)
+}
+
+// length returns the number of entries in the queue, including the stale ones
+func (q *evictableQueue) length() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return q.queue.Len()
+}
+
+// transactions sorted by gas price (ascending),
+// the ones with a higher nonce go first on the same price
+type minPriceQueue struct {
+	baseFee uint64
+	txs     []*types.Transaction
+}
+
+/* Queue methods required by the heap interface */
+
+func (q *minPriceQueue) Len() int {
+	return len(q.txs)
+}
+
+func (q *minPriceQueue) Swap(i, j int) {
+	q.txs[i], q.txs[j] = q.txs[j], q.txs[i]
+}
+
+func (q *minPriceQueue) Less(i, j int) bool {
+	if c := q.txs[i].GetGasPrice(q.baseFee).Cmp(q.txs[j].GetGasPrice(q.baseFee)); c != 0 {
+		return c < 0
+	}
+
+	return q.txs[i].Nonce() > q.txs[j].Nonce()
+}
+
+func (q *minPriceQueue) Push(x interface{}) {
+	transaction, ok := x.(*types.Transaction)
+	if !ok {
+		return
+	}
+
+	q.txs = append(q.txs, transaction)
+}
+
+func (q *minPriceQueue) Pop() interface{} {
+	old := q.txs
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	q.txs = old[0 : n-1]
+
+	return x
+}
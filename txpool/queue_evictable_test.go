@@ -0,0 +1,39 @@
+package txpool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestEvictableQueue(t *testing.T) {
+	t.Parallel()
+
+	var (
+		cheapest = newPricedTx(addr1, 1, 1)
+		cheap    = newPricedTx(addr1, 0, 1)
+		pricey   = newPricedTx(addr2, 0, 5)
+		stale    = newPricedTx(addr3, 0, 2)
+	)
+
+	q := newEvictableQueue()
+	q.push(pricey, cheap, stale, cheapest)
+
+	// the higher nonce goes first on the same price
+	require.Equal(t, cheapest, q.pop())
+	require.Equal(t, cheap, q.pop())
+
+	q.push(cheapest, cheap)
+
+	// the stale entries are dropped on reheap
+	q.reheap(0, func(tx *types.Transaction) bool { return tx != stale })
+	require.Equal(t, 3, q.length())
+
+	for _, expected := range []*types.Transaction{cheapest, cheap, pricey} {
+		require.Equal(t, expected, q.pop())
+	}
+
+	require.Nil(t, q.pop())
+}
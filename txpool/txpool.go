@@ -102,6 +102,16 @@ type Config struct {
 	MaxAccountEnqueued uint64
 	ChainID            *big.Int
 	PeerID             peer.ID
+
+	// JournalPath is the path of the local transactions journal, empty value disables it
+	JournalPath string
+	// JournalRotation is the time interval to regenerate the local transactions journal,
+	// zero value disables the periodic regeneration
+	JournalRotation time.Duration
+
+	// Lifetime is the maximum time the enqueued transactions of an inactive
+	// non-local account are kept in the pool, zero value disables the eviction
+	Lifetime time.Duration
 }
 
 /* All requests are passed to the main loop
@@ -189,6 +199,18 @@ type TxPool struct {
 
 	// localPeerID is the peer ID of the local node that is running the txpool
 	localPeerID peer.ID
+
+	// journal of the local transactions, nil if disabled
+	journal *journal
+
+	// journalRotation is the time interval to regenerate the journal
+	journalRotation time.Duration
+
+	// lifetime is the maximum time the enqueued transactions are kept in the pool
+	lifetime time.Duration
+
+	// evictables are the non-local transactions ordered by price for the eviction from a full pool
+	evictables *evictableQueue
 }
 
 // NewTxPool returns a new pool for processing incoming transactions.
@@ -211,6 +233,8 @@ func NewTxPool(
 		priceLimit:  config.PriceLimit,
 		chainID:     config.ChainID,
 		localPeerID: config.PeerID,
+		lifetime:    config.Lifetime,
+		evictables:  newEvictableQueue(),
 
 		//	main loop channels
 		promoteReqCh: make(chan promoteRequest),
@@ -221,6 +245,11 @@ func NewTxPool(
 	// Attach the event manager
 	pool.eventManager = newEventManager(pool.logger)
 
+	if config.JournalPath != "" {
+		pool.journal = newJournal(config.JournalPath)
+		pool.journalRotation = config.JournalRotation
+	}
+
 	if network != nil {
 		// subscribe to the gossip protocol
 		topic, err := network.NewTopic(topicNameV1, &proto.Txn{})
@@ -281,12 +310,86 @@ func (p *TxPool) Start() {
 			}
 		}
 	}()
+
+	if p.lifetime > 0 {
+		go p.runLifetimeEviction()
+	}
+
+	if p.journal != nil {
+		// replay the journal once the tx pipeline is running,
+		// so the loaded transactions can be promoted
+		p.loadJournal()
+
+		if p.journalRotation > 0 {
+			go p.runJournalRotation()
+		}
+	}
 }
 
 // Close shuts down the pool's main loop.
 func (p *TxPool) Close() {
 	p.eventManager.Close()
 	close(p.shutdownCh)
+
+	if p.journal != nil {
+		if err := p.journal.close(); err != nil {
+			p.logger.Error("failed to close journal", "err", err)
+		}
+	}
+}
+
+// loadJournal adds the transactions from the journal to the pool
+// and regenerates the journal with the accepted ones
+func (p *TxPool) loadJournal() {
+	loaded, dropped, err := p.journal.load(func(tx *types.Transaction) error {
+		if err := p.addTx(local, tx); err != nil {
+			if p.logger.IsDebug() {
+				p.logger.Debug("dropped journaled tx", "hash", tx.Hash().String(), "err", err)
+			}
+
+			return err
+		}
+
+		p.publish(tx)
+
+		return nil
+	})
+	if err != nil {
+		p.logger.Error("failed to load journal", "path", p.journal.path, "err", err)
+	}
+
+	p.logger.Info("loaded journaled txs", "loaded", loaded, "dropped", dropped)
+
+	p.rotateJournal()
+}
+
+// runJournalRotation periodically regenerates the journal from the local transactions in the pool
+func (p *TxPool) runJournalRotation() {
+	ticker := time.NewTicker(p.journalRotation)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case <-ticker.C:
+			p.rotateJournal()
+		}
+	}
+}
+
+func (p *TxPool) rotateJournal() {
+	localTxs := p.accounts.localTxs()
+
+	if err := p.journal.rotate(localTxs); err != nil {
+		p.logger.Error("failed to rotate journal", "path", p.journal.path, "err", err)
+
+		return
+	}
+
+	if p.logger.IsDebug() {
+		p.logger.Debug("regenerated journal", "txs", len(localTxs))
+	}
 }
 
 // SetSigner sets the signer the pool will use
@@ -309,21 +412,27 @@ func (p *TxPool) AddTx(tx *types.Transaction) error {
 		return err
 	}
 
-	// broadcast the transaction only if a topic
-	// subscription is present
-	if p.topic != nil {
-		tx := &proto.Txn{
-			Raw: &any.Any{
-				Value: tx.MarshalRLP(),
-			},
-		}
+	p.publish(tx)
 
-		if err := p.topic.Publish(tx); err != nil {
-			p.logger.Error("failed to topic tx", "err", err)
-		}
+	return nil
+}
+
+// publish broadcasts the transaction to the network
+// only if a topic subscription is present
+func (p *TxPool) publish(tx *types.Transaction) {
+	if p.topic == nil {
+		return
 	}
 
-	return nil
+	msg := &proto.Txn{
+		Raw: &any.Any{
+			Value: tx.MarshalRLP(),
+		},
+	}
+
+	if err := p.topic.Publish(msg); err != nil {
+		p.logger.Error("failed to topic tx", "err", err)
+	}
 }
 
 // Prepare generates all the transactions
@@ -535,6 +644,9 @@ func (p *TxPool) ResetWithBlock(block *types.Block) {
 
 	p.SetBaseFee(block.Header)
 
+	// drop the txs which left the pool and reorder the rest under the new base fee
+	p.evictables.reheap(p.GetBaseFee(), p.isEvictable)
+
 	// reset accounts with the new state
 	p.resetAccounts(stateNonces)
 
@@ -601,6 +713,7 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	stateRoot := currentHeader.StateRoot
 	latestBlockGasLimit := currentHeader.GasLimit
 	baseFee := p.GetBaseFee() // base fee is calculated for the next block
+	priceLimit := p.GetPriceLimit()
 
 	if tx.Type() == types.AccessListTxType {
 		// Reject access list tx if berlin hardfork(eip-2930) is not enabled
@@ -611,13 +724,13 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		}
 
 		// check if the given tx is not underpriced (same as Legacy approach)
-		if tx.GetGasPrice(baseFee).Cmp(big.NewInt(0).SetUint64(p.priceLimit)) < 0 {
+		if tx.GetGasPrice(baseFee).Cmp(big.NewInt(0).SetUint64(priceLimit)) < 0 {
 			metrics.IncrCounter([]string{txPoolMetrics, "underpriced_tx"}, 1)
 
 			p.logger.Debug("access list tx is undepriced",
 				"gasPrice", tx.GetGasPrice(baseFee).String(),
 				"baseFee", baseFee,
-				"priceLimit", p.priceLimit)
+				"priceLimit", priceLimit)
 
 			return ErrUnderpriced
 		}
@@ -635,7 +748,7 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 
 			p.logger.Debug("dynamic tx is undepriced because no fee cap or tip cap is set",
 				"baseFee", baseFee,
-				"priceLimit", p.priceLimit)
+				"priceLimit", priceLimit)
 
 			return ErrUnderpriced
 		}
@@ -665,7 +778,7 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 			p.logger.Debug("dynamic tx is undepriced",
 				"gasFeeCap", tx.GasFeeCap().String(),
 				"baseFee", baseFee,
-				"priceLimit", p.priceLimit)
+				"priceLimit", priceLimit)
 
 			return ErrUnderpriced
 		}
@@ -677,20 +790,20 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 			p.logger.Debug("legacy tx is undepriced on london fork",
 				"gasPrice", tx.GasPrice().String(),
 				"baseFee", baseFee,
-				"priceLimit", p.priceLimit)
+				"priceLimit", priceLimit)
 
 			return ErrUnderpriced
 		}
 	}
 
-	if tx.GetGasPrice(baseFee).Cmp(new(big.Int).SetUint64(p.priceLimit)) < 0 {
+	if tx.GetGasPrice(baseFee).Cmp(new(big.Int).SetUint64(priceLimit)) < 0 {
 		// Make sure that the transaction is not underpriced
 		metrics.IncrCounter([]string{txPoolMetrics, "underpriced_tx"}, 1)
 
 		p.logger.Debug("tx is undepriced in regards to price limit",
 			"gasPrice", tx.GetGasPrice(baseFee).String(),
 			"baseFee", baseFee,
-			"priceLimit", p.priceLimit)
+			"priceLimit", priceLimit)
 
 		return ErrUnderpriced
 	}
@@ -884,7 +997,14 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 	if slotsAllocated > slotsFreed {
 		slotsIncreased = slotsAllocated - slotsFreed
 		if !p.gauge.increaseWithinLimit(slotsIncreased) {
-			return ErrTxPoolOverflow
+			// the tx is acceptable, make room for it by evicting cheaper txs
+			if required := p.gauge.read() + slotsIncreased; required > p.gauge.max {
+				p.evictUnderpriced(tx, required-p.gauge.max)
+			}
+
+			if !p.gauge.increaseWithinLimit(slotsIncreased) {
+				return ErrTxPoolOverflow
+			}
 		}
 	}
 
@@ -911,6 +1031,19 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 
 	account.enqueue(tx, oldTxWithSameNonce != nil) // add or replace tx into account
 
+	if origin == local {
+		account.local.Store(true)
+
+		if p.journal != nil {
+			if err := p.journal.insert(tx); err != nil && !errors.Is(err, errNoActiveJournal) {
+				p.logger.Error("failed to journal local tx", "hash", tx.Hash().String(), "err", err)
+			}
+		}
+	} else if !account.local.Load() {
+		// only the txs of non-local accounts can be evicted
+		p.evictables.push(tx)
+	}
+
 	go p.invokePromotion(tx, tx.Nonce() <= accountNonce) // don't signal promotion for higher nonce txs
 
 	return nil
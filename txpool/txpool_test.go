@@ -279,7 +279,7 @@ func TestAddTxErrors(t *testing.T) {
 
 		pool := setupPool()
 
-		pool.priceLimit = 1000000
+		pool.SetPriceLimit(1000000)
 
 		tx := newTx(defaultAddr, 0, 1, types.LegacyTxType) // gasPrice == 1
 		tx = signTx(tx)
@@ -249,12 +249,19 @@ func (t *TxRelayerImpl) sendTransactionLocked(txn *types.Transaction, key crypto
 		txn.SetGas(gasLimit)
 	}
 
-	signer := crypto.NewLondonSigner(
-		chainID.Uint64())
-	signedTxn, err := signer.SignTxWithCallback(txn,
-		func(hash types.Hash) (sig []byte, err error) {
-			return key.Sign(hash.Bytes())
-		})
+	var signedTxn *types.Transaction
+
+	if txKey, ok := key.(crypto.TxKey); ok {
+		signedTxn, err = txKey.SignTx(txn, chainID.Uint64())
+	} else {
+		signer := crypto.NewLondonSigner(
+			chainID.Uint64())
+		signedTxn, err = signer.SignTxWithCallback(txn,
+			func(hash types.Hash) (sig []byte, err error) {
+				return key.Sign(hash.Bytes())
+			})
+	}
+
 	if err != nil {
 		return types.ZeroHash, err
 	}
@@ -0,0 +1,18 @@
+package types
+
+// EquivocationEvidence is the proof that the validator signed two conflicting consensus messages
+// of the same type for the same height and round
+type EquivocationEvidence struct {
+	Validator   Address
+	Height      uint64
+	Round       uint64
+	MessageType string
+
+	// FirstMessage and SecondMessage are the protobuf encoded signed IBFT messages,
+	// so the evidence can be verified by anyone knowing the validator address
+	FirstMessage  []byte
+	SecondMessage []byte
+
+	// DetectedAt is the unix timestamp of the detection
+	DetectedAt uint64
+}
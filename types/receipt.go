@@ -107,6 +107,29 @@ func (b *Bloom) setEncode(hasher *keccak.Keccak, h []byte) {
 	}
 }
 
+// IsBitSet checks if the bit at the given global position [0..BloomByteLength*8-1] is set
+func (b *Bloom) IsBitSet(bit uint) bool {
+	return b[BloomByteLength-1-bit/8]&(1<<(bit%8)) != 0
+}
+
+// BloomBits returns the global positions of the bits that the given data sets in the Bloom filter
+func BloomBits(data []byte) [3]uint {
+	hasher := keccak.DefaultKeccakPool.Get()
+	defer keccak.DefaultKeccakPool.Put(hasher)
+
+	hasher.Reset()
+	hasher.Write(data) //nolint:errcheck
+	buf := hasher.Read()
+
+	var bits [3]uint
+
+	for i := 0; i < 6; i += 2 {
+		bits[i/2] = (uint(buf[i+1]) + (uint(buf[i]) << 8)) & (BloomByteLength*8 - 1)
+	}
+
+	return bits
+}
+
 // IsLogInBloom checks if the log has a possible presence in the bloom filter
 func (b *Bloom) IsLogInBloom(log *Log) bool {
 	hasher := keccak.DefaultKeccakPool.Get()